@@ -29,7 +29,7 @@ yutu activity list --channelId UC_x5XG1OV2P6uZZ5FSM9Ttw --maxResults 10
 yutu activity list --publishedAfter 2024-01-01T00:00:00Z --output json`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -50,12 +50,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","contentDetails"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -88,7 +84,7 @@ func init() {
 		&parts, "parts", "p", []string{"id", "snippet", "contentDetails"},
 		pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -96,18 +92,19 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := activity.NewActivity(
-			activity.WithChannelId(channelId),
-			activity.WithFor(activityFor),
-			activity.WithMaxResults(maxResults),
-			activity.WithPublishedAfter(publishedAfter),
-			activity.WithPublishedBefore(publishedBefore),
-			activity.WithRegionCode(regionCode),
-			activity.WithParts(parts),
-			activity.WithOutput(output),
+			append(
+				cmd.ListOptions[activity.Option](c),
+				activity.WithChannelId(channelId),
+				activity.WithFor(activityFor),
+				activity.WithMaxResults(maxResults),
+				activity.WithPublishedAfter(publishedAfter),
+				activity.WithPublishedBefore(publishedBefore),
+				activity.WithRegionCode(regionCode),
+				activity.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
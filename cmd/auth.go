@@ -5,25 +5,62 @@ package cmd
 
 import (
 	"fmt"
-	"log/slog"
-	"os"
+	"strings"
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 const (
-	authShort  = "Authenticate with YouTube APIs"
-	authLong   = "Authenticate with YouTube APIs to access and manage YouTube resources."
-	credUsage  = "Path to client secret file, or Base64 encoded string, or JSON string (env: YUTU_CREDENTIAL)"
-	cacheUsage = "Path to token cache file, or Base64 encoded string, or JSON string (env: YUTU_CACHE_TOKEN)"
+	authShort   = "Authenticate with YouTube APIs"
+	authLong    = "Authenticate with YouTube APIs to access and manage YouTube resources. Without a subcommand, a cached token is reused and the authorization flow only runs when there is none."
+	credUsage   = "Path to client secret file, or Base64 encoded string, or JSON string (env: YUTU_CREDENTIAL, default: client_secret.json)"
+	cacheUsage  = "Path to token cache file, or Base64 encoded string, or JSON string (env: YUTU_CACHE_TOKEN, default: youtube.token.json)"
+	scopesUsage = "Scopes to request: full, readonly, or scope URLs"
+	deviceUsage = "Sign in by entering a code on another device, for machines without a browser. Without --scopes, only the youtube scope is asked for, as Google refuses the others to this flow"
+
+	loginShort   = "Sign in and cache a new token"
+	loginLong    = "Run the authorization flow even if a token is cached, and cache the new token. Use --scopes readonly on machines that must only read: list commands keep working, while YouTube refuses changes."
+	loginExample = `# Sign in with the scopes every command needs
+yutu auth login
+# Sign in for read-only use
+yutu auth login --scopes readonly
+# Sign in on a headless server by entering a code on another device
+yutu auth login --device`
+	statusShort   = "Show the cached token"
+	statusLong    = "Show which account and channel the cached token belongs to, the scopes it was granted, when it expires, and whether its refresh token still works. Exits with the auth error code when it does not."
+	statusExample = `# Show the cached token
+yutu auth status
+# Check a profile's token in JSON format
+yutu --profile gaming auth status --output json`
+	revokeShort   = "Revoke the cached token and delete it"
+	revokeLong    = "Revoke the cached token at Google, which signs out every copy of it, and delete the token cache."
+	revokeExample = `# Revoke the cached token
+yutu auth revoke`
+	logoutShort   = "Delete the cached token"
+	logoutLong    = "Delete the token cache, or have the credential helper erase the token, without revoking it. The token stays valid at Google until it expires or is revoked."
+	logoutExample = `# Delete the cached token
+yutu auth logout`
+
+	statusFormat = `Token cache:  %s
+Account:      %s
+Channel:      %s (%s)
+Scopes:       %s
+Expiry:       %s
+Refreshable:  %t %s
+`
 )
 
 var (
 	credential string
 	cacheToken string
 	authPort   int
+	scopes     []string
+	device     bool
 )
 
 var authCmd = &cobra.Command{
@@ -31,28 +68,137 @@ var authCmd = &cobra.Command{
 	Short: authShort,
 	Long:  authLong,
 	Run: func(cmd *cobra.Command, _ []string) {
-		redirectURL := fmt.Sprintf("http://localhost:%d", authPort)
-		if _, err := auth.NewY2BService(
-			auth.WithCredential(credential, pkg.Root.FS()),
+		if _, err := newAuthService().GetService(); err != nil {
+			utils.HandleCmdError(authFailure(err), cmd)
+		}
+	},
+}
+
+var loginCmd = &cobra.Command{
+	Use:     "login",
+	Short:   loginShort,
+	Long:    loginLong,
+	Example: loginExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		resolved, err := auth.ResolveScopes(scopes)
+		if err != nil {
+			utils.HandleCmdError(failure.New(failure.InvalidArgument, err), cmd)
+			return
+		}
+		if device && len(scopes) == 0 {
+			resolved = auth.DeviceScopes
+		}
+		s := newAuthService(auth.WithScopes(resolved))
+		if err := s.Login(); err != nil {
+			utils.HandleCmdError(authFailure(err), cmd)
+			return
+		}
+		_, _ = fmt.Fprintf(
+			cmd.OutOrStdout(), "Signed in with scopes %s\n",
+			strings.Join(resolved, " "),
+		)
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   statusShort,
+	Long:    statusLong,
+	Example: statusExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		status, err := newAuthService().Status()
+		if err != nil {
+			utils.HandleCmdError(authFailure(err), cmd)
+			return
+		}
+		common.PrintResult(
+			output, status, cmd.OutOrStdout(), statusFormat,
+			status.TokenFile, status.Account, status.ChannelTitle, status.ChannelId,
+			strings.Join(status.Scopes, " "), status.Expiry, status.Refreshable,
+			status.RefreshError,
+		)
+		// The report already says why the token cannot be refreshed.
+		if !status.Refreshable {
+			utils.Exit(failure.AuthError.ExitCode())
+		}
+	},
+}
+
+var revokeCmd = &cobra.Command{
+	Use:     "revoke",
+	Short:   revokeShort,
+	Long:    revokeLong,
+	Example: revokeExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		s := auth.NewY2BService(
 			auth.WithCacheToken(cacheToken, pkg.Root.FS()),
-			auth.WithRedirectURL(redirectURL),
-		).GetService(); err != nil {
-			slog.Error("authentication failed", "error", err)
-			os.Exit(1)
+		).(auth.Manager)
+		if err := s.Revoke(); err != nil {
+			utils.HandleCmdError(authFailure(err), cmd)
+			return
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Token revoked")
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:     "logout",
+	Short:   logoutShort,
+	Long:    logoutLong,
+	Example: logoutExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		s := auth.NewY2BService(
+			auth.WithCacheToken(cacheToken, pkg.Root.FS()),
+		).(auth.Manager)
+		if err := s.Logout(); err != nil {
+			utils.HandleCmdError(authFailure(err), cmd)
+			return
 		}
+		if s.TokenFile() == "" {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Erased the token kept by the credential helper")
+			return
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Deleted %s\n", s.TokenFile())
 	},
 }
 
+// authFailure classifies err, counting an error that fits no other class,
+// such as a missing client secret or a refused token, as an auth error.
+func authFailure(err error) error {
+	if failure.Classify(err).Class == failure.Unknown {
+		return failure.New(failure.AuthError, err)
+	}
+	return err
+}
+
+func newAuthService(opts ...auth.Option) auth.Manager {
+	redirectURL := fmt.Sprintf("http://localhost:%d", authPort)
+	opts = append(
+		[]auth.Option{
+			auth.WithCredential(credential, pkg.Root.FS()),
+			auth.WithCacheToken(cacheToken, pkg.Root.FS()),
+			auth.WithRedirectURL(redirectURL),
+			auth.WithDevice(device),
+		}, opts...,
+	)
+	return auth.NewY2BService(opts...).(auth.Manager)
+}
+
 func init() {
 	RootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(loginCmd, statusCmd, revokeCmd, logoutCmd)
 
-	authCmd.Flags().StringVarP(
-		&credential, "credential", "c", "client_secret.json", credUsage,
+	authCmd.PersistentFlags().StringVarP(
+		&credential, "credential", "c", "", credUsage,
 	)
-	authCmd.Flags().StringVarP(
-		&cacheToken, "cacheToken", "t", "youtube.token.json", cacheUsage,
+	authCmd.PersistentFlags().StringVarP(
+		&cacheToken, "cacheToken", "t", "", cacheUsage,
 	)
-	authCmd.Flags().IntVarP(
+	authCmd.PersistentFlags().IntVarP(
 		&authPort, "port", "p", 8216, "Port for OAuth redirect URL",
 	)
+	authCmd.PersistentFlags().BoolVar(&device, "device", false, deviceUsage)
+	loginCmd.Flags().StringSliceVar(&scopes, "scopes", []string{}, scopesUsage)
+	statusCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
 }
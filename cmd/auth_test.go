@@ -0,0 +1,34 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"google.golang.org/api/googleapi"
+)
+
+func TestAuthFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want failure.Class
+	}{
+		{name: "unclassified", err: errors.New("failed to refresh token"), want: failure.AuthError},
+		{name: "not confirmed", err: utils.ErrNotConfirmed, want: failure.InvalidArgument},
+		{
+			name: "api", err: fmt.Errorf("failed to look up channel: %w", &googleapi.Error{Code: 403}),
+			want: failure.Forbidden,
+		},
+	}
+	for _, tt := range tests {
+		if got := failure.Classify(authFailure(tt.err)).Class; got != tt.want {
+			t.Errorf("authFailure(%s) class = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
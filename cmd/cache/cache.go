@@ -0,0 +1,27 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	short = "Manage the local response cache"
+	long  = "Manage the local response cache. With --cache-ttl or YUTU_CACHE_TTL set, the responses of list calls are kept in a directory next to the token cache and served again without spending quota until they are older than the TTL, after which they are revalidated with their ETag."
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: short,
+	Long:  long,
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(cacheCmd)
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/cache"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	clearTool           = "cache-clear"
+	clearShort          = "Remove cached responses"
+	clearLong           = "Remove cached list responses, all of them along with the hit counters, or only those of some resources. Use this tool when data changed outside yutu and must be read fresh."
+	clearResourcesUsage = "API resources to clear, e.g. videos,playlists, all when empty"
+	clearExample        = `# Remove every cached response
+yutu cache clear
+# Remove only cached video and playlist lists
+yutu cache clear --resources videos,playlists`
+)
+
+var resources []string
+
+var clearInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{},
+	Properties: map[string]*jsonschema.Schema{
+		"resources": {
+			Type: "array", Description: clearResourcesUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "silent"},
+			Description: pkg.SilentUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: clearTool, Title: clearShort, Description: clearLong,
+			InputSchema: clearInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    false,
+			},
+		}, cobramcp.GenToolHandler(
+			clearTool, func(input cache.Cache, writer io.Writer) error {
+				return input.Clear(writer)
+			},
+		),
+	)
+	cacheCmd.AddCommand(clearCmd)
+
+	clearCmd.Flags().StringSliceVar(
+		&resources, "resources", []string{}, clearResourcesUsage,
+	)
+	clearCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
+}
+
+var clearCmd = &cobra.Command{
+	Use:     "clear",
+	Short:   clearShort,
+	Long:    clearLong,
+	Example: clearExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		input := cache.NewCache(
+			cache.WithResources(resources),
+			cache.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Clear(cmd.OutOrStdout()), cmd)
+	},
+}
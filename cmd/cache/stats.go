@@ -0,0 +1,72 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/cache"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statsTool    = "cache-stats"
+	statsShort   = "Show response cache statistics"
+	statsLong    = "Show the cached list responses per resource, how many are still fresh, and the hits, revalidations, misses and quota units saved so far. Use this tool to check whether the cache is enabled and paying off."
+	statsExample = `# Show cache statistics
+yutu cache stats
+# Show cache statistics for a 10 minute TTL in JSON format
+yutu cache stats --cache-ttl 10m --output json`
+)
+
+var statsInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{},
+	Properties: map[string]*jsonschema.Schema{
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "table"},
+			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: statsTool, Title: statsShort, Description: statsLong,
+			InputSchema: statsInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			statsTool, func(input cache.Cache, writer io.Writer) error {
+				return input.Stats(writer)
+			},
+		),
+	)
+	cacheCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+}
+
+var statsCmd = &cobra.Command{
+	Use:     "stats",
+	Short:   statsShort,
+	Long:    statsLong,
+	Example: statsExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		input := cache.NewCache(cache.WithOutput(output))
+		utils.HandleCmdError(input.Stats(cmd.OutOrStdout()), cmd)
+	},
+}
@@ -74,13 +74,15 @@ func init() {
 				OpenWorldHint:   new(true),
 				ReadOnlyHint:    false,
 			},
-		}, cobramcp.GenToolHandler(
-			insertTool, func(input caption.Caption, writer io.Writer) error {
-				if !input.Confirmed {
-					return utils.ErrNotConfirmed
-				}
-				return input.Insert(writer)
-			},
+		}, cmd.WithProgress(
+			cobramcp.GenToolHandler(
+				insertTool, func(input caption.Caption, writer io.Writer) error {
+					if !input.Confirmed {
+						return utils.ErrNotConfirmed
+					}
+					return input.Insert(writer)
+				},
+			),
 		),
 	)
 	captionCmd.AddCommand(insertCmd)
@@ -140,6 +142,7 @@ var insertCmd = &cobra.Command{
 			caption.WithOnBehalfOf(onBehalfOf),
 			caption.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
 			caption.WithOutput(output),
+			caption.WithContext(cmd.ProgressContext(c)),
 		)
 		utils.HandleCmdError(input.Insert(c.OutOrStdout()), c)
 	},
@@ -30,7 +30,7 @@ yutu caption list --videoId dQw4w9WgXcQ --output json
 yutu caption list --ids abc123,def456 --videoId dQw4w9WgXcQ`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -46,13 +46,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Description: pkg.TableUsage,
-			Enum:    []any{"json", "yaml", "table"},
-			Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag)
 
 func init() {
 	mcp.AddTool(
@@ -81,7 +76,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 var listCmd = &cobra.Command{
@@ -89,16 +84,17 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := caption.NewCaption(
-			caption.WithIds(ids),
-			caption.WithVideoId(videoId),
-			caption.WithOnBehalfOf(onBehalfOf),
-			caption.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			caption.WithParts(parts),
-			caption.WithOutput(output),
+			append(
+				cmd.ListOptions[caption.Option](c),
+				caption.WithIds(ids),
+				caption.WithVideoId(videoId),
+				caption.WithOnBehalfOf(onBehalfOf),
+				caption.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				caption.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -32,7 +32,7 @@ yutu channel list --ids UC_x5XG1OV2P6uZZ5FSM9Ttw --output json
 yutu channel list --maxResults 10 --output table`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -60,12 +60,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","status"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -106,7 +102,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet", "status"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -114,20 +110,21 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := channel.NewChannel(
-			channel.WithCategoryId(categoryId),
-			channel.WithForHandle(forHandle),
-			channel.WithForUsername(forUsername),
-			channel.WithHl(hl),
-			channel.WithIds(ids),
-			channel.WithFor(channelFor),
-			channel.WithMaxResults(maxResults),
-			channel.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			channel.WithParts(parts),
-			channel.WithOutput(output),
+			append(
+				cmd.ListOptions[channel.Option](c),
+				channel.WithCategoryId(categoryId),
+				channel.WithForHandle(forHandle),
+				channel.WithForUsername(forUsername),
+				channel.WithHl(hl),
+				channel.WithIds(ids),
+				channel.WithFor(channelFor),
+				channel.WithMaxResults(maxResults),
+				channel.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				channel.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -61,14 +61,16 @@ func init() {
 				OpenWorldHint:   new(true),
 				ReadOnlyHint:    false,
 			},
-		}, cobramcp.GenToolHandler(
-			insertTool,
-			func(input channelBanner.ChannelBanner, writer io.Writer) error {
-				if !input.Confirmed {
-					return utils.ErrNotConfirmed
-				}
-				return input.Insert(writer)
-			},
+		}, cmd.WithProgress(
+			cobramcp.GenToolHandler(
+				insertTool,
+				func(input channelBanner.ChannelBanner, writer io.Writer) error {
+					if !input.Confirmed {
+						return utils.ErrNotConfirmed
+					}
+					return input.Insert(writer)
+				},
+			),
 		),
 	)
 	channelBannerCmd.AddCommand(insertCmd)
@@ -105,6 +107,7 @@ var insertCmd = &cobra.Command{
 			channelBanner.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
 			channelBanner.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
 			channelBanner.WithOutput(output),
+			channelBanner.WithContext(cmd.ProgressContext(c)),
 		)
 		utils.HandleCmdError(input.Insert(c.OutOrStdout()), c)
 	},
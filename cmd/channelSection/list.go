@@ -30,7 +30,7 @@ yutu channelSection list --channelId UC_x5XG1OV2P6uZZ5FSM9Ttw
 yutu channelSection list --ids abc123,def456 --output json`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -47,12 +47,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag)
 
 func init() {
 	mcp.AddTool(
@@ -83,7 +79,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 var listCmd = &cobra.Command{
@@ -91,17 +87,18 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := channelSection.NewChannelSection(
-			channelSection.WithIds(ids),
-			channelSection.WithChannelId(channelId),
-			channelSection.WithHl(hl),
-			channelSection.WithMine(mine),
-			channelSection.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			channelSection.WithParts(parts),
-			channelSection.WithOutput(output),
+			append(
+				cmd.ListOptions[channelSection.Option](c),
+				channelSection.WithIds(ids),
+				channelSection.WithChannelId(channelId),
+				channelSection.WithHl(hl),
+				channelSection.WithMine(mine),
+				channelSection.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				channelSection.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
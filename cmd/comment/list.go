@@ -30,7 +30,7 @@ yutu comment list --ids abc123,def456 --output json
 yutu comment list --ids abc123 --textFormat plainText`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -53,12 +53,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -89,7 +85,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -97,16 +93,17 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := comment.NewComment(
-			comment.WithIds(ids),
-			comment.WithMaxResults(maxResults),
-			comment.WithParentId(parentId),
-			comment.WithTextFormat(textFormat),
-			comment.WithParts(parts),
-			comment.WithOutput(output),
+			append(
+				cmd.ListOptions[comment.Option](c),
+				comment.WithIds(ids),
+				comment.WithMaxResults(maxResults),
+				comment.WithParentId(parentId),
+				comment.WithTextFormat(textFormat),
+				comment.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -30,7 +30,7 @@ yutu commentThread list --channelId UC_x5XG1OV2P6uZZ5FSM9Ttw --searchTerms 'hell
 yutu commentThread list --ids abc123,def456 --output json`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -68,12 +68,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -110,7 +106,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -118,21 +114,22 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := commentThread.NewCommentThread(
-			commentThread.WithIds(ids),
-			commentThread.WithAllThreadsRelatedToChannelId(allThreadsRelatedToChannelId),
-			commentThread.WithChannelId(channelId),
-			commentThread.WithMaxResults(maxResults),
-			commentThread.WithModerationStatus(moderationStatus),
-			commentThread.WithOrder(order),
-			commentThread.WithSearchTerms(searchTerms),
-			commentThread.WithTextFormat(textFormat),
-			commentThread.WithVideoId(videoId),
-			commentThread.WithParts(parts),
-			commentThread.WithOutput(output),
+			append(
+				cmd.ListOptions[commentThread.Option](c),
+				commentThread.WithIds(ids),
+				commentThread.WithAllThreadsRelatedToChannelId(allThreadsRelatedToChannelId),
+				commentThread.WithChannelId(channelId),
+				commentThread.WithMaxResults(maxResults),
+				commentThread.WithModerationStatus(moderationStatus),
+				commentThread.WithOrder(order),
+				commentThread.WithSearchTerms(searchTerms),
+				commentThread.WithTextFormat(textFormat),
+				commentThread.WithVideoId(videoId),
+				commentThread.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -48,21 +48,22 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", defaultParts, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: listShort,
 	Long:  listLong,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := i18nLanguage.NewI18nLanguage(
-			i18nLanguage.WithHl(hl),
-			i18nLanguage.WithParts(parts),
-			i18nLanguage.WithOutput(output),
+			append(
+				cmd.ListOptions[i18nLanguage.Option](c),
+				i18nLanguage.WithHl(hl),
+				i18nLanguage.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
 
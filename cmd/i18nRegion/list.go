@@ -37,21 +37,22 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", defaultParts, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: listShort,
 	Long:  listLong,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := i18nRegion.NewI18nRegion(
-			i18nRegion.WithHl(hl),
-			i18nRegion.WithParts(parts),
-			i18nRegion.WithOutput(output),
+			append(
+				cmd.ListOptions[i18nRegion.Option](c),
+				i18nRegion.WithHl(hl),
+				i18nRegion.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
 
@@ -0,0 +1,35 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	short    = "Manage the local upload ledger"
+	long     = "Manage the local upload ledger. Every video uploaded by yutu is recorded with its content hash, size, path and video ID in a file next to the token cache, so that the same file is not uploaded twice by accident."
+	idsUsage = "Video IDs or hash prefixes of the ledger entries"
+)
+
+var (
+	ids     []string
+	query   string
+	before  string
+	missing bool
+)
+
+var ledgerCmd = &cobra.Command{
+	Use:   "ledger",
+	Short: short,
+	Long:  long,
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(ledgerCmd)
+}
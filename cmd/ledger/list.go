@@ -0,0 +1,75 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg/ledger"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listTool    = "ledger-list"
+	listShort   = "List uploads recorded in the ledger"
+	listLong    = "List uploads recorded in the local ledger. Use this tool to check which files have already been uploaded and their video IDs."
+	listExample = `# List every recorded upload
+yutu ledger list
+# List specific entries by video ID in JSON format
+yutu ledger list --ids dQw4w9WgXcQ --output json`
+)
+
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
+	Type:     "object",
+	Required: []string{},
+	Properties: map[string]*jsonschema.Schema{
+		"ids": {
+			Type: "array", Description: idsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+	},
+})
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: listTool, Title: listShort, Description: listLong,
+			InputSchema: listInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			listTool, func(input ledger.Ledger, writer io.Writer) error {
+				return input.List(writer)
+			},
+		),
+	)
+	ledgerCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringSliceVarP(&ids, "ids", "i", []string{}, idsUsage)
+	cmd.AddListFlags(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Short:   listShort,
+	Long:    listLong,
+	Example: listExample,
+	Run: func(c *cobra.Command, _ []string) {
+		input := ledger.NewLedger(
+			append(
+				cmd.ListOptions[ledger.Option](c),
+				ledger.WithIds(ids),
+			)...,
+		)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
+	},
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/ledger"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	pruneTool         = "ledger-prune"
+	pruneBeforeUsage  = "Remove entries uploaded before this date (2006-01-02) or RFC3339 time"
+	pruneMissingUsage = "Remove entries whose file no longer exists, stream uploads are kept"
+	pruneShort        = "Remove entries from the ledger"
+	pruneLong         = "Remove entries from the local upload ledger. Use this tool to forget uploads by video ID or hash prefix, by age, or whose file is gone, so that those files can be uploaded again without --force."
+	pruneExample      = `# Forget the upload of a deleted video
+yutu ledger prune --ids dQw4w9WgXcQ
+# Remove entries older than a date and entries whose file is gone
+yutu ledger prune --before 2025-01-01 --missing`
+)
+
+var pruneInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{},
+	Properties: map[string]*jsonschema.Schema{
+		"ids": {
+			Type: "array", Description: idsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"before":    {Type: "string", Description: pruneBeforeUsage},
+		"missing":   {Type: "boolean", Description: pruneMissingUsage},
+		"confirmed": {Type: "boolean", Description: pkg.ConfirmedUsage},
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "silent"},
+			Description: pkg.SilentUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: pruneTool, Title: pruneShort, Description: pruneLong,
+			InputSchema: pruneInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(true),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    false,
+			},
+		}, cobramcp.GenToolHandler(
+			pruneTool, func(input ledger.Ledger, writer io.Writer) error {
+				if !input.Confirmed {
+					return utils.ErrNotConfirmed
+				}
+				return input.Prune(writer)
+			},
+		),
+	)
+	ledgerCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().StringSliceVarP(&ids, "ids", "i", []string{}, idsUsage)
+	pruneCmd.Flags().StringVarP(&before, "before", "b", "", pruneBeforeUsage)
+	pruneCmd.Flags().BoolVarP(&missing, "missing", "m", false, pruneMissingUsage)
+	pruneCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
+	pruneCmd.Flags().Bool("yes", false, pkg.ConfirmedUsage)
+	pruneCmd.MarkFlagsOneRequired("ids", "before", "missing")
+}
+
+var pruneCmd = &cobra.Command{
+	Use:     "prune",
+	Short:   pruneShort,
+	Long:    pruneLong,
+	Example: pruneExample,
+	PreRunE: func(c *cobra.Command, _ []string) error {
+		var filters []string
+		if len(ids) > 0 {
+			filters = append(filters, "ids "+strings.Join(ids, ", "))
+		}
+		if before != "" {
+			filters = append(filters, "uploaded before "+before)
+		}
+		if missing {
+			filters = append(filters, "missing files")
+		}
+		msg := fmt.Sprintf(
+			"Would remove ledger entries matching %s", strings.Join(filters, " or "),
+		)
+		return utils.ConfirmPreRun(c, msg)
+	},
+	Run: func(c *cobra.Command, _ []string) {
+		output, _ := c.Flags().GetString("output")
+		input := ledger.NewLedger(
+			ledger.WithIds(ids),
+			ledger.WithBefore(before),
+			ledger.WithMissing(missing),
+			ledger.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Prune(c.OutOrStdout()), c)
+	},
+}
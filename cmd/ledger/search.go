@@ -0,0 +1,95 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/ledger"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	searchTool       = "ledger-search"
+	searchQueryUsage = "Case-insensitive text to find in the path, title, video ID or hash"
+	searchShort      = "Search uploads recorded in the ledger"
+	searchLong       = "Search uploads recorded in the local ledger. Use this tool to find whether a file was uploaded before by its path, title, video ID or content hash."
+	searchExample    = `# Find uploads whose path or title mentions "vlog"
+yutu ledger search --query vlog
+# Find the upload of a file by its SHA-256 hash
+yutu ledger search --query 9f86d081884c7d65 --output json`
+)
+
+var searchInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{"query"},
+	Properties: map[string]*jsonschema.Schema{
+		"query": {Type: "string", Description: searchQueryUsage},
+		"columns": {
+			Type: "array", Description: pkg.ColumnsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"where":   {Type: "string", Description: pkg.WhereUsage},
+		"sort_by": {Type: "string", Description: pkg.SortByUsage},
+		"output": {
+			Type: "string", Pattern: common.OutputPattern(),
+			Description: common.ListUsage(), Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: searchTool, Title: searchShort, Description: searchLong,
+			InputSchema: searchInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			searchTool, func(input ledger.Ledger, writer io.Writer) error {
+				return input.Search(writer)
+			},
+		),
+	)
+	ledgerCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringVarP(&query, "query", "q", "", searchQueryUsage)
+	searchCmd.Flags().StringP("output", "o", "table", common.ListUsage())
+	searchCmd.Flags().StringSlice("columns", nil, pkg.ColumnsUsage)
+	searchCmd.Flags().String("where", "", pkg.WhereUsage)
+	searchCmd.Flags().String("sort-by", "", pkg.SortByUsage)
+	_ = searchCmd.MarkFlagRequired("query")
+}
+
+var searchCmd = &cobra.Command{
+	Use:     "search",
+	Short:   searchShort,
+	Long:    searchLong,
+	Example: searchExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		where, _ := cmd.Flags().GetString("where")
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		input := ledger.NewLedger(
+			ledger.WithQuery(query),
+			ledger.WithColumns(columns),
+			ledger.WithWhere(where),
+			ledger.WithSortBy(sortBy),
+			ledger.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Search(cmd.OutOrStdout()), cmd)
+	},
+}
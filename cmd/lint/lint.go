@@ -0,0 +1,94 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/lint"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	lintTool  = "lint"
+	short     = "Check metadata against YouTube limits"
+	long      = "Check titles, descriptions and tags against YouTube limits before uploading. Use this tool to find titles over 100 characters, descriptions over 5000 bytes, tags over 500 characters in total counting the yutu🐰 tag that video insert and update add, and < or > in any of them, reported per entry and field. Video insert and update, playlist insert and update, and channel update run the same checks before calling the API."
+	fileUsage = "Path to a YAML, JSON or CSV file with title, description and tags, a single entry or a list such as a video manifest"
+	example   = `# Check a metadata file
+yutu lint --file metadata.yaml
+# Check every entry of a video manifest before uploading it
+yutu lint --file uploads.csv --output json`
+)
+
+var file string
+
+var lintInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{"file"},
+	Properties: map[string]*jsonschema.Schema{
+		"file": {Type: "string", Description: fileUsage},
+		"columns": {
+			Type: "array", Description: pkg.ColumnsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"where":   {Type: "string", Description: pkg.WhereUsage},
+		"sort_by": {Type: "string", Description: pkg.SortByUsage},
+		"output": {
+			Type: "string", Pattern: common.OutputPattern(),
+			Description: common.ListUsage(), Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: lintTool, Title: short, Description: long,
+			InputSchema: lintInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			lintTool, func(input lint.Linter, writer io.Writer) error {
+				return input.Lint(writer)
+			},
+		),
+	)
+	cmd.RootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVarP(&file, "file", "f", "", fileUsage)
+	lintCmd.Flags().StringP("output", "o", "table", common.ListUsage())
+	lintCmd.Flags().StringSlice("columns", nil, pkg.ColumnsUsage)
+	lintCmd.Flags().String("where", "", pkg.WhereUsage)
+	lintCmd.Flags().String("sort-by", "", pkg.SortByUsage)
+	_ = lintCmd.MarkFlagRequired("file")
+}
+
+var lintCmd = &cobra.Command{
+	Use:     "lint",
+	Short:   short,
+	Long:    long,
+	Example: example,
+	Run: func(c *cobra.Command, _ []string) {
+		output, _ := c.Flags().GetString("output")
+		columns, _ := c.Flags().GetStringSlice("columns")
+		where, _ := c.Flags().GetString("where")
+		sortBy, _ := c.Flags().GetString("sort-by")
+		input := lint.NewLinter(
+			lint.WithFile(file), lint.WithColumns(columns), lint.WithWhere(where),
+			lint.WithSortBy(sortBy), lint.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Lint(c.OutOrStdout()), c)
+	},
+}
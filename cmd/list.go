@@ -0,0 +1,89 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+// ListFlags picks the list options a list command takes beyond --output,
+// --columns, --where and --sort-by, which every one of them takes.
+type ListFlags int
+
+const (
+	// FieldsFlag is --fields, for lists fetched from the API.
+	FieldsFlag ListFlags = iota
+	// PageFlags are --page-token and --envelope, for paged lists.
+	PageFlags
+)
+
+// AddListFlags registers the list options picked by flags on c.
+func AddListFlags(c *cobra.Command, flags ...ListFlags) {
+	c.Flags().StringP("output", "o", "table", common.ListUsage())
+	c.Flags().StringSlice("columns", nil, pkg.ColumnsUsage)
+	c.Flags().String("where", "", pkg.WhereUsage)
+	c.Flags().String("sort-by", "", pkg.SortByUsage)
+	if slices.Contains(flags, FieldsFlag) {
+		c.Flags().String("fields", "", pkg.FieldsUsage)
+	}
+	if slices.Contains(flags, PageFlags) {
+		c.Flags().String("page-token", "", pkg.PageTokenUsage)
+		c.Flags().Bool("envelope", false, pkg.EnvelopeUsage)
+	}
+}
+
+// ListOptions returns the options set by the list flags of c, see
+// AddListFlags. Flags c does not take leave their option unset.
+func ListOptions[O ~func(T), T common.HasFields](c *cobra.Command) []O {
+	output, _ := c.Flags().GetString("output")
+	columns, _ := c.Flags().GetStringSlice("columns")
+	where, _ := c.Flags().GetString("where")
+	sortBy, _ := c.Flags().GetString("sort-by")
+	fieldMask, _ := c.Flags().GetString("fields")
+	pageToken, _ := c.Flags().GetString("page-token")
+	envelope, _ := c.Flags().GetBool("envelope")
+	return []O{
+		common.WithOutput[T](output),
+		common.WithColumns[T](columns),
+		common.WithWhere[T](where),
+		common.WithSortBy[T](sortBy),
+		common.WithFieldMask[T](fieldMask),
+		common.WithPageToken[T](pageToken),
+		common.WithEnvelope[T](envelope),
+	}
+}
+
+// ListSchema adds the MCP arguments matching the list options picked by
+// flags to the properties of schema, and returns it.
+func ListSchema(schema *jsonschema.Schema, flags ...ListFlags) *jsonschema.Schema {
+	properties := schema.Properties
+	properties["output"] = &jsonschema.Schema{
+		Type: "string", Pattern: common.OutputPattern(),
+		Description: common.ListUsage(), Default: json.RawMessage(`"yaml"`),
+	}
+	properties["columns"] = &jsonschema.Schema{
+		Type: "array", Description: pkg.ColumnsUsage,
+		Items: &jsonschema.Schema{Type: "string"},
+	}
+	properties["where"] = &jsonschema.Schema{Type: "string", Description: pkg.WhereUsage}
+	properties["sort_by"] = &jsonschema.Schema{Type: "string", Description: pkg.SortByUsage}
+	if slices.Contains(flags, FieldsFlag) {
+		properties["fields"] = &jsonschema.Schema{Type: "string", Description: pkg.FieldsUsage}
+	}
+	if slices.Contains(flags, PageFlags) {
+		properties["page_token"] = &jsonschema.Schema{
+			Type: "string", Description: pkg.PageTokenUsage,
+		}
+		properties["envelope"] = &jsonschema.Schema{
+			Type: "boolean", Description: pkg.EnvelopeUsage,
+		}
+	}
+	return schema
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+func TestListOptions(t *testing.T) {
+	c := &cobra.Command{Use: "list"}
+	AddListFlags(c, FieldsFlag, PageFlags)
+	for name, value := range map[string]string{
+		"output": "csv", "columns": "id,name", "where": `name == "a"`,
+		"sort-by": "name:desc", "fields": "items(id)", "page-token": "p2",
+		"envelope": "true",
+	} {
+		if err := c.Flags().Set(name, value); err != nil {
+			t.Fatalf("Set(%s) error = %v", name, err)
+		}
+	}
+
+	p := &profile.Profile{}
+	for _, opt := range ListOptions[profile.Option](c) {
+		opt(p)
+	}
+	f := p.GetFields()
+	if f.Output != "csv" || !slices.Equal(f.Columns, []string{"id", "name"}) ||
+		f.Where != `name == "a"` || f.SortBy != "name:desc" ||
+		f.FieldMask != "items(id)" || f.PageToken != "p2" || !f.Envelope {
+		t.Errorf("ListOptions() set %+v", f)
+	}
+}
+
+func TestListSchema(t *testing.T) {
+	tests := []struct {
+		flags []ListFlags
+		want  []string
+	}{
+		{want: []string{"columns", "ids", "output", "sort_by", "where"}},
+		{
+			flags: []ListFlags{FieldsFlag, PageFlags},
+			want: []string{
+				"columns", "envelope", "fields", "ids", "output", "page_token",
+				"sort_by", "where",
+			},
+		},
+	}
+	for _, tt := range tests {
+		schema := ListSchema(
+			&jsonschema.Schema{
+				Type: "object", Properties: map[string]*jsonschema.Schema{
+					"ids": {Type: "array"},
+				},
+			}, tt.flags...,
+		)
+		var got []string
+		for name := range schema.Properties {
+			got = append(got, name)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ListSchema(%v) properties = %v, want %v", tt.flags, got, tt.want)
+		}
+
+		c := &cobra.Command{Use: "list"}
+		AddListFlags(c, tt.flags...)
+		for _, name := range tt.want {
+			if name == "ids" {
+				continue
+			}
+			flag := strings.ReplaceAll(name, "_", "-")
+			if c.Flags().Lookup(flag) == nil {
+				t.Errorf("AddListFlags(%v) did not add --%s", tt.flags, flag)
+			}
+		}
+	}
+}
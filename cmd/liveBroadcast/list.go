@@ -30,7 +30,7 @@ yutu liveBroadcast list --ids broadcast1,broadcast2
 yutu liveBroadcast list --mine --broadcastStatus active --output json`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -54,12 +54,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","status"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -99,7 +95,7 @@ func init() {
 		&parts, "parts", "p", []string{"id", "snippet", "status"},
 		pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -107,19 +103,20 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := liveBroadcast.NewLiveBroadcast(
-			liveBroadcast.WithIds(ids),
-			liveBroadcast.WithMine(mine),
-			liveBroadcast.WithBroadcastStatus(broadcastStatus),
-			liveBroadcast.WithBroadcastType(broadcastType),
-			liveBroadcast.WithMaxResults(maxResults),
-			liveBroadcast.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			liveBroadcast.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
-			liveBroadcast.WithParts(parts),
-			liveBroadcast.WithOutput(output),
+			append(
+				cmd.ListOptions[liveBroadcast.Option](c),
+				liveBroadcast.WithIds(ids),
+				liveBroadcast.WithMine(mine),
+				liveBroadcast.WithBroadcastStatus(broadcastStatus),
+				liveBroadcast.WithBroadcastType(broadcastType),
+				liveBroadcast.WithMaxResults(maxResults),
+				liveBroadcast.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				liveBroadcast.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
+				liveBroadcast.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -27,7 +27,7 @@ yutu liveChatMessage list --liveChatId abc123
 yutu liveChatMessage list --liveChatId abc123 --maxResults 10`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{"live_chat_id"},
 	Properties: map[string]*jsonschema.Schema{
@@ -43,12 +43,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["snippet","authorDetails"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -77,7 +73,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"snippet", "authorDetails"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 	_ = listCmd.MarkFlagRequired("liveChatId")
 }
 
@@ -86,15 +82,16 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := liveChatMessage.NewLiveChatMessage(
-			liveChatMessage.WithLiveChatId(liveChatId),
-			liveChatMessage.WithHl(hl),
-			liveChatMessage.WithMaxResults(maxResults),
-			liveChatMessage.WithParts(parts),
-			liveChatMessage.WithOutput(output),
+			append(
+				cmd.ListOptions[liveChatMessage.Option](c),
+				liveChatMessage.WithLiveChatId(liveChatId),
+				liveChatMessage.WithHl(hl),
+				liveChatMessage.WithMaxResults(maxResults),
+				liveChatMessage.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
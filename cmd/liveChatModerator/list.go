@@ -27,7 +27,7 @@ yutu liveChatModerator list --liveChatId abc123
 yutu liveChatModerator list --liveChatId abc123 --maxResults 10`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{"live_chat_id"},
 	Properties: map[string]*jsonschema.Schema{
@@ -42,12 +42,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -73,7 +69,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 	_ = listCmd.MarkFlagRequired("liveChatId")
 }
 
@@ -82,14 +78,15 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := liveChatModerator.NewLiveChatModerator(
-			liveChatModerator.WithLiveChatId(liveChatId),
-			liveChatModerator.WithMaxResults(maxResults),
-			liveChatModerator.WithParts(parts),
-			liveChatModerator.WithOutput(output),
+			append(
+				cmd.ListOptions[liveChatModerator.Option](c),
+				liveChatModerator.WithLiveChatId(liveChatId),
+				liveChatModerator.WithMaxResults(maxResults),
+				liveChatModerator.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -30,7 +30,7 @@ yutu liveStream list --ids stream1,stream2
 yutu liveStream list --mine --output json --maxResults 10`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -52,12 +52,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","cdn","status"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -93,7 +89,7 @@ func init() {
 		&parts, "parts", "p", []string{"id", "snippet", "cdn", "status"},
 		pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -101,17 +97,18 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := liveStream.NewLiveStream(
-			liveStream.WithIds(ids),
-			liveStream.WithMine(mine),
-			liveStream.WithMaxResults(maxResults),
-			liveStream.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			liveStream.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
-			liveStream.WithParts(parts),
-			liveStream.WithOutput(output),
+			append(
+				cmd.ListOptions[liveStream.Option](c),
+				liveStream.WithIds(ids),
+				liveStream.WithMine(mine),
+				liveStream.WithMaxResults(maxResults),
+				liveStream.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				liveStream.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
+				liveStream.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
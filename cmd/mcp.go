@@ -4,11 +4,18 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	cobramcp "github.com/eat-pray-ai/cobra-mcp"
 	"github.com/eat-pray-ai/yutu/pkg/auth"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +29,14 @@ yutu mcp --mode http --port 8216
 yutu mcp --mode http --host 0.0.0.0 --port 8216
 
 # Behind a reverse proxy with a public base URL
-yutu mcp --mode http --baseUrl https://mcp.example.com`
+yutu mcp --mode http --baseUrl https://mcp.example.com
+
+# Pin a named profile for a stdio server
+yutu mcp --profile gaming`
+
+var errProfileHTTP = errors.New(
+	"--profile only applies to stdio mode, HTTP clients authorize with OAuth",
+)
 
 var mcpConfig = &cobramcp.Config{
 	Name:         "yutu",
@@ -35,10 +49,17 @@ var Server, mcpCmd = cobramcp.ServerAndCommand(mcpConfig)
 func init() {
 	mcpCmd.Example = example
 	RootCmd.AddCommand(mcpCmd)
+	Server.AddReceivingMiddleware(
+		classifyToolErrors, profileDefaults, checkListArgs, pageCursor,
+	)
 
 	mcpCmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		mode, _ := cmd.Flags().GetString("mode")
 		if mode == "http" {
+			if cmd.Flags().Changed("profile") {
+				return failure.New(failure.InvalidArgument, errProfileHTTP)
+			}
+			activeProfile = nil
 			mcpConfig.Auth = &cobramcp.AuthConfig{
 				TokenVerifier:        auth.GoogleTokenVerifier,
 				Scopes:               auth.Scopes,
@@ -53,3 +74,134 @@ func init() {
 		return nil
 	}
 }
+
+// classifyToolErrors replaces the message of a failed tool call with its
+// classified error, the same object the CLI prints with --output json.
+func classifyToolErrors(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		res, err := next(ctx, method, req)
+		result, ok := res.(*mcp.CallToolResult)
+		if err != nil || !ok || result.GetError() == nil {
+			return res, err
+		}
+		details := map[string]any{"error": failure.Classify(result.GetError())}
+		text, _ := json.Marshal(details)
+		result.Content = []mcp.Content{&mcp.TextContent{Text: string(text)}}
+		result.StructuredContent = details
+		return result, nil
+	}
+}
+
+// profileDefaults fills the content owner arguments a tool call leaves out
+// with those of the profile pinned with yutu mcp --profile, and its channel
+// as the CLI does for the command behind the tool.
+func profileDefaults(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if activeProfile == nil || method != "tools/call" || !ok {
+			return next(ctx, method, req)
+		}
+		args := map[string]any{}
+		if len(params.Arguments) > 0 {
+			if err := json.Unmarshal(params.Arguments, &args); err != nil {
+				return next(ctx, method, req)
+			}
+		}
+		defaults := map[string]string{
+			"on_behalf_of_content_owner":         activeProfile.OnBehalfOfContentOwner,
+			"on_behalf_of_content_owner_channel": activeProfile.OnBehalfOfContentOwnerChannel,
+		}
+		if c := toolCommand(params.Name); c != nil && !skipsProfile(c) &&
+			c.Flags().Lookup("channelId") != nil &&
+			fillsChannel(
+				c.CommandPath(), func(flag string) bool {
+					_, set := args[argName(flag)]
+					return set
+				},
+			) {
+			defaults["channel_id"] = activeProfile.ChannelId
+		}
+		for key, value := range defaults {
+			if _, set := args[key]; !set && value != "" {
+				args[key] = value
+			}
+		}
+		if raw, err := json.Marshal(args); err == nil {
+			params.Arguments = raw
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// toolCommand returns the command a tool such as playlist-list runs, or
+// nil.
+func toolCommand(tool string) *cobra.Command {
+	c, rest, err := RootCmd.Find(strings.Split(tool, "-"))
+	if err != nil || len(rest) > 0 || c == RootCmd {
+		return nil
+	}
+	return c
+}
+
+// argName returns the tool argument of a flag, channel_id for channelId.
+func argName(flag string) string {
+	var b strings.Builder
+	for _, r := range flag {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// checkListArgs rejects a tool call whose output, where, sort_by or envelope
+// argument cannot be used before it reaches the API, as the root command
+// does for flags.
+func checkListArgs(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if method != "tools/call" || !ok || len(params.Arguments) == 0 {
+			return next(ctx, method, req)
+		}
+		var args struct {
+			Output   string `json:"output"`
+			Where    string `json:"where"`
+			SortBy   string `json:"sort_by"`
+			Envelope bool   `json:"envelope"`
+		}
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return next(ctx, method, req)
+		}
+		list := &common.Fields{
+			Output: args.Output, Where: args.Where, SortBy: args.SortBy,
+			Envelope: args.Envelope,
+		}
+		if err := common.CheckList(list); err != nil {
+			result := &mcp.CallToolResult{}
+			result.SetError(err)
+			return result, nil
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// pageCursor adds where a paged list tool stopped to its result, so that
+// clients can ask for the next page with page_token.
+func pageCursor(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		page := &common.Page{}
+		res, err := next(common.CtxWithPage(ctx, page), method, req)
+		result, ok := res.(*mcp.CallToolResult)
+		if err != nil || !ok || result.IsError || *page == (common.Page{}) {
+			return res, err
+		}
+		text, _ := json.Marshal(page)
+		result.Content = append(result.Content, &mcp.TextContent{Text: string(text)})
+		return result, nil
+	}
+}
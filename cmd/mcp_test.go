@@ -0,0 +1,78 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+func TestProfileDefaults_ChannelId(t *testing.T) {
+	old := activeProfile
+	t.Cleanup(func() { activeProfile = old })
+	activeProfile = &profile.Account{Name: "gaming", ChannelId: "UC1"}
+
+	resource := &cobra.Command{Use: "testResource"}
+	list := &cobra.Command{Use: "list"}
+	list.Flags().String("channelId", "", "")
+	list.Flags().String("forChannelId", "", "")
+	resource.AddCommand(list, &cobra.Command{Use: "delete"})
+	RootCmd.AddCommand(resource)
+	channelFilters["yutu testResource list"] = []string{"forChannelId"}
+	t.Cleanup(
+		func() {
+			RootCmd.RemoveCommand(resource)
+			delete(channelFilters, "yutu testResource list")
+		},
+	)
+
+	tests := []struct {
+		name string
+		tool string
+		args string
+		want any
+	}{
+		{name: "no filter", tool: "testResource-list", args: `{}`, want: "UC1"},
+		{
+			name: "filter set", tool: "testResource-list",
+			args: `{"for_channel_id": "UC9"}`,
+		},
+		{
+			name: "explicit channel", tool: "testResource-list",
+			args: `{"channel_id": "UC2"}`, want: "UC2",
+		},
+		{name: "no channel flag", tool: "testResource-delete", args: `{}`},
+		{name: "unknown tool", tool: "none-list", args: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				params := &mcp.CallToolParamsRaw{
+					Name: tt.tool, Arguments: json.RawMessage(tt.args),
+				}
+				var args map[string]any
+				next := func(
+					_ context.Context, _ string, req mcp.Request,
+				) (mcp.Result, error) {
+					raw := req.GetParams().(*mcp.CallToolParamsRaw).Arguments
+					return nil, json.Unmarshal(raw, &args)
+				}
+				req := &mcp.ServerRequest[*mcp.CallToolParamsRaw]{Params: params}
+				if _, err := profileDefaults(next)(
+					context.Background(), "tools/call", req,
+				); err != nil {
+					t.Fatalf("profileDefaults() error = %v", err)
+				}
+				if got := args["channel_id"]; got != tt.want {
+					t.Errorf("channel_id = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -31,7 +31,7 @@ yutu playlist list --channelId UC_x5XG1OV2P6uZZ5FSM9Ttw --maxResults 10
 yutu playlist list --ids PLxxx1,PLxxx2 --output json`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -62,12 +62,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","status"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -102,7 +98,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet", "status"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -110,19 +106,20 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := playlist.NewPlaylist(
-			playlist.WithIds(ids),
-			playlist.WithChannelId(channelId),
-			playlist.WithHl(hl),
-			playlist.WithMaxResults(maxResults),
-			playlist.WithMine(mine),
-			playlist.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			playlist.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
-			playlist.WithParts(parts),
-			playlist.WithOutput(output),
+			append(
+				cmd.ListOptions[playlist.Option](c),
+				playlist.WithIds(ids),
+				playlist.WithChannelId(channelId),
+				playlist.WithHl(hl),
+				playlist.WithMaxResults(maxResults),
+				playlist.WithMine(mine),
+				playlist.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				playlist.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
+				playlist.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -27,7 +27,7 @@ yutu playlistImage list --parent PLxxx
 yutu playlistImage list --parent PLxxx --maxResults 10 --output json`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -50,12 +50,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","kind","snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -79,7 +75,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "kind", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 	listCmd.Flags().StringVarP(
 		&onBehalfOfContentOwner, "onBehalfOfContentOwner", "b", "", pkg.OBOCOUsage,
 	)
@@ -94,17 +90,18 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := playlistImage.NewPlaylistImage(
-			playlistImage.WithParent(parent),
-			playlistImage.WithMaxResults(maxResults),
-			playlistImage.WithParts(parts),
-			playlistImage.WithOutput(output),
-			playlistImage.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			playlistImage.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
-			playlistImage.WithService(nil),
+			append(
+				cmd.ListOptions[playlistImage.Option](c),
+				playlistImage.WithParent(parent),
+				playlistImage.WithMaxResults(maxResults),
+				playlistImage.WithParts(parts),
+				playlistImage.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				playlistImage.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
+				playlistImage.WithService(nil),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -31,7 +31,7 @@ yutu playlistItem list --playlistId PLxxx --maxResults 20 --output json
 yutu playlistItem list --ids abc123,def456`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -52,12 +52,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","status"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -87,7 +83,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet", "status"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -95,17 +91,18 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := playlistItem.NewPlaylistItem(
-			playlistItem.WithIds(ids),
-			playlistItem.WithPlaylistId(playlistId),
-			playlistItem.WithMaxResults(maxResults),
-			playlistItem.WithVideoId(videoId),
-			playlistItem.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			playlistItem.WithParts(parts),
-			playlistItem.WithOutput(output),
+			append(
+				cmd.ListOptions[playlistItem.Option](c),
+				playlistItem.WithIds(ids),
+				playlistItem.WithPlaylistId(playlistId),
+				playlistItem.WithMaxResults(maxResults),
+				playlistItem.WithVideoId(videoId),
+				playlistItem.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				playlistItem.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -0,0 +1,113 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	addTool       = "profile-add"
+	addShort      = "Add or replace a profile"
+	addLong       = "Add a named profile, or replace the one with the same name. The first profile added becomes the current one. Without --cacheToken the profile keeps its token in a file of its own; run yutu --profile NAME auth to sign in to it."
+	addCredUsage  = "Path to client secret file, or Base64 encoded string, or JSON string, default: client_secret.json"
+	addCacheUsage = "Path to token cache file, or Base64 encoded string, or JSON string, default: yutu.profiles/NAME/youtube.token.json"
+	addCidUsage   = "ID of the channel used when a command's --channelId is not given"
+	addExample    = `# Add a profile for a channel with its own token
+yutu profile add --name gaming --channelId UC_xxx
+# Add a profile for a content owner with a separate client secret
+yutu profile add --name music --credential music.secret.json --onBehalfOfContentOwner owner --onBehalfOfContentOwnerChannel UC_yyy
+# Sign in to the new profile
+yutu --profile gaming auth`
+)
+
+var addInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{"name"},
+	Properties: map[string]*jsonschema.Schema{
+		"name":        {Type: "string", Description: nameUsage},
+		"credential":  {Type: "string", Description: addCredUsage},
+		"cache_token": {Type: "string", Description: addCacheUsage},
+		"channel_id":  {Type: "string", Description: addCidUsage},
+		"on_behalf_of_content_owner": {
+			Type: "string", Description: pkg.OBOCOUsage,
+		},
+		"on_behalf_of_content_owner_channel": {
+			Type: "string", Description: pkg.OBOCOCUsage,
+		},
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "silent"},
+			Description: pkg.SilentUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: addTool, Title: addShort, Description: addLong,
+			InputSchema: addInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    false,
+			},
+		}, cobramcp.GenToolHandler(
+			addTool, func(input profile.Profile, writer io.Writer) error {
+				return input.Add(writer)
+			},
+		),
+	)
+	profileCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringVarP(&name, "name", "n", "", nameUsage)
+	addCmd.Flags().StringVarP(
+		&credential, "credential", "c", "", addCredUsage,
+	)
+	addCmd.Flags().StringVarP(
+		&cacheToken, "cacheToken", "t", "", addCacheUsage,
+	)
+	addCmd.Flags().StringVarP(&channelId, "channelId", "C", "", addCidUsage)
+	addCmd.Flags().StringVarP(
+		&onBehalfOfContentOwner, "onBehalfOfContentOwner", "b", "",
+		pkg.OBOCOUsage,
+	)
+	addCmd.Flags().StringVarP(
+		&onBehalfOfContentOwnerChannel, "onBehalfOfContentOwnerChannel", "B", "",
+		pkg.OBOCOCUsage,
+	)
+	addCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
+
+	_ = addCmd.MarkFlagRequired("name")
+}
+
+var addCmd = &cobra.Command{
+	Use:     "add",
+	Short:   addShort,
+	Long:    addLong,
+	Example: addExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		input := profile.NewProfile(
+			profile.WithName(name),
+			profile.WithCredential(credential),
+			profile.WithCacheToken(cacheToken),
+			profile.WithChannelId(channelId),
+			profile.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+			profile.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
+			profile.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Add(cmd.OutOrStdout()), cmd)
+	},
+}
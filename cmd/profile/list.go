@@ -0,0 +1,64 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listTool    = "profile-list"
+	listShort   = "List profiles"
+	listLong    = "List the named profiles with their default channel ID, content owner and token cache, marking the current one. Use this tool to find which channels yutu can act for."
+	listExample = `# List profiles
+yutu profile list
+# List profiles in JSON format
+yutu profile list --output json`
+)
+
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
+	Type:       "object",
+	Required:   []string{},
+	Properties: map[string]*jsonschema.Schema{},
+})
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: listTool, Title: listShort, Description: listLong,
+			InputSchema: listInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			listTool, func(input profile.Profile, writer io.Writer) error {
+				return input.List(writer)
+			},
+		),
+	)
+	profileCmd.AddCommand(listCmd)
+
+	cmd.AddListFlags(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Short:   listShort,
+	Long:    listLong,
+	Example: listExample,
+	Run: func(c *cobra.Command, _ []string) {
+		input := profile.NewProfile(cmd.ListOptions[profile.Option](c)...)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
+	},
+}
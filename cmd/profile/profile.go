@@ -0,0 +1,38 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	short     = "Manage named account profiles"
+	long      = "Manage named account profiles. Each profile has its own credential, token cache, default channel ID and content owner, so that one yutu can manage several channels. Select one with --profile or YUTU_PROFILE, or make it current with yutu profile use. Flags left unset, such as --channelId and --onBehalfOfContentOwner, take the values of the profile."
+	nameUsage = "Name of the profile"
+)
+
+var (
+	name                          string
+	credential                    string
+	cacheToken                    string
+	channelId                     string
+	onBehalfOfContentOwner        string
+	onBehalfOfContentOwnerChannel string
+)
+
+var profileCmd = &cobra.Command{
+	Use:         "profile",
+	Short:       short,
+	Long:        long,
+	Annotations: map[string]string{cmd.SkipProfile: "true"},
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(profileCmd)
+}
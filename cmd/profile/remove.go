@@ -0,0 +1,77 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	removeTool    = "profile-remove"
+	removeShort   = "Remove a profile"
+	removeLong    = "Remove a named profile. Its token cache is left on disk. Removing the current profile leaves none current."
+	removeExample = `# Remove a profile
+yutu profile remove --name gaming`
+)
+
+var removeInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{"name"},
+	Properties: map[string]*jsonschema.Schema{
+		"name": {Type: "string", Description: nameUsage},
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "silent"},
+			Description: pkg.SilentUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: removeTool, Title: removeShort, Description: removeLong,
+			InputSchema: removeInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(true),
+				IdempotentHint:  false,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    false,
+			},
+		}, cobramcp.GenToolHandler(
+			removeTool, func(input profile.Profile, writer io.Writer) error {
+				return input.Remove(writer)
+			},
+		),
+	)
+	profileCmd.AddCommand(removeCmd)
+
+	removeCmd.Flags().StringVarP(&name, "name", "n", "", nameUsage)
+	removeCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
+
+	_ = removeCmd.MarkFlagRequired("name")
+}
+
+var removeCmd = &cobra.Command{
+	Use:     "remove",
+	Short:   removeShort,
+	Long:    removeLong,
+	Example: removeExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		input := profile.NewProfile(
+			profile.WithName(name),
+			profile.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Remove(cmd.OutOrStdout()), cmd)
+	},
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	useTool    = "profile-use"
+	useShort   = "Make a profile current"
+	useLong    = "Make a named profile current, so that commands run without --profile or YUTU_PROFILE use it. A running MCP server keeps the profile it was started with."
+	useExample = `# Switch to a profile
+yutu profile use --name gaming`
+)
+
+var useInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{"name"},
+	Properties: map[string]*jsonschema.Schema{
+		"name": {Type: "string", Description: nameUsage},
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "silent"},
+			Description: pkg.SilentUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: useTool, Title: useShort, Description: useLong,
+			InputSchema: useInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    false,
+			},
+		}, cobramcp.GenToolHandler(
+			useTool, func(input profile.Profile, writer io.Writer) error {
+				return input.Use(writer)
+			},
+		),
+	)
+	profileCmd.AddCommand(useCmd)
+
+	useCmd.Flags().StringVarP(&name, "name", "n", "", nameUsage)
+	useCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
+
+	_ = useCmd.MarkFlagRequired("name")
+}
+
+var useCmd = &cobra.Command{
+	Use:     "use",
+	Short:   useShort,
+	Long:    useLong,
+	Example: useExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		input := profile.NewProfile(
+			profile.WithName(name),
+			profile.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Use(cmd.OutOrStdout()), cmd)
+	},
+}
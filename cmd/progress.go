@@ -0,0 +1,39 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/eat-pray-ai/yutu/pkg/progress"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+// ProgressContext returns the command context, with a progress bar attached
+// when stderr is an interactive terminal.
+func ProgressContext(c *cobra.Command) context.Context {
+	ctx := c.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !utils.IsInteractive(c.ErrOrStderr()) {
+		return ctx
+	}
+	return progress.NewContext(ctx, progress.Bar(c.ErrOrStderr()))
+}
+
+// WithProgress wraps an MCP tool handler so that uploads send
+// notifications/progress when the client supplied a progress token.
+func WithProgress[In, Out any](
+	h mcp.ToolHandlerFor[In, Out],
+) mcp.ToolHandlerFor[In, Out] {
+	return func(
+		ctx context.Context, req *mcp.CallToolRequest, input In,
+	) (*mcp.CallToolResult, Out, error) {
+		ctx = progress.NewContext(ctx, progress.MCP(ctx, req))
+		return h(ctx, req, input)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	short = "Manage local API quota accounting"
+	long  = "Manage local API quota accounting. Every YouTube Data API call made by yutu is recorded with its method and unit cost in a file next to the token cache, which starts over at midnight Pacific time when YouTube resets the project quota. Calls that would exceed --quota-budget or YUTU_QUOTA_BUDGET are refused before they are sent."
+)
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: short,
+	Long:  long,
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(quotaCmd)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/quota"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusTool    = "quota-status"
+	statusShort   = "Show quota units spent today"
+	statusLong    = "Show the YouTube Data API quota units spent today per method, what is left of the budget, or of the default 10000 units without one, and when the quota resets. Use this tool before expensive operations such as video uploads (1600 units) or searches (100 units)."
+	statusExample = `# Show today's usage per method
+yutu quota status
+# Show usage against a budget in JSON format
+yutu quota status --quota-budget 5000 --output json`
+)
+
+var statusInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{},
+	Properties: map[string]*jsonschema.Schema{
+		"output": {
+			Type: "string", Enum: []any{"json", "yaml", "table"},
+			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: statusTool, Title: statusShort, Description: statusLong,
+			InputSchema: statusInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(false),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			statusTool, func(input quota.Quota, writer io.Writer) error {
+				return input.Status(writer)
+			},
+		),
+	)
+	quotaCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+}
+
+var statusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   statusShort,
+	Long:    statusLong,
+	Example: statusExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		input := quota.NewQuota(quota.WithOutput(output))
+		utils.HandleCmdError(input.Status(cmd.OutOrStdout()), cmd)
+	},
+}
@@ -6,6 +6,12 @@ package cmd
 import (
 	"os"
 
+	"github.com/eat-pray-ai/yutu/pkg/cache"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/eat-pray-ai/yutu/pkg/quota"
+	"github.com/eat-pray-ai/yutu/pkg/retry"
 	"github.com/spf13/cobra"
 )
 
@@ -14,25 +20,164 @@ const (
 	long  = `yutu is a CLI, MCP server, and AI agent for YouTube that can automate almost all YouTube workflows.
 
 Environment variables:
-  YUTU_CREDENTIAL    Path/Base64/JSON of OAuth client secret (default: client_secret.json)
-  YUTU_CACHE_TOKEN   Path/Base64/JSON of cached OAuth token (default: youtube.token.json)
-  YUTU_ROOT          Root directory for file resolution (default: current working directory)
-  YUTU_LOG_LEVEL     Log level: DEBUG, INFO, WARN, ERROR (default: INFO)`
+  YUTU_CREDENTIAL         Path/Base64/JSON of OAuth client secret (default: client_secret.json)
+  YUTU_CACHE_TOKEN        Path/Base64/JSON of cached OAuth token (default: youtube.token.json)
+  YUTU_CREDENTIAL_HELPER  Program run with get/store that keeps the client secret and token (default: none)
+  YUTU_PROFILE            Named profile to use, see yutu profile (default: the current profile)
+  YUTU_API_KEY            API key for public read-only calls instead of OAuth (default: none)
+  YUTU_ROOT               Root directory for file resolution (default: current working directory)
+  YUTU_LOG_LEVEL          Log level: DEBUG, INFO, WARN, ERROR (default: INFO)
+  YUTU_QUOTA_BUDGET       Quota units yutu may spend per Pacific day, 0 for no limit (default: 0)
+  YUTU_RETRY_ATTEMPTS     Tries per API call on transient errors, 1 to disable retries (default: 4)
+  YUTU_RETRY_MAX_DELAY    Longest wait between tries, e.g. 30s (default: 30s)
+  YUTU_CACHE_TTL          Serve list responses from a local cache for this long, e.g. 5m (default: 0, off)`
+	budgetUsage   = "Quota units yutu may spend per Pacific day, 0 for no limit (env: YUTU_QUOTA_BUDGET)"
+	attemptsUsage = "Tries per API call on transient errors, 1 to disable retries (env: YUTU_RETRY_ATTEMPTS)"
+	maxDelayUsage = "Longest wait between tries, a longer Retry-After gives up (env: YUTU_RETRY_MAX_DELAY)"
+	cacheTTLUsage = "Serve list responses from a local cache for this long, 0 to disable (env: YUTU_CACHE_TTL)"
+	profileUsage  = "Named profile whose credential, token and defaults to use (env: YUTU_PROFILE)"
+	apiKeyUsage   = "API key for public read-only calls instead of OAuth, changes are refused (env: YUTU_API_KEY)"
 )
 
+var (
+	profileName string
+	// activeProfile is the profile activated for this run, or nil.
+	activeProfile *profile.Account
+)
+
+// SkipProfile annotates commands, and with them their subcommands, that run
+// without activating a profile.
+const SkipProfile = "skipProfile"
+
+// otherChannelCommands use --channelId for a channel other than the
+// profile's, so it is not filled in from the profile.
+var otherChannelCommands = map[string]bool{
+	"yutu search list":         true,
+	"yutu subscription insert": true,
+}
+
+// channelFilters are the flags of a command that, like --channelId, choose
+// what it lists. The profile's channel is only filled in when none of them
+// is set, as the API refuses more than one.
+var channelFilters = map[string][]string{
+	"yutu activity list":       {"for"},
+	"yutu channelSection list": {"ids", "mine"},
+	"yutu commentThread list":  {"ids", "videoId", "allThreadsRelatedToChannelId"},
+	"yutu playlist list":       {"ids", "mine"},
+	"yutu subscription list":   {"ids", "for", "forChannelId"},
+}
+
 var RootCmd = &cobra.Command{
 	Use:   "yutu",
 	Short: short,
 	Long:  long,
 
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		list := &common.Fields{}
+		list.Output, _ = cmd.Flags().GetString("output")
+		list.Where, _ = cmd.Flags().GetString("where")
+		list.SortBy, _ = cmd.Flags().GetString("sort-by")
+		list.Envelope, _ = cmd.Flags().GetBool("envelope")
+		if err := common.CheckList(list); err != nil {
+			return err
+		}
+		return applyProfile(cmd)
+	},
 	Run: func(cmd *cobra.Command, _ []string) {
 		_ = cmd.Help()
 	},
 }
 
+func init() {
+	// Resource commands have hooks of their own, the profile must still apply.
+	cobra.EnableTraverseRunHooks = true
+
+	RootCmd.PersistentFlags().StringVar(
+		&profileName, "profile", "", profileUsage,
+	)
+	RootCmd.PersistentFlags().StringVar(
+		&common.APIKey, "api-key", "", apiKeyUsage,
+	)
+	RootCmd.PersistentFlags().Int64Var(
+		&quota.Budget, "quota-budget", quota.Budget, budgetUsage,
+	)
+	RootCmd.PersistentFlags().IntVar(
+		&retry.Default.Attempts, "retry-attempts", retry.Default.Attempts,
+		attemptsUsage,
+	)
+	RootCmd.PersistentFlags().DurationVar(
+		&retry.Default.MaxDelay, "retry-max-delay", retry.Default.MaxDelay,
+		maxDelayUsage,
+	)
+	RootCmd.PersistentFlags().DurationVar(
+		&cache.TTL, "cache-ttl", cache.TTL, cacheTTLUsage,
+	)
+}
+
+// applyProfile activates the selected profile and fills the content owner
+// and channel flags of cmd the user left unset with its settings.
+func applyProfile(cmd *cobra.Command) error {
+	if skipsProfile(cmd) {
+		return nil
+	}
+	a, err := profile.Active(profileName)
+	if err != nil || a == nil {
+		return err
+	}
+	if err := a.Activate(); err != nil {
+		return err
+	}
+	activeProfile = a
+
+	flags := cmd.Flags()
+	defaults := map[string]string{
+		"onBehalfOfContentOwner":        a.OnBehalfOfContentOwner,
+		"onBehalfOfContentOwnerChannel": a.OnBehalfOfContentOwnerChannel,
+	}
+	if fillsChannel(cmd.CommandPath(), flags.Changed) {
+		defaults["channelId"] = a.ChannelId
+	}
+	for name, value := range defaults {
+		if f := flags.Lookup(name); f != nil && value != "" && !f.Changed {
+			if err := flags.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func skipsProfile(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[SkipProfile]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fillsChannel reports whether the profile's channel is filled in for the
+// command at path, given which of its flags are set.
+func fillsChannel(path string, set func(flag string) bool) bool {
+	if otherChannelCommands[path] {
+		return false
+	}
+	for _, name := range channelFilters[path] {
+		if set(name) {
+			return false
+		}
+	}
+	return true
+}
+
 func Execute() {
 	err := RootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		// Cobra only returns flag, argument and confirmation mistakes.
+		f := failure.Classify(err)
+		if f.Class == failure.Unknown {
+			f.ExitCode = failure.InvalidArgument.ExitCode()
+		}
+		os.Exit(f.ExitCode)
 	}
 }
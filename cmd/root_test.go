@@ -0,0 +1,102 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/profile"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyProfile_ChannelId(t *testing.T) {
+	common.SetTestRoot(t)
+	t.Setenv(profile.Env, "")
+	t.Setenv("YUTU_CREDENTIAL", "")
+	old := activeProfile
+	t.Cleanup(func() { activeProfile = old })
+
+	err := profile.NewProfile(
+		profile.WithName("gaming"), profile.WithChannelId("UC1"),
+		profile.WithOutput("silent"),
+	).Add(&bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		path  []string
+		flags map[string]string
+		want  string
+	}{
+		{name: "no filter", path: []string{"subscription", "list"}, want: "UC1"},
+		{
+			name: "subscription for", path: []string{"subscription", "list"},
+			flags: map[string]string{"for": "mine"},
+		},
+		{
+			name: "subscription forChannelId", path: []string{"subscription", "list"},
+			flags: map[string]string{"forChannelId": "UC9"},
+		},
+		{
+			name: "activity for", path: []string{"activity", "list"},
+			flags: map[string]string{"for": "mine"},
+		},
+		{
+			name: "commentThread videoId", path: []string{"commentThread", "list"},
+			flags: map[string]string{"videoId": "v1"},
+		},
+		{
+			name: "commentThread allThreads", path: []string{"commentThread", "list"},
+			flags: map[string]string{"allThreadsRelatedToChannelId": "UC9"},
+		},
+		{
+			name: "playlist mine", path: []string{"playlist", "list"},
+			flags: map[string]string{"mine": "true"},
+		},
+		{name: "other channel", path: []string{"search", "list"}},
+		{
+			name: "explicit channel", path: []string{"playlist", "list"},
+			flags: map[string]string{"channelId": "UC2"}, want: "UC2",
+		},
+		{
+			name: "other flag", path: []string{"activity", "list"},
+			flags: map[string]string{"regionCode": "US"}, want: "UC1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cmd := &cobra.Command{Use: "yutu"}
+				for _, name := range tt.path {
+					sub := &cobra.Command{Use: name}
+					cmd.AddCommand(sub)
+					cmd = sub
+				}
+				for _, name := range []string{
+					"channelId", "for", "forChannelId", "videoId",
+					"allThreadsRelatedToChannelId", "regionCode",
+				} {
+					cmd.Flags().String(name, "", "")
+				}
+				cmd.Flags().Bool("mine", false, "")
+				for name, value := range tt.flags {
+					if err := cmd.Flags().Set(name, value); err != nil {
+						t.Fatalf("Set(%s) error = %v", name, err)
+					}
+				}
+
+				if err := applyProfile(cmd); err != nil {
+					t.Fatalf("applyProfile() error = %v", err)
+				}
+				if got, _ := cmd.Flags().GetString("channelId"); got != tt.want {
+					t.Errorf("channelId = %q, want %q", got, tt.want)
+				}
+			},
+		)
+	}
+}
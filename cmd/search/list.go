@@ -31,7 +31,7 @@ yutu search list --channelId UC_x5XG1OV2P6uZZ5FSM9Ttw --order date --maxResults
 yutu search list --q 'live coding' --eventType live --types video`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -124,12 +124,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -185,7 +181,7 @@ func init() {
 	listCmd.Flags().StringSliceVar(
 		&parts, "parts", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().String("output", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -193,39 +189,40 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := search.NewSearch(
-			search.WithChannelId(channelId),
-			search.WithChannelType(channelType),
-			search.WithEventType(eventType),
-			search.WithFor(searchFor),
-			search.WithLocation(location),
-			search.WithLocationRadius(locationRadius),
-			search.WithMaxResults(maxResults),
-			search.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			search.WithOrder(order),
-			search.WithPublishedAfter(publishedAfter),
-			search.WithPublishedBefore(publishedBefore),
-			search.WithQ(q),
-			search.WithRegionCode(regionCode),
-			search.WithRelevanceLanguage(relevanceLanguage),
-			search.WithSafeSearch(safeSearch),
-			search.WithTopicId(topicId),
-			search.WithTypes(types),
-			search.WithVideoCaption(videoCaption),
-			search.WithVideoCategoryId(videoCategoryId),
-			search.WithVideoDefinition(videoDefinition),
-			search.WithVideoDimension(videoDimension),
-			search.WithVideoDuration(videoDuration),
-			search.WithVideoEmbeddable(videoEmbeddable),
-			search.WithVideoLicense(videoLicense),
-			search.WithVideoPaidProductPlacement(videoPaidProductPlacement),
-			search.WithVideoSyndicated(videoSyndicated),
-			search.WithVideoType(videoType),
-			search.WithParts(parts),
-			search.WithOutput(output),
+			append(
+				cmd.ListOptions[search.Option](c),
+				search.WithChannelId(channelId),
+				search.WithChannelType(channelType),
+				search.WithEventType(eventType),
+				search.WithFor(searchFor),
+				search.WithLocation(location),
+				search.WithLocationRadius(locationRadius),
+				search.WithMaxResults(maxResults),
+				search.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				search.WithOrder(order),
+				search.WithPublishedAfter(publishedAfter),
+				search.WithPublishedBefore(publishedBefore),
+				search.WithQ(q),
+				search.WithRegionCode(regionCode),
+				search.WithRelevanceLanguage(relevanceLanguage),
+				search.WithSafeSearch(safeSearch),
+				search.WithTopicId(topicId),
+				search.WithTypes(types),
+				search.WithVideoCaption(videoCaption),
+				search.WithVideoCategoryId(videoCategoryId),
+				search.WithVideoDefinition(videoDefinition),
+				search.WithVideoDimension(videoDimension),
+				search.WithVideoDuration(videoDuration),
+				search.WithVideoEmbeddable(videoEmbeddable),
+				search.WithVideoLicense(videoLicense),
+				search.WithVideoPaidProductPlacement(videoPaidProductPlacement),
+				search.WithVideoSyndicated(videoSyndicated),
+				search.WithVideoType(videoType),
+				search.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
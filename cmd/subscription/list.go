@@ -33,7 +33,7 @@ yutu subscription list --ids abc123,def456 --output json
 yutu subscription list --forChannelId UC_x5XG1OV2P6uZZ5FSM9Ttw --order alphabetical`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -72,12 +72,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -113,7 +109,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -121,20 +117,21 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := subscription.NewSubscription(
-			subscription.WithIds(ids),
-			subscription.WithChannelId(channelId),
-			subscription.WithForChannelId(forChannelId),
-			subscription.WithMaxResults(maxResults),
-			subscription.WithFor(subscriptionFor),
-			subscription.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			subscription.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
-			subscription.WithOrder(order),
-			subscription.WithParts(parts),
-			subscription.WithOutput(output),
+			append(
+				cmd.ListOptions[subscription.Option](c),
+				subscription.WithIds(ids),
+				subscription.WithChannelId(channelId),
+				subscription.WithForChannelId(forChannelId),
+				subscription.WithMaxResults(maxResults),
+				subscription.WithFor(subscriptionFor),
+				subscription.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				subscription.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
+				subscription.WithOrder(order),
+				subscription.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
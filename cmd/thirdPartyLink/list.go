@@ -29,7 +29,7 @@ yutu thirdPartyLink list --linkingToken abc123 --parts snippet,status
 yutu thirdPartyLink list --type channelToStoreLink --parts snippet,status`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -41,12 +41,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["snippet","status"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag)
 
 func init() {
 	mcp.AddTool(
@@ -75,7 +71,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"snippet", "status"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 var listCmd = &cobra.Command{
@@ -83,15 +79,16 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := thirdPartyLink.NewThirdPartyLink(
-			thirdPartyLink.WithLinkingToken(linkingToken),
-			thirdPartyLink.WithType(linkType),
-			thirdPartyLink.WithExternalChannelId(externalChannelId),
-			thirdPartyLink.WithParts(parts),
-			thirdPartyLink.WithOutput(output),
+			append(
+				cmd.ListOptions[thirdPartyLink.Option](c),
+				thirdPartyLink.WithLinkingToken(linkingToken),
+				thirdPartyLink.WithType(linkType),
+				thirdPartyLink.WithExternalChannelId(externalChannelId),
+				thirdPartyLink.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
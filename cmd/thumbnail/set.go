@@ -52,13 +52,15 @@ func init() {
 				OpenWorldHint:   new(true),
 				ReadOnlyHint:    false,
 			},
-		}, cobramcp.GenToolHandler(
-			setTool, func(input thumbnail.Thumbnail, writer io.Writer) error {
-				if !input.Confirmed {
-					return utils.ErrNotConfirmed
-				}
-				return input.Set(writer)
-			},
+		}, cmd.WithProgress(
+			cobramcp.GenToolHandler(
+				setTool, func(input thumbnail.Thumbnail, writer io.Writer) error {
+					if !input.Confirmed {
+						return utils.ErrNotConfirmed
+					}
+					return input.Set(writer)
+				},
+			),
 		),
 	)
 	thumbnailCmd.AddCommand(setCmd)
@@ -86,6 +88,7 @@ var setCmd = &cobra.Command{
 			thumbnail.WithFile(file),
 			thumbnail.WithVideoId(videoId),
 			thumbnail.WithOutput(output),
+			thumbnail.WithContext(cmd.ProgressContext(c)),
 		)
 		utils.HandleCmdError(input.Set(c.OutOrStdout()), c)
 	},
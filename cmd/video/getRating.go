@@ -10,6 +10,7 @@ import (
 	cobramcp "github.com/eat-pray-ai/cobra-mcp"
 	"github.com/eat-pray-ai/yutu/cmd"
 	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/eat-pray-ai/yutu/pkg/utils"
 	"github.com/eat-pray-ai/yutu/pkg/video"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -37,9 +38,15 @@ var getRatingInSchema = &jsonschema.Schema{
 			Items: &jsonschema.Schema{Type: "string"},
 		},
 		"on_behalf_of_content_owner": {Type: "string", Description: pkg.OBOCOUsage},
+		"columns": {
+			Type: "array", Description: pkg.ColumnsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"where":   {Type: "string", Description: pkg.WhereUsage},
+		"sort_by": {Type: "string", Description: pkg.SortByUsage},
 		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
+			Type: "string", Pattern: common.OutputPattern(),
+			Description: common.ListUsage(), Default: json.RawMessage(`"yaml"`),
 		},
 	},
 }
@@ -66,7 +73,10 @@ func init() {
 	getRatingCmd.Flags().StringVarP(
 		&onBehalfOfContentOwner, "onBehalfOfContentOwner", "b", "", pkg.OBOCOUsage,
 	)
-	getRatingCmd.Flags().StringP("output", "o", "", pkg.TableUsage)
+	getRatingCmd.Flags().StringP("output", "o", "", common.ListUsage())
+	getRatingCmd.Flags().StringSlice("columns", nil, pkg.ColumnsUsage)
+	getRatingCmd.Flags().String("where", "", pkg.WhereUsage)
+	getRatingCmd.Flags().String("sort-by", "", pkg.SortByUsage)
 	_ = getRatingCmd.MarkFlagRequired("ids")
 }
 
@@ -77,9 +87,15 @@ var getRatingCmd = &cobra.Command{
 	Example: getRatingExample,
 	Run: func(cmd *cobra.Command, _ []string) {
 		output, _ := cmd.Flags().GetString("output")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		where, _ := cmd.Flags().GetString("where")
+		sortBy, _ := cmd.Flags().GetString("sort-by")
 		input := video.NewVideo(
 			video.WithIds(ids),
 			video.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+			video.WithColumns(columns),
+			video.WithWhere(where),
+			video.WithSortBy(sortBy),
 			video.WithOutput(output),
 			video.WithService(nil),
 		)
@@ -28,12 +28,28 @@ yutu video insert --file video.mp4 --title 'My Video' --categoryId 22 --privacy
 # Upload a private video with tags
 yutu video insert --file video.mp4 --title 'Tutorial' --categoryId 27 --privacy private --tags 'go,tutorial'
 # Upload an unlisted video with custom thumbnail
-yutu video insert --file video.mp4 --title 'Music Video' --categoryId 10 --privacy unlisted --thumbnail cover.jpg`
+yutu video insert --file video.mp4 --title 'Music Video' --categoryId 10 --privacy unlisted --thumbnail cover.jpg
+# Upload with thumbnail, playlist and caption, deleting the video if any of them fails
+yutu video insert --file video.mp4 --title 'Episode 1' --categoryId 22 --privacy private --thumbnail cover.jpg --playlistId PLxxx --caption en.srt --captionLanguage en --atomic --output json
+# Upload a large video in chunks, rerun the same command to resume after a failure
+yutu video insert --file video.mp4 --title 'Long Stream' --categoryId 20 --privacy private --resume
+# Upload a video piped from another program, a title is required
+ffmpeg -i input.mkv -c copy -movflags frag_keyframe+empty_moov -f mp4 - | yutu video insert --file - --title 'Piped' --categoryId 22 --privacy private --yes
+# Upload with chapters listed in a CSV of time,title
+yutu video insert --file video.mp4 --title 'Tutorial' --categoryId 27 --privacy private --chapters chapters.csv
+# Take the recording date from the file and check it before uploading
+yutu video insert --file video.mp4 --title 'Holiday' --categoryId 19 --privacy private --probe
+# Upload a file even if the local ledger shows it was uploaded before
+yutu video insert --file video.mp4 --title 'Reupload' --categoryId 22 --privacy private --force
+# Upload a video and wait until YouTube finishes processing it
+yutu video insert --file video.mp4 --title 'My Video' --categoryId 22 --privacy private --wait --waitTimeout 1h
+# Upload every entry of a manifest, rerun to retry only the failed ones
+yutu video insert --manifest uploads.yaml --categoryId 22 --privacy private --concurrency 3`
 )
 
 var insertInSchema = &jsonschema.Schema{
 	Type:     "object",
-	Required: []string{"file", "category_id", "privacy"},
+	Required: []string{"category_id", "privacy"},
 	Properties: map[string]*jsonschema.Schema{
 		"auto_levels": {Type: "boolean", Description: alUsage},
 		"file":        {Type: "string", Description: fileUsage},
@@ -60,11 +76,27 @@ var insertInSchema = &jsonschema.Schema{
 		"embeddable":               {Type: "boolean", Description: embeddableUsage},
 		"contains_synthetic_media": {Type: "boolean", Description: csmUsage},
 		"recording_date":           {Type: "string", Description: rdUsage},
+		"chapters":                 {Type: "string", Description: chaptersUsage},
 		"publish_at":               {Type: "string", Description: paUsage},
 		"stabilize":                {Type: "boolean", Description: stabilizeUsage},
 		"notify_subscribers":       {Type: "boolean", Description: nsUsage},
 		"public_stats_viewable":    {Type: "boolean", Description: psvUsage},
+		"resume":                   {Type: "boolean", Description: resumeUsage},
+		"force":                    {Type: "boolean", Description: forceUsage},
+		"wait":                     {Type: "boolean", Description: waitUsage},
+		"caption":                  {Type: "string", Description: captionUsage},
+		"caption_language":         {Type: "string", Description: clUsage},
+		"caption_name":             {Type: "string", Description: cnUsage},
+		"atomic":                   {Type: "boolean", Description: atomicUsage},
+		"probe":                    {Type: "boolean", Description: probeUsage},
+		"wait_timeout":             {Type: "string", Description: wtUsage},
+		"manifest":                 {Type: "string", Description: manifestUsage},
+		"results":                  {Type: "string", Description: resultsUsage},
 		"confirmed":                {Type: "boolean", Description: pkg.ConfirmedUsage},
+		"concurrency": {
+			Type: "number", Description: concUsage,
+			Default: json.RawMessage("2"), Minimum: new(float64(1)),
+		},
 
 		"on_behalf_of_content_owner": {
 			Type:        "string",
@@ -91,13 +123,15 @@ func init() {
 				OpenWorldHint:   new(true),
 				ReadOnlyHint:    false,
 			},
-		}, cobramcp.GenToolHandler(
-			insertTool, func(input video.Video, writer io.Writer) error {
-				if !input.Confirmed {
-					return utils.ErrNotConfirmed
-				}
-				return input.Insert(writer)
-			},
+		}, cmd.WithProgress(
+			cobramcp.GenToolHandler(
+				insertTool, func(input video.Video, writer io.Writer) error {
+					if !input.Confirmed {
+						return utils.ErrNotConfirmed
+					}
+					return input.Insert(writer)
+				},
+			),
 		),
 	)
 	videoCmd.AddCommand(insertCmd)
@@ -105,7 +139,7 @@ func init() {
 	insertCmd.Flags().BoolVarP(
 		autoLevels, "autoLevels", "A", true, alUsage,
 	)
-	insertCmd.Flags().StringVarP(&file, "file", "f", "", fileUsage)
+	insertCmd.Flags().StringVarP(&file, "file", "f", "", fileUsage+", - to read from stdin")
 	insertCmd.Flags().StringVarP(&title, "title", "t", "", titleUsage)
 	insertCmd.Flags().StringVarP(&description, "description", "d", "", descUsage)
 	insertCmd.Flags().StringSliceVarP(&tags, "tags", "a", []string{}, tagsUsage)
@@ -124,6 +158,7 @@ func init() {
 		containsSyntheticMedia, "containsSyntheticMedia", "M", false, csmUsage,
 	)
 	insertCmd.Flags().StringVarP(&recordingDate, "recordingDate", "D", "", rdUsage)
+	insertCmd.Flags().StringVar(&chapters, "chapters", "", chaptersUsage)
 	insertCmd.Flags().StringVarP(&publishAt, "publishAt", "U", "", paUsage)
 	insertCmd.Flags().BoolVarP(stabilize, "stabilize", "S", true, stabilizeUsage)
 	insertCmd.Flags().BoolVarP(
@@ -132,6 +167,18 @@ func init() {
 	insertCmd.Flags().BoolVarP(
 		publicStatsViewable, "publicStatsViewable", "P", false, psvUsage,
 	)
+	insertCmd.Flags().StringVar(&caption, "caption", "", captionUsage)
+	insertCmd.Flags().StringVar(&captionLanguage, "captionLanguage", "", clUsage)
+	insertCmd.Flags().StringVar(&captionName, "captionName", "", cnUsage)
+	insertCmd.Flags().BoolVar(&atomic, "atomic", false, atomicUsage)
+	insertCmd.Flags().BoolVar(&probe, "probe", false, probeUsage)
+	insertCmd.Flags().BoolVarP(&resume, "resume", "R", false, resumeUsage)
+	insertCmd.Flags().BoolVar(&force, "force", false, forceUsage)
+	insertCmd.Flags().BoolVarP(&wait, "wait", "w", false, waitUsage)
+	insertCmd.Flags().StringVar(&waitTimeout, "waitTimeout", "30m", wtUsage)
+	insertCmd.Flags().StringVarP(&manifest, "manifest", "m", "", manifestUsage)
+	insertCmd.Flags().StringVarP(&results, "results", "r", "", resultsUsage)
+	insertCmd.Flags().Int64VarP(&concurrency, "concurrency", "C", 2, concUsage)
 	insertCmd.Flags().StringVarP(
 		&onBehalfOfContentOwner, "onBehalfOfContentOwner", "b", "", pkg.OBOCOUsage,
 	)
@@ -141,7 +188,8 @@ func init() {
 	)
 	insertCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
 	insertCmd.Flags().Bool("yes", false, pkg.ConfirmedUsage)
-	_ = insertCmd.MarkFlagRequired("file")
+	insertCmd.MarkFlagsOneRequired("file", "manifest")
+	insertCmd.MarkFlagsMutuallyExclusive("file", "manifest")
 	_ = insertCmd.MarkFlagRequired("categoryId")
 	_ = insertCmd.MarkFlagRequired("privacy")
 }
@@ -153,13 +201,21 @@ var insertCmd = &cobra.Command{
 	Example: insertExample,
 	PreRunE: func(c *cobra.Command, _ []string) error {
 		msg := fmt.Sprintf("Would insert video: %s", file)
+		if manifest != "" {
+			msg = fmt.Sprintf("Would insert videos from manifest: %s", manifest)
+		}
 		return utils.ConfirmPreRun(c, msg)
 	},
 	Run: func(c *cobra.Command, _ []string) {
 		output, _ := c.Flags().GetString("output")
+		var reader io.Reader
+		if file == "-" {
+			reader = c.InOrStdin()
+		}
 		input := video.NewVideo(
 			video.WithAutoLevels(autoLevels),
 			video.WithFile(file),
+			video.WithReader(reader),
 			video.WithTitle(title),
 			video.WithDescription(description),
 			video.WithTags(tags),
@@ -174,13 +230,27 @@ var insertCmd = &cobra.Command{
 			video.WithEmbeddable(embeddable),
 			video.WithContainsSyntheticMedia(containsSyntheticMedia),
 			video.WithRecordingDate(recordingDate),
+			video.WithChapters(chapters),
 			video.WithPublishAt(publishAt),
 			video.WithStabilize(stabilize),
 			video.WithNotifySubscribers(notifySubscribers),
 			video.WithPublicStatsViewable(publicStatsViewable),
+			video.WithCaption(caption),
+			video.WithCaptionLanguage(captionLanguage),
+			video.WithCaptionName(captionName),
+			video.WithAtomic(atomic),
+			video.WithProbe(probe),
+			video.WithResume(resume),
+			video.WithForce(force),
+			video.WithWait(wait),
+			video.WithWaitTimeout(waitTimeout),
+			video.WithManifest(manifest),
+			video.WithResults(results),
+			video.WithConcurrency(concurrency),
 			video.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
 			video.WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel),
 			video.WithOutput(output),
+			video.WithContext(cmd.ProgressContext(c)),
 		)
 		utils.HandleCmdError(input.Insert(c.OutOrStdout()), c)
 	},
@@ -33,7 +33,7 @@ yutu video list --chart mostPopular --regionCode US --maxResults 10
 yutu video list --myRating like --output yaml`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -66,12 +66,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet","status","statistics"]`),
 		},
-		"output": {
-			Type: "string", Enum: []any{"json", "yaml", "table"},
-			Description: pkg.TableUsage, Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag, cmd.PageFlags)
 
 func init() {
 	mcp.AddTool(
@@ -108,7 +104,7 @@ func init() {
 		&parts, "parts", "p", []string{"id", "snippet", "status", "statistics"},
 		pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag, cmd.PageFlags)
 }
 
 var listCmd = &cobra.Command{
@@ -116,23 +112,24 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := video.NewVideo(
-			video.WithIds(ids),
-			video.WithChart(chart),
-			video.WithHl(hl),
-			video.WithLocale(locale),
-			video.WithCategory(categoryId),
-			video.WithRegionCode(regionCode),
-			video.WithMaxHeight(maxHeight),
-			video.WithMaxWidth(maxWidth),
-			video.WithMaxResults(maxResults),
-			video.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
-			video.WithRating(rating),
-			video.WithParts(parts),
-			video.WithOutput(output),
+			append(
+				cmd.ListOptions[video.Option](c),
+				video.WithIds(ids),
+				video.WithChart(chart),
+				video.WithHl(hl),
+				video.WithLocale(locale),
+				video.WithCategory(categoryId),
+				video.WithRegionCode(regionCode),
+				video.WithMaxHeight(maxHeight),
+				video.WithMaxWidth(maxWidth),
+				video.WithMaxResults(maxResults),
+				video.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+				video.WithRating(rating),
+				video.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
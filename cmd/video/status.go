@@ -0,0 +1,103 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"encoding/json"
+	"io"
+
+	cobramcp "github.com/eat-pray-ai/cobra-mcp"
+	"github.com/eat-pray-ai/yutu/cmd"
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/eat-pray-ai/yutu/pkg/video"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusTool     = "video-status"
+	statusIdsUsage = "IDs of the videos to report the status of"
+	statusShort    = "Show upload and processing status of videos"
+	statusLong     = "Show upload and processing status of videos. Use this tool to check whether uploaded videos finished processing, failed or were rejected, including failure and rejection reasons, processing progress and processing suggestions. Only works for videos owned by the authorized channel."
+	statusExample  = `# Check whether a freshly uploaded video finished processing
+yutu video status --ids dQw4w9WgXcQ
+# Show the full report, including processing errors and hints, in JSON
+yutu video status --ids dQw4w9WgXcQ,abc123 --output json`
+)
+
+var statusInSchema = &jsonschema.Schema{
+	Type:     "object",
+	Required: []string{"ids"},
+	Properties: map[string]*jsonschema.Schema{
+		"ids": {
+			Type: "array", Description: statusIdsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"on_behalf_of_content_owner": {Type: "string", Description: pkg.OBOCOUsage},
+		"columns": {
+			Type: "array", Description: pkg.ColumnsUsage,
+			Items: &jsonschema.Schema{Type: "string"},
+		},
+		"where":   {Type: "string", Description: pkg.WhereUsage},
+		"sort_by": {Type: "string", Description: pkg.SortByUsage},
+		"output": {
+			Type: "string", Pattern: common.OutputPattern(),
+			Description: common.ListUsage(), Default: json.RawMessage(`"yaml"`),
+		},
+	},
+}
+
+func init() {
+	mcp.AddTool(
+		cmd.Server, &mcp.Tool{
+			Name: statusTool, Title: statusShort, Description: statusLong,
+			InputSchema: statusInSchema, Annotations: &mcp.ToolAnnotations{
+				DestructiveHint: new(false),
+				IdempotentHint:  true,
+				OpenWorldHint:   new(true),
+				ReadOnlyHint:    true,
+			},
+		}, cobramcp.GenToolHandler(
+			statusTool, func(input video.Video, writer io.Writer) error {
+				return input.Status(writer)
+			},
+		),
+	)
+	videoCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringSliceVarP(&ids, "ids", "i", []string{}, statusIdsUsage)
+	statusCmd.Flags().StringVarP(
+		&onBehalfOfContentOwner, "onBehalfOfContentOwner", "b", "", pkg.OBOCOUsage,
+	)
+	statusCmd.Flags().StringP("output", "o", "table", common.ListUsage())
+	statusCmd.Flags().StringSlice("columns", nil, pkg.ColumnsUsage)
+	statusCmd.Flags().String("where", "", pkg.WhereUsage)
+	statusCmd.Flags().String("sort-by", "", pkg.SortByUsage)
+	_ = statusCmd.MarkFlagRequired("ids")
+}
+
+var statusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   statusShort,
+	Long:    statusLong,
+	Example: statusExample,
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, _ := cmd.Flags().GetString("output")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		where, _ := cmd.Flags().GetString("where")
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		input := video.NewVideo(
+			video.WithIds(ids),
+			video.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+			video.WithColumns(columns),
+			video.WithWhere(where),
+			video.WithSortBy(sortBy),
+			video.WithOutput(output),
+		)
+		utils.HandleCmdError(input.Status(cmd.OutOrStdout()), cmd)
+	},
+}
@@ -32,7 +32,9 @@ yutu video update --id dQw4w9WgXcQ --description 'Updated description' --privacy
 # Schedule a private video to publish later
 yutu video update --id dQw4w9WgXcQ --privacy private --publishAt 2026-08-18T15:00:00Z
 # Update video tags and category
-yutu video update --id dQw4w9WgXcQ --tags 'music,pop,2024' --categoryId 10`
+yutu video update --id dQw4w9WgXcQ --tags 'music,pop,2024' --categoryId 10
+# Add chapters to the description, checked against the video duration
+yutu video update --id dQw4w9WgXcQ --chapters chapters.txt`
 )
 
 var updateInSchema = &jsonschema.Schema{
@@ -65,6 +67,7 @@ var updateInSchema = &jsonschema.Schema{
 		"embeddable":               {Type: "boolean", Description: embeddableUsage},
 		"contains_synthetic_media": {Type: "boolean", Description: csmUsage},
 		"recording_date":           {Type: "string", Description: rdUsage},
+		"chapters":                 {Type: "string", Description: chaptersUsage},
 		"confirmed":                {Type: "boolean", Description: pkg.ConfirmedUsage},
 		"output": {
 			Type: "string", Enum: []any{"json", "yaml", "silent"},
@@ -113,6 +116,7 @@ func init() {
 		containsSyntheticMedia, "containsSyntheticMedia", "M", false, csmUsage,
 	)
 	updateCmd.Flags().StringVarP(&recordingDate, "recordingDate", "D", "", rdUsage)
+	updateCmd.Flags().StringVar(&chapters, "chapters", "", chaptersUsage)
 	updateCmd.Flags().StringP("output", "o", "", pkg.SilentUsage)
 	updateCmd.Flags().Bool("yes", false, pkg.ConfirmedUsage)
 	_ = updateCmd.MarkFlagRequired("id")
@@ -144,6 +148,7 @@ var updateCmd = &cobra.Command{
 			video.WithEmbeddable(embeddable),
 			video.WithContainsSyntheticMedia(containsSyntheticMedia),
 			video.WithRecordingDate(recordingDate),
+			video.WithChapters(chapters),
 			video.WithMaxResults(1),
 			video.WithOutput(output),
 		)
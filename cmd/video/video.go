@@ -13,7 +13,7 @@ import (
 
 const (
 	short           = "Manage YouTube videos"
-	long            = "Manage YouTube videos. Use this tool to list, upload, update, delete, get rating, or report videos."
+	long            = "Manage YouTube videos. Use this tool to list, upload, update, delete, get rating, check processing status, or report videos."
 	alUsage         = "Should auto-levels be applied to the upload"
 	fileUsage       = "Path to the video file"
 	titleUsage      = "Title of the video"
@@ -42,6 +42,19 @@ const (
 	mwUsage         = "Max width of the embedded player in pixels"
 	nsUsage         = "Notify the channel subscribers about the new video"
 	psvUsage        = "Whether the extended video statistics can be viewed by everyone"
+	resumeUsage     = "Upload in chunks and resume an interrupted upload of the same file"
+	forceUsage      = "Upload even if the upload ledger already has a file with the same content"
+	waitUsage       = "Wait until processing finishes and fail if the video is rejected or processing fails"
+	wtUsage         = "How long to wait for processing, e.g. 30m or 1h"
+	captionUsage    = "Path to a caption file to upload for the new video"
+	clUsage         = "Language of the caption, defaults to the video language"
+	cnUsage         = "Name of the caption track"
+	atomicUsage     = "Delete the uploaded video if the thumbnail, playlist or caption step fails"
+	chaptersUsage   = "Path to a chapter list, lines of time and title or a CSV of time,title, rendered into the description"
+	probeUsage      = "Fill in the recording date from the file, log its duration and resolution, and warn about problems before uploading"
+	manifestUsage   = "Path to a YAML or CSV manifest of videos to upload"
+	resultsUsage    = "Path to the results file of a manifest upload"
+	concUsage       = "Number of manifest entries to upload concurrently"
 )
 
 var (
@@ -75,6 +88,19 @@ var (
 	maxWidth          int64
 	maxResults        int64
 	parts             []string
+	resume            bool
+	force             bool
+	wait              bool
+	waitTimeout       string
+	caption           string
+	captionLanguage   string
+	captionName       string
+	atomic            bool
+	probe             bool
+	chapters          string
+	manifest          string
+	results           string
+	concurrency       int64
 
 	notifySubscribers             = new(false)
 	publicStatsViewable           = new(false)
@@ -25,7 +25,7 @@ const (
 yutu videoAbuseReportReason list --hl en`
 )
 
-var listInSchema = &jsonschema.Schema{
+var listInSchema = cmd.ListSchema(&jsonschema.Schema{
 	Type:     "object",
 	Required: []string{},
 	Properties: map[string]*jsonschema.Schema{
@@ -35,13 +35,8 @@ var listInSchema = &jsonschema.Schema{
 			Items:   &jsonschema.Schema{Type: "string"},
 			Default: json.RawMessage(`["id","snippet"]`),
 		},
-		"output": {
-			Type: "string", Description: pkg.TableUsage,
-			Enum:    []any{"json", "yaml", "table"},
-			Default: json.RawMessage(`"yaml"`),
-		},
 	},
-}
+}, cmd.FieldsFlag)
 
 func init() {
 	mcp.AddTool(
@@ -67,7 +62,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 var listCmd = &cobra.Command{
@@ -75,13 +70,14 @@ var listCmd = &cobra.Command{
 	Short:   listShort,
 	Long:    listLong,
 	Example: listExample,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := videoAbuseReportReason.NewVideoAbuseReportReason(
-			videoAbuseReportReason.WithHL(hl),
-			videoAbuseReportReason.WithParts(parts),
-			videoAbuseReportReason.WithOutput(output),
+			append(
+				cmd.ListOptions[videoAbuseReportReason.Option](c),
+				videoAbuseReportReason.WithHL(hl),
+				videoAbuseReportReason.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
@@ -35,7 +35,7 @@ func init() {
 	listCmd.Flags().StringSliceVarP(
 		&parts, "parts", "p", []string{"id", "snippet"}, pkg.PartsUsage,
 	)
-	listCmd.Flags().StringP("output", "o", "table", pkg.TableUsage)
+	cmd.AddListFlags(listCmd, cmd.FieldsFlag)
 }
 
 const (
@@ -47,16 +47,17 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: listShort,
 	Long:  listLong,
-	Run: func(cmd *cobra.Command, _ []string) {
-		output, _ := cmd.Flags().GetString("output")
+	Run: func(c *cobra.Command, _ []string) {
 		input := videoCategory.NewVideoCategory(
-			videoCategory.WithIds(ids),
-			videoCategory.WithHl(hl),
-			videoCategory.WithRegionCode(regionCode),
-			videoCategory.WithParts(parts),
-			videoCategory.WithOutput(output),
+			append(
+				cmd.ListOptions[videoCategory.Option](c),
+				videoCategory.WithIds(ids),
+				videoCategory.WithHl(hl),
+				videoCategory.WithRegionCode(regionCode),
+				videoCategory.WithParts(parts),
+			)...,
 		)
-		utils.HandleCmdError(input.List(cmd.OutOrStdout()), cmd)
+		utils.HandleCmdError(input.List(c.OutOrStdout()), c)
 	},
 }
 
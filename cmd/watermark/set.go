@@ -60,13 +60,15 @@ func init() {
 				OpenWorldHint:   new(true),
 				ReadOnlyHint:    false,
 			},
-		}, cobramcp.GenToolHandler(
-			setTool, func(input watermark.Watermark, writer io.Writer) error {
-				if !input.Confirmed {
-					return utils.ErrNotConfirmed
-				}
-				return input.Set(writer)
-			},
+		}, cmd.WithProgress(
+			cobramcp.GenToolHandler(
+				setTool, func(input watermark.Watermark, writer io.Writer) error {
+					if !input.Confirmed {
+						return utils.ErrNotConfirmed
+					}
+					return input.Set(writer)
+				},
+			),
 		),
 	)
 	watermarkCmd.AddCommand(setCmd)
@@ -105,6 +107,7 @@ var setCmd = &cobra.Command{
 			watermark.WithOffsetMs(offsetMs),
 			watermark.WithOffsetType(offsetType),
 			watermark.WithOnBehalfOfContentOwner(onBehalfOfContentOwner),
+			watermark.WithContext(cmd.ProgressContext(c)),
 		)
 		utils.HandleCmdError(input.Set(c.OutOrStdout()), c)
 	},
@@ -20,6 +20,7 @@ import (
 	// subcommand (and sub-subcommands) on cmd.RootCmd.
 	_ "github.com/eat-pray-ai/yutu/cmd/abuseReport"
 	_ "github.com/eat-pray-ai/yutu/cmd/activity"
+	_ "github.com/eat-pray-ai/yutu/cmd/cache"
 	_ "github.com/eat-pray-ai/yutu/cmd/caption"
 	_ "github.com/eat-pray-ai/yutu/cmd/channel"
 	_ "github.com/eat-pray-ai/yutu/cmd/channelBanner"
@@ -28,6 +29,7 @@ import (
 	_ "github.com/eat-pray-ai/yutu/cmd/commentThread"
 	_ "github.com/eat-pray-ai/yutu/cmd/i18nLanguage"
 	_ "github.com/eat-pray-ai/yutu/cmd/i18nRegion"
+	_ "github.com/eat-pray-ai/yutu/cmd/ledger"
 	_ "github.com/eat-pray-ai/yutu/cmd/liveBroadcast"
 	_ "github.com/eat-pray-ai/yutu/cmd/liveChatBan"
 	_ "github.com/eat-pray-ai/yutu/cmd/liveChatMessage"
@@ -38,6 +40,8 @@ import (
 	_ "github.com/eat-pray-ai/yutu/cmd/playlist"
 	_ "github.com/eat-pray-ai/yutu/cmd/playlistImage"
 	_ "github.com/eat-pray-ai/yutu/cmd/playlistItem"
+	_ "github.com/eat-pray-ai/yutu/cmd/profile"
+	_ "github.com/eat-pray-ai/yutu/cmd/quota"
 	_ "github.com/eat-pray-ai/yutu/cmd/search"
 	_ "github.com/eat-pray-ai/yutu/cmd/subscription"
 	_ "github.com/eat-pray-ai/yutu/cmd/superChatEvent"
@@ -88,6 +92,7 @@ var categoryMap = map[string]string{
 	"caption":                "Content",
 	"thumbnail":              "Content",
 	"watermark":              "Content",
+	"ledger":                 "Content",
 	"playlist":               "Organization",
 	"playlistItem":           "Organization",
 	"playlistImage":          "Organization",
@@ -113,6 +118,9 @@ var categoryMap = map[string]string{
 	"videoAbuseReportReason": "Metadata",
 	"i18nLanguage":           "Metadata",
 	"i18nRegion":             "Metadata",
+	"quota":                  "Metadata",
+	"cache":                  "Metadata",
+	"profile":                "Channel",
 }
 
 // resourceCategory returns the category for a resource, or "Other" if unmapped.
@@ -8,6 +8,7 @@ import (
 	_ "github.com/eat-pray-ai/yutu/cmd/abuseReport"
 	_ "github.com/eat-pray-ai/yutu/cmd/activity"
 	_ "github.com/eat-pray-ai/yutu/cmd/agent"
+	_ "github.com/eat-pray-ai/yutu/cmd/cache"
 	_ "github.com/eat-pray-ai/yutu/cmd/caption"
 	_ "github.com/eat-pray-ai/yutu/cmd/channel"
 	_ "github.com/eat-pray-ai/yutu/cmd/channelBanner"
@@ -16,6 +17,8 @@ import (
 	_ "github.com/eat-pray-ai/yutu/cmd/commentThread"
 	_ "github.com/eat-pray-ai/yutu/cmd/i18nLanguage"
 	_ "github.com/eat-pray-ai/yutu/cmd/i18nRegion"
+	_ "github.com/eat-pray-ai/yutu/cmd/ledger"
+	_ "github.com/eat-pray-ai/yutu/cmd/lint"
 	_ "github.com/eat-pray-ai/yutu/cmd/liveBroadcast"
 	_ "github.com/eat-pray-ai/yutu/cmd/liveChatBan"
 	_ "github.com/eat-pray-ai/yutu/cmd/liveChatMessage"
@@ -26,6 +29,8 @@ import (
 	_ "github.com/eat-pray-ai/yutu/cmd/playlist"
 	_ "github.com/eat-pray-ai/yutu/cmd/playlistImage"
 	_ "github.com/eat-pray-ai/yutu/cmd/playlistItem"
+	_ "github.com/eat-pray-ai/yutu/cmd/profile"
+	_ "github.com/eat-pray-ai/yutu/cmd/quota"
 	_ "github.com/eat-pray-ai/yutu/cmd/search"
 	_ "github.com/eat-pray-ai/yutu/cmd/subscription"
 	_ "github.com/eat-pray-ai/yutu/cmd/superChatEvent"
@@ -6,6 +6,7 @@ package activity
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -27,6 +28,7 @@ type Activity struct {
 type IActivity[T any] interface {
 	List(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 }
 
 type Option func(*Activity)
@@ -40,8 +42,12 @@ func NewActivity(opts ...Option) IActivity[youtube.Activity] {
 }
 
 func (a *Activity) Get() ([]*youtube.Activity, error) {
+	return common.Collect(a.All())
+}
+
+func (a *Activity) All() iter.Seq2[*youtube.Activity, error] {
 	if err := a.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Activity](err)
 	}
 	call := a.Service.Activities.List(a.Parts)
 	if a.ChannelId != "" {
@@ -67,27 +73,31 @@ func (a *Activity) Get() ([]*youtube.Activity, error) {
 		call = call.RegionCode(a.RegionCode)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&a.Fields, call,
-		func(r *youtube.ActivityListResponse) ([]*youtube.Activity, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.ActivityListResponse) (
+			[]*youtube.Activity, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetActivity,
 	)
 }
 
 func (a *Activity) List(writer io.Writer) error {
-	activities, err := a.Get()
-	if err != nil && activities == nil {
-		return err
-	}
-
-	common.PrintList(
-		a.Output, activities, writer, table.Row{"ID", "Title", "Type", "Time"},
+	return common.PrintSeq(
+		&a.Fields, a.All(), writer, table.Row{"ID", "Title", "Type", "Time"},
 		func(a *youtube.Activity) table.Row {
-			return table.Row{a.Id, a.Snippet.Title, a.Snippet.Type, a.Snippet.PublishedAt}
+			title := ""
+			activityType := ""
+			publishedAt := ""
+			if a.Snippet != nil {
+				title = a.Snippet.Title
+				activityType = a.Snippet.Type
+				publishedAt = a.Snippet.PublishedAt
+			}
+			return table.Row{a.Id, title, activityType, publishedAt}
 		},
 	)
-	return err
 }
 
 func WithFor(f string) Option {
@@ -118,6 +128,12 @@ var (
 	WithChannelId  = common.WithChannelId[*Activity]
 	WithMaxResults = common.WithMaxResults[*Activity]
 	WithParts      = common.WithParts[*Activity]
+	WithColumns    = common.WithColumns[*Activity]
+	WithWhere      = common.WithWhere[*Activity]
+	WithSortBy     = common.WithSortBy[*Activity]
+	WithFieldMask  = common.WithFieldMask[*Activity]
+	WithPageToken  = common.WithPageToken[*Activity]
+	WithEnvelope   = common.WithEnvelope[*Activity]
 	WithOutput     = common.WithOutput[*Activity]
 	WithService    = common.WithService[*Activity]
 )
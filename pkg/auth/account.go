@@ -0,0 +1,258 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+)
+
+const defaultRevokeURL = "https://oauth2.googleapis.com/revoke"
+
+var (
+	// tokenInfoURL, revokeURL and apiOptions are replaced in tests.
+	tokenInfoURL = defaultTokenInfoURL
+	revokeURL    = defaultRevokeURL
+	apiOptions   []option.ClientOption
+)
+
+var (
+	errNoToken      = errors.New("no cached token, run yutu auth login first")
+	errInlineToken  = errors.New("token is not cached in a file, nothing to delete")
+	errRevokeToken  = errors.New("failed to revoke token")
+	errDeleteToken  = errors.New("failed to delete token cache")
+	errUnknownScope = errors.New("unknown scope")
+)
+
+// ScopeSets name the scopes yutu can ask for. full is what every command
+// needs; readonly only lets list commands work, for machines that must not
+// change anything.
+var ScopeSets = map[string][]string{
+	"full":     Scopes,
+	"readonly": {youtube.YoutubeReadonlyScope},
+}
+
+// ResolveScopes expands the names of ScopeSets, and passes scope URLs
+// through, returning Scopes when names is empty.
+func ResolveScopes(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return Scopes, nil
+	}
+	var scopes []string
+	for _, name := range names {
+		set, ok := ScopeSets[name]
+		switch {
+		case ok:
+		case strings.HasPrefix(name, "https://"):
+			set = []string{name}
+		default:
+			return nil, fmt.Errorf("%w: %s", errUnknownScope, name)
+		}
+		for _, scope := range set {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// WithScopes sets the scopes asked for when a new token is needed.
+func WithScopes(scopes []string) Option {
+	return func(s *svc) {
+		s.scopes = scopes
+	}
+}
+
+// TokenStatus describes the cached token and what it gives access to.
+type TokenStatus struct {
+	TokenFile    string   `yaml:"token_file" json:"token_file,omitempty"`
+	Account      string   `yaml:"account" json:"account,omitempty"`
+	ChannelId    string   `yaml:"channel_id" json:"channel_id,omitempty"`
+	ChannelTitle string   `yaml:"channel_title" json:"channel_title,omitempty"`
+	Scopes       []string `yaml:"scopes" json:"scopes"`
+	Expiry       string   `yaml:"expiry" json:"expiry,omitempty"`
+	Refreshable  bool     `yaml:"refreshable" json:"refreshable"`
+	RefreshError string   `yaml:"refresh_error" json:"refresh_error,omitempty"`
+}
+
+// Login runs the authorization flow even if a token is cached, and caches
+// the new token.
+func (s *svc) Login() error {
+	if s.initErr != nil {
+		return s.initErr
+	}
+	config, err := s.getConfig()
+	if err != nil {
+		return err
+	}
+	_, token, err := s.newClient(config)
+	if err != nil {
+		return err
+	}
+	if !s.keepsToken() {
+		return nil
+	}
+	return s.saveToken(token)
+}
+
+// Status refreshes the cached token to prove its refresh token still works,
+// then asks Google which scopes it was granted and which channel it acts
+// for.
+func (s *svc) Status() (*TokenStatus, error) {
+	token, err := s.cachedToken()
+	if err != nil {
+		return nil, err
+	}
+	if s.initErr != nil {
+		return nil, s.initErr
+	}
+	config, err := s.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TokenStatus{TokenFile: s.tokenFile, Scopes: []string{}}
+	if token.RefreshToken == "" {
+		status.RefreshError = "no refresh token cached"
+	} else {
+		fresh, err := config.TokenSource(
+			s.ctx, &oauth2.Token{RefreshToken: token.RefreshToken},
+		).Token()
+		if err != nil {
+			status.RefreshError = err.Error()
+		} else {
+			status.Refreshable = true
+			token = fresh
+			if s.keepsToken() {
+				if err := s.saveToken(token); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if !token.Expiry.IsZero() {
+		status.Expiry = token.Expiry.Format(time.RFC3339)
+	}
+	if !token.Valid() {
+		return status, nil
+	}
+
+	info, err := NewGoogleTokenVerifier(tokenInfoURL)(s.ctx, token.AccessToken, nil)
+	if err != nil {
+		slog.Warn("Failed to look up token info", "error", err)
+	} else {
+		status.Scopes = info.Scopes
+		status.Account = info.UserID
+	}
+
+	opts := append(
+		[]option.ClientOption{option.WithHTTPClient(config.Client(s.ctx, token))},
+		apiOptions...,
+	)
+	service, err := youtube.NewService(s.ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", createSvcFailed, err)
+	}
+	res, err := service.Channels.List([]string{"snippet"}).Mine(true).Do()
+	if err != nil {
+		slog.Warn("Failed to look up channel", "error", err)
+	} else if len(res.Items) > 0 {
+		status.ChannelId = res.Items[0].Id
+		status.ChannelTitle = res.Items[0].Snippet.Title
+	}
+	return status, nil
+}
+
+// Revoke revokes the cached token at Google, which signs every copy of it
+// out, and deletes the cache. A token Google no longer knows counts as
+// revoked.
+func (s *svc) Revoke() error {
+	token, err := s.cachedToken()
+	if err != nil {
+		return err
+	}
+	value := token.RefreshToken
+	if value == "" {
+		value = token.AccessToken
+	}
+
+	res, err := http.PostForm(revokeURL, url.Values{"token": {value}})
+	if err != nil {
+		return errors.Join(errRevokeToken, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	switch {
+	case res.StatusCode == http.StatusOK:
+	case res.StatusCode == http.StatusBadRequest &&
+		strings.Contains(string(body), "invalid_token"):
+		slog.Warn("Token was already revoked or expired")
+	default:
+		return fmt.Errorf(
+			"%w: %s: %s", errRevokeToken, res.Status, strings.TrimSpace(string(body)),
+		)
+	}
+	if !s.keepsToken() {
+		return nil
+	}
+	return s.Logout()
+}
+
+// Logout deletes the token cache, or has the credential helper erase the
+// token, leaving it valid at Google until it expires or is revoked.
+func (s *svc) Logout() error {
+	if s.tokenHelper {
+		if s.CacheToken == "" {
+			return errNoToken
+		}
+		if err := s.helperEraseToken(); err != nil {
+			return errors.Join(errDeleteToken, err)
+		}
+		return nil
+	}
+	if s.tokenFile == "" {
+		return errInlineToken
+	}
+	err := pkg.Root.Remove(s.tokenFile)
+	if os.IsNotExist(err) {
+		return errNoToken
+	} else if err != nil {
+		return errors.Join(errDeleteToken, err)
+	}
+	return nil
+}
+
+// TokenFile returns the token cache file, relative to pkg.Root, or "" for
+// a token given inline.
+func (s *svc) TokenFile() string {
+	return s.tokenFile
+}
+
+func (s *svc) cachedToken() (*oauth2.Token, error) {
+	token := &oauth2.Token{}
+	if s.CacheToken == "" || json.Unmarshal([]byte(s.CacheToken), token) != nil {
+		return nil, errNoToken
+	}
+	if token.AccessToken == "" && token.RefreshToken == "" {
+		return nil, errNoToken
+	}
+	return token, nil
+}
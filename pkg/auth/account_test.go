@@ -0,0 +1,269 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestResolveScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr error
+	}{
+		{name: "default", names: nil, want: Scopes},
+		{
+			name: "readonly", names: []string{"readonly"},
+			want: []string{youtube.YoutubeReadonlyScope},
+		},
+		{
+			name:  "set and url",
+			names: []string{"readonly", youtube.YoutubeUploadScope, "readonly"},
+			want:  []string{youtube.YoutubeReadonlyScope, youtube.YoutubeUploadScope},
+		},
+		{name: "unknown", names: []string{"admin"}, wantErr: errUnknownScope},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, err := ResolveScopes(tt.names)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ResolveScopes() error = %v, want %v", err, tt.wantErr)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ResolveScopes() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+// fakeGoogle fakes the token, tokeninfo, revocation and channels endpoints.
+type fakeGoogle struct {
+	*httptest.Server
+	refreshOK bool
+	refreshes atomic.Int32
+	revoked   string
+}
+
+func newFakeGoogle(t *testing.T) *fakeGoogle {
+	t.Helper()
+	g := &fakeGoogle{refreshOK: true}
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/token", func(w http.ResponseWriter, r *http.Request) {
+			if !g.refreshOK || r.FormValue("refresh_token") == "" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			g.refreshes.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(
+				[]byte(`{"access_token":"fresh","token_type":"Bearer","expires_in":3599}`),
+			)
+		},
+	)
+	mux.HandleFunc(
+		"/tokeninfo", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(
+				[]byte(`{"expires_in":"3599","scope":"` + youtube.YoutubeReadonlyScope + `","sub":"42"}`),
+			)
+		},
+	)
+	mux.HandleFunc(
+		"/revoke", func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("token") == "gone" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_token"}`))
+				return
+			}
+			g.revoked = r.FormValue("token")
+		},
+	)
+	mux.HandleFunc(
+		"/youtube/v3/channels", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write(
+				[]byte(`{"items":[{"id":"UC1","snippet":{"title":"Gaming"}}]}`),
+			)
+		},
+	)
+	g.Server = httptest.NewServer(mux)
+	t.Cleanup(g.Close)
+
+	oldInfo, oldRevoke, oldOpts := tokenInfoURL, revokeURL, apiOptions
+	tokenInfoURL, revokeURL = g.URL+"/tokeninfo", g.URL+"/revoke"
+	apiOptions = []option.ClientOption{option.WithEndpoint(g.URL)}
+	t.Cleanup(
+		func() {
+			tokenInfoURL, revokeURL, apiOptions = oldInfo, oldRevoke, oldOpts
+		},
+	)
+	return g
+}
+
+func (g *fakeGoogle) credential() string {
+	cred := map[string]map[string]any{
+		"installed": {
+			"client_id":     "test-client-id",
+			"client_secret": "test-secret",
+			"auth_uri":      g.URL + "/auth",
+			"token_uri":     g.URL + "/token",
+			"redirect_uris": []string{"http://localhost"},
+		},
+	}
+	b, _ := json.Marshal(cred)
+	return string(b)
+}
+
+// tokenRoot roots pkg.Root at a temp dir holding token as youtube.token.json.
+func tokenRoot(t *testing.T, token string) string {
+	t.Helper()
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatalf("failed to open root: %v", err)
+	}
+	oldRoot, oldRootDir := pkg.Root, pkg.RootDir
+	pkg.Root, pkg.RootDir = root, &dir
+	t.Cleanup(
+		func() {
+			pkg.Root, pkg.RootDir = oldRoot, oldRootDir
+			_ = root.Close()
+		},
+	)
+	if token != "" {
+		err := os.WriteFile(filepath.Join(dir, "youtube.token.json"), []byte(token), 0600)
+		if err != nil {
+			t.Fatalf("failed to write token: %v", err)
+		}
+	}
+	return dir
+}
+
+func newTestSvc(g *fakeGoogle, dir string) *svc {
+	return NewY2BService(
+		WithCredential(g.credential(), os.DirFS(dir)),
+		WithCacheToken(filepath.Join(dir, "youtube.token.json"), os.DirFS(dir)),
+	).(*svc)
+}
+
+func TestStatus(t *testing.T) {
+	g := newFakeGoogle(t)
+	dir := tokenRoot(t, `{"access_token":"stale","refresh_token":"r1"}`)
+
+	status, err := newTestSvc(g, dir).Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	want := &TokenStatus{
+		TokenFile: "youtube.token.json", Account: "42", ChannelId: "UC1",
+		ChannelTitle: "Gaming", Scopes: []string{youtube.YoutubeReadonlyScope},
+		Refreshable: true,
+	}
+	status.Expiry = ""
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("Status() = %+v, want %+v", status, want)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "youtube.token.json"))
+	if !strings.Contains(string(data), `"fresh"`) {
+		t.Errorf("refreshed token not cached: %s", data)
+	}
+}
+
+func TestStatus_RefreshFails(t *testing.T) {
+	g := newFakeGoogle(t)
+	g.refreshOK = false
+	dir := tokenRoot(t, `{"access_token":"stale","refresh_token":"r1"}`)
+
+	status, err := newTestSvc(g, dir).Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Refreshable || !strings.Contains(status.RefreshError, "invalid_grant") {
+		t.Errorf("Status() = %+v, want a refresh error", status)
+	}
+}
+
+func TestStatus_NoToken(t *testing.T) {
+	g := newFakeGoogle(t)
+	dir := tokenRoot(t, "")
+
+	if _, err := newTestSvc(g, dir).Status(); !errors.Is(err, errNoToken) {
+		t.Errorf("Status() error = %v, want %v", err, errNoToken)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		revoked string
+	}{
+		{
+			name: "refresh token", token: `{"access_token":"a","refresh_token":"r1"}`,
+			revoked: "r1",
+		},
+		{name: "access token only", token: `{"access_token":"a"}`, revoked: "a"},
+		{name: "already revoked", token: `{"refresh_token":"gone"}`},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := newFakeGoogle(t)
+				dir := tokenRoot(t, tt.token)
+
+				if err := newTestSvc(g, dir).Revoke(); err != nil {
+					t.Fatalf("Revoke() error = %v", err)
+				}
+				if g.revoked != tt.revoked {
+					t.Errorf("revoked %q, want %q", g.revoked, tt.revoked)
+				}
+				_, err := os.Stat(filepath.Join(dir, "youtube.token.json"))
+				if !os.IsNotExist(err) {
+					t.Errorf("token cache not deleted: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	g := newFakeGoogle(t)
+	dir := tokenRoot(t, `{"access_token":"a"}`)
+	s := newTestSvc(g, dir)
+
+	if err := s.Logout(); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if err := s.Logout(); !errors.Is(err, errNoToken) {
+		t.Errorf("second Logout() error = %v, want %v", err, errNoToken)
+	}
+
+	inline := NewY2BService(WithCacheToken(`{"access_token":"a"}`, os.DirFS(dir))).(*svc)
+	if err := inline.Logout(); !errors.Is(err, errInlineToken) {
+		t.Errorf("Logout() of inline token error = %v, want %v", err, errInlineToken)
+	}
+}
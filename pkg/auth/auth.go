@@ -5,6 +5,7 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -14,8 +15,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/retry"
 	"github.com/eat-pray-ai/yutu/pkg/utils"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -31,13 +34,17 @@ const (
 	exchangeFailed     = "failed to exchange token"
 	listenFailed       = "failed to start web server"
 	cacheTokenFailed   = "failed to cache token"
+	lockTokenFailed    = "failed to lock token cache"
 	parseTokenFailed   = "failed to parse token"
 	refreshTokenFailed = "failed to refresh token, please re-authenticate in cli"
 	parseSecretFailed  = "failed to parse client secret"
+	deviceAuthFailed   = "failed to start device authorization"
+	devicePollFailed   = "device authorization was not completed"
 
 	browserOpenedHint = "Your browser has been opened to an authorization URL. yutu will resume once authorization has been provided.\n%s\n"
 	openBrowserHint   = "It seems that your browser is not open. Go to the following link in your browser:\n%s\n"
 	receivedCodeHint  = "Authorization code received: %s\nYou can now safely close the browser window.\n"
+	deviceCodeHint    = "On any device, go to the following link and enter the code %s\n%s\nyutu will resume once authorization has been provided.\n"
 	manualInputHint   = `
 After completing the authorization flow, enter the authorization code on command line.
 
@@ -48,6 +55,16 @@ ONLY 'COPY-THIS' part is the code you need to enter on command line.
 `
 )
 
+// deviceAuthURL is replaced in tests.
+var deviceAuthURL = google.Endpoint.DeviceAuthURL
+
+// lockPoll is how often a held token lock is retried, and lockWait how long
+// at most. Both are replaced in tests.
+var (
+	lockPoll = 100 * time.Millisecond
+	lockWait = 30 * time.Second
+)
+
 // Scopes is the set of YouTube OAuth scopes required by yutu.
 var Scopes = []string{
 	youtube.YoutubeScope,
@@ -55,6 +72,10 @@ var Scopes = []string{
 	youtube.YoutubeChannelMembershipsCreatorScope,
 }
 
+// DeviceScopes is asked for by the device flow when no scopes are given, as
+// Google refuses the rest of Scopes to it.
+var DeviceScopes = []string{youtube.YoutubeScope}
+
 func (s *svc) GetService() (*youtube.Service, error) {
 	if s.initErr != nil {
 		return nil, s.initErr
@@ -64,15 +85,21 @@ func (s *svc) GetService() (*youtube.Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	client = retry.Client(client)
 	service, err := youtube.NewService(s.ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", createSvcFailed, err)
 	}
 	s.service = service
+	s.client = client
 
 	return s.service, nil
 }
 
+func (s *svc) HTTPClient() *http.Client {
+	return s.client
+}
+
 func (s *svc) refreshClient() (client *http.Client, err error) {
 	config, err := s.getConfig()
 	if err != nil {
@@ -85,7 +112,7 @@ func (s *svc) refreshClient() (client *http.Client, err error) {
 		if err != nil {
 			return nil, err
 		}
-		if s.tokenFile != "" {
+		if s.keepsToken() {
 			if err := s.saveToken(authedToken); err != nil {
 				return nil, err
 			}
@@ -94,9 +121,34 @@ func (s *svc) refreshClient() (client *http.Client, err error) {
 	}
 
 	if !authedToken.Valid() {
+		// Another process sharing the cache may be refreshing the same
+		// token. Wait for it, and use what it cached instead of spending the
+		// refresh token again.
+		unlock := s.lockToken()
+		if s.tokenFile != "" {
+			if cached := s.readToken(); cached != nil {
+				if cached.Valid() {
+					unlock()
+					return config.Client(s.ctx, cached), nil
+				}
+				authedToken = cached
+			}
+		}
+
 		tokenSource := config.TokenSource(s.ctx, authedToken)
 		authedToken, err = tokenSource.Token()
-		if err != nil && s.tokenFile != "" {
+		if err == nil && s.keepsToken() {
+			err = s.writeToken(authedToken)
+			unlock()
+			if err != nil {
+				return nil, err
+			}
+			return config.Client(s.ctx, authedToken), nil
+		}
+		// Signing in again waits on the user, who may never come back, so
+		// the other processes are not kept waiting meanwhile.
+		unlock()
+		if err != nil && s.keepsToken() {
 			client, authedToken, err = s.newClient(config)
 			if err != nil {
 				return nil, err
@@ -108,12 +160,6 @@ func (s *svc) refreshClient() (client *http.Client, err error) {
 		} else if err != nil {
 			return nil, fmt.Errorf("%s: %w", refreshTokenFailed, err)
 		}
-
-		if authedToken != nil && s.tokenFile != "" {
-			if err := s.saveToken(authedToken); err != nil {
-				return nil, err
-			}
-		}
 		return config.Client(s.ctx, authedToken), nil
 	}
 
@@ -123,14 +169,18 @@ func (s *svc) refreshClient() (client *http.Client, err error) {
 func (s *svc) newClient(config *oauth2.Config) (
 	client *http.Client, token *oauth2.Token, err error,
 ) {
-	verifier := oauth2.GenerateVerifier()
-	authURL := config.AuthCodeURL(
-		s.state,
-		oauth2.ApprovalForce,
-		oauth2.AccessTypeOffline,
-		oauth2.S256ChallengeOption(verifier),
-	)
-	token, err = s.getTokenFromWeb(config, authURL, verifier)
+	if s.device {
+		token, err = s.getTokenFromDevice(config)
+	} else {
+		verifier := oauth2.GenerateVerifier()
+		authURL := config.AuthCodeURL(
+			s.state,
+			oauth2.ApprovalForce,
+			oauth2.AccessTypeOffline,
+			oauth2.S256ChallengeOption(verifier),
+		)
+		token, err = s.getTokenFromWeb(config, authURL, verifier)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -140,7 +190,13 @@ func (s *svc) newClient(config *oauth2.Config) (
 }
 
 func (s *svc) getConfig() (*oauth2.Config, error) {
-	config, err := google.ConfigFromJSON([]byte(s.Credential), Scopes...)
+	scopes := s.scopes
+	if len(scopes) == 0 && s.device {
+		scopes = DeviceScopes
+	} else if len(scopes) == 0 {
+		scopes = Scopes
+	}
+	config, err := google.ConfigFromJSON([]byte(s.Credential), scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", parseSecretFailed, err)
 	}
@@ -246,29 +302,145 @@ func (s *svc) getTokenFromWeb(
 	return token, nil
 }
 
+// getTokenFromDevice runs the OAuth 2.0 device authorization grant: it
+// prints a link and a code to enter on any device with a browser, then polls
+// the token endpoint until the user approves, denies, or the code expires.
+func (s *svc) getTokenFromDevice(config *oauth2.Config) (*oauth2.Token, error) {
+	if config.Endpoint.DeviceAuthURL == "" {
+		config.Endpoint.DeviceAuthURL = deviceAuthURL
+	}
+	da, err := config.DeviceAuth(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", deviceAuthFailed, err)
+	}
+
+	link := da.VerificationURI
+	if da.VerificationURIComplete != "" {
+		link = da.VerificationURIComplete
+	}
+	_, _ = fmt.Fprintf(s.out, deviceCodeHint, da.UserCode, link)
+
+	token, err := config.DeviceAccessToken(s.ctx, da)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", devicePollFailed, err)
+	}
+	return token, nil
+}
+
+// saveToken caches token under the token lock.
 func (s *svc) saveToken(token *oauth2.Token) error {
+	unlock := s.lockToken()
+	defer unlock()
+	return s.writeToken(token)
+}
+
+// keepsToken reports whether a new token can be cached, in a file or by the
+// credential helper.
+func (s *svc) keepsToken() bool {
+	return s.tokenFile != "" || s.tokenHelper
+}
+
+// writeToken replaces the token cache with token through a temp file and a
+// rename, so that readers never see a partly written cache. The caller holds
+// the token lock. A token kept by the credential helper is handed to it.
+func (s *svc) writeToken(token *oauth2.Token) error {
+	if s.tokenHelper {
+		if err := s.helperStoreToken(token); err != nil {
+			slog.Error(cacheTokenFailed, "error", err)
+			return fmt.Errorf("%s: %w", cacheTokenFailed, err)
+		}
+		return nil
+	}
 	dir := filepath.Dir(s.tokenFile)
 	if err := pkg.Root.MkdirAll(dir, 0755); err != nil {
 		slog.Error(cacheTokenFailed, "dir", dir, "error", err)
 		return fmt.Errorf("%s: %w", cacheTokenFailed, err)
 	}
 
-	f, err := pkg.Root.OpenFile(
-		s.tokenFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600,
-	)
+	tmp := fmt.Sprintf("%s.%s.tmp", s.tokenFile, rand.Text())
+	f, err := pkg.Root.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
 	if err != nil {
 		slog.Error(cacheTokenFailed, "file", s.tokenFile, "error", err)
 		return fmt.Errorf("%s: %w", cacheTokenFailed, err)
 	}
-
-	defer func() {
-		_ = f.Close()
-	}()
 	err = json.NewEncoder(f).Encode(token)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = pkg.Root.Rename(tmp, s.tokenFile)
+	}
 	if err != nil {
+		_ = pkg.Root.Remove(tmp)
+		slog.Error(cacheTokenFailed, "file", s.tokenFile, "error", err)
 		return fmt.Errorf("%s: %w", cacheTokenFailed, err)
 	}
 	slog.Debug("Token cached to file", "file", s.tokenFile)
 
 	return nil
 }
+
+// readToken returns the token in the cache file, or nil if there is none.
+func (s *svc) readToken() *oauth2.Token {
+	data, err := pkg.Root.ReadFile(s.tokenFile)
+	if err != nil {
+		return nil
+	}
+	token := &oauth2.Token{}
+	if json.Unmarshal(data, token) != nil {
+		return nil
+	}
+	return token
+}
+
+// lockToken takes an advisory lock on a file next to the token cache, so
+// that processes sharing the cache take turns refreshing and writing it. It
+// waits for another holder until lockWait has passed or s.ctx is done. A
+// lock that cannot be taken only costs that protection, so it is logged and
+// a no-op unlock is returned.
+func (s *svc) lockToken() (unlock func()) {
+	unlock = func() {}
+	if s.tokenFile == "" {
+		return unlock
+	}
+	name := s.tokenFile + ".lock"
+	if err := pkg.Root.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		slog.Warn(lockTokenFailed, "file", name, "error", err)
+		return unlock
+	}
+	f, err := pkg.Root.OpenFile(name, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		slog.Warn(lockTokenFailed, "file", name, "error", err)
+		return unlock
+	}
+	if err := s.waitLock(f); err != nil {
+		slog.Warn(lockTokenFailed, "file", name, "error", err)
+		_ = f.Close()
+		return unlock
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}
+}
+
+// waitLock tries to lock f every lockPoll until it succeeds, lockWait has
+// passed or s.ctx is done.
+func (s *svc) waitLock(f *os.File) error {
+	ctx, cancel := context.WithTimeout(s.ctx, lockWait)
+	defer cancel()
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil || locked {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockPoll):
+		}
+	}
+}
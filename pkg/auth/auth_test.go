@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"testing/iotest"
 	"time"
@@ -325,6 +328,70 @@ func TestSaveToken_Success(t *testing.T) {
 	}
 }
 
+func TestSaveToken_Atomic(t *testing.T) {
+	dir := tokenRoot(t, `{"access_token":"old"}`)
+	s := NewY2BService().(*svc)
+	s.tokenFile = "youtube.token.json"
+
+	if err := s.saveToken(&oauth2.Token{AccessToken: "new"}); err != nil {
+		t.Fatalf("saveToken returned error: %v", err)
+	}
+	if token := s.readToken(); token == nil || token.AccessToken != "new" {
+		t.Errorf("cached token = %+v, want new", token)
+	}
+	tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if len(tmps) != 0 {
+		t.Errorf("temp files left behind: %v", tmps)
+	}
+}
+
+func TestRefreshClient_Coalesced(t *testing.T) {
+	g := newFakeGoogle(t)
+	dir := tokenRoot(
+		t, `{"access_token":"stale","refresh_token":"r1","expiry":"2000-01-01T00:00:00Z"}`,
+	)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(
+			func() {
+				if _, err := newTestSvc(g, dir).refreshClient(); err != nil {
+					t.Errorf("refreshClient returned error: %v", err)
+				}
+			},
+		)
+	}
+	wg.Wait()
+
+	if n := g.refreshes.Load(); n != 1 {
+		t.Errorf("token endpoint hit %d times, want 1", n)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "youtube.token.json"))
+	if !strings.Contains(string(data), `"fresh"`) {
+		t.Errorf("refreshed token not cached: %s", data)
+	}
+}
+
+func TestLockToken_Bounded(t *testing.T) {
+	tokenRoot(t, `{"access_token":"old"}`)
+	oldWait, oldPoll := lockWait, lockPoll
+	lockWait, lockPoll = 50*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() { lockWait, lockPoll = oldWait, oldPoll })
+
+	holder := NewY2BService().(*svc)
+	holder.tokenFile = "youtube.token.json"
+	waiter := NewY2BService().(*svc)
+	waiter.tokenFile = "youtube.token.json"
+
+	unlock := holder.lockToken()
+	start := time.Now()
+	waiter.lockToken()()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("lockToken waited %v on a held lock", elapsed)
+	}
+	unlock()
+}
+
 func TestGetConfig_Scopes(t *testing.T) {
 	s := NewY2BService(
 		WithCredential(validCredentialJSON("http://localhost"), os.DirFS(".")),
@@ -351,6 +418,21 @@ func TestGetConfig_Scopes(t *testing.T) {
 	}
 }
 
+func TestGetConfig_DeviceScopes(t *testing.T) {
+	s := NewY2BService(
+		WithCredential(validCredentialJSON("http://localhost"), os.DirFS(".")),
+		WithDevice(true),
+	).(*svc)
+
+	config, err := s.getConfig()
+	if err != nil {
+		t.Fatalf("getConfig returned error: %v", err)
+	}
+	if len(config.Scopes) != 1 || config.Scopes[0] != youtube.YoutubeScope {
+		t.Errorf("scopes = %v, want %v", config.Scopes, DeviceScopes)
+	}
+}
+
 func TestGetCodeFromPrompt_ReadError(t *testing.T) {
 	errReader := iotest.ErrReader(fmt.Errorf("read error"))
 	var out bytes.Buffer
@@ -365,3 +447,117 @@ func TestGetCodeFromPrompt_ReadError(t *testing.T) {
 		t.Errorf("expected error to contain %q, got %q", "failed to read prompt", err.Error())
 	}
 }
+
+// deviceServer fakes the device code and token endpoints of the device
+// authorization grant, answering authorization_pending pending times.
+func deviceServer(t *testing.T, pending int, final string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/device/code", func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("client_id") != "test-client-id" {
+				http.Error(w, "unknown client", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(
+				[]byte(`{"device_code":"dev-123","user_code":"ABCD-EFGH","verification_url":"https://www.google.com/device","expires_in":60,"interval":1}`),
+			)
+		},
+	)
+	mux.HandleFunc(
+		"/token", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.FormValue("device_code") != "dev-123" ||
+				r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			if pending > 0 {
+				pending--
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+				return
+			}
+			if final != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"` + final + `"}`))
+				return
+			}
+			_, _ = w.Write(
+				[]byte(`{"access_token":"device-token","refresh_token":"r1","token_type":"Bearer","expires_in":3599}`),
+			)
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	oldURL := deviceAuthURL
+	deviceAuthURL = server.URL + "/device/code"
+	t.Cleanup(func() { deviceAuthURL = oldURL })
+	return server
+}
+
+func deviceCredential(server *httptest.Server) string {
+	cred := map[string]map[string]any{
+		"installed": {
+			"client_id":     "test-client-id",
+			"client_secret": "test-secret",
+			"auth_uri":      server.URL + "/auth",
+			"token_uri":     server.URL + "/token",
+			"redirect_uris": []string{"http://localhost"},
+		},
+	}
+	b, _ := json.Marshal(cred)
+	return string(b)
+}
+
+func TestLogin_Device(t *testing.T) {
+	server := deviceServer(t, 1, "")
+	dir := tokenRoot(t, "")
+	var out bytes.Buffer
+	s := NewY2BService(
+		WithCredential(deviceCredential(server), os.DirFS(dir)),
+		WithCacheToken(dir+"/youtube.token.json", os.DirFS(dir)),
+		WithDevice(true),
+		WithIO(strings.NewReader(""), &out),
+	).(Manager)
+
+	if err := s.Login(); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "ABCD-EFGH") ||
+		!strings.Contains(out.String(), "https://www.google.com/device") {
+		t.Errorf("Login() printed %q, want the user code and link", out.String())
+	}
+
+	data, err := os.ReadFile(dir + "/youtube.token.json")
+	if err != nil {
+		t.Fatalf("token not cached: %v", err)
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		t.Fatalf("failed to unmarshal cached token: %v", err)
+	}
+	if token.AccessToken != "device-token" || token.RefreshToken != "r1" {
+		t.Errorf("cached token = %+v", token)
+	}
+}
+
+func TestGetTokenFromDevice_Denied(t *testing.T) {
+	server := deviceServer(t, 0, "access_denied")
+	s := NewY2BService(
+		WithCredential(deviceCredential(server), os.DirFS(".")),
+		WithIO(strings.NewReader(""), &bytes.Buffer{}),
+	).(*svc)
+	config, err := s.getConfig()
+	if err != nil {
+		t.Fatalf("getConfig returned error: %v", err)
+	}
+
+	_, err = s.getTokenFromDevice(config)
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("getTokenFromDevice() error = %v, want access_denied", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// HelperEnv names a program that keeps the client secret and token, so that
+// they never sit on disk. Like a git credential helper, it is run with the
+// operation, get, store or erase, as its last argument, and speaks JSON over
+// stdin and stdout:
+//
+//	get:   {"key":"credential"} -> {"value":{"installed":{...}}}
+//	get:   {"key":"token"}      -> {"value":{"access_token":...}}
+//	store: {"key":"token","value":{"access_token":...}}
+//	erase: {"key":"token"}
+//
+// A get answered with no value means the helper has nothing stored. Its
+// stderr is passed through, so it may prompt or log there. Unlike git, no
+// shell runs it: the variable is split on white space into the program and
+// its arguments, so neither may contain spaces or quotes.
+const HelperEnv = "YUTU_CREDENTIAL_HELPER"
+
+const (
+	opGet   = "get"
+	opStore = "store"
+	opErase = "erase"
+
+	keyCredential = "credential"
+	keyToken      = "token"
+)
+
+var errHelper = errors.New("credential helper failed")
+
+type helperRequest struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type helperResponse struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// helperGet asks the helper for key. A value given as a JSON string is
+// returned as it is, so that it may hold Base64; "" means nothing is stored.
+func (s *svc) helperGet(key string) (string, error) {
+	out, err := s.runHelper(opGet, helperRequest{Key: key})
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return "", nil
+	}
+	res := &helperResponse{}
+	if err := json.Unmarshal(out, res); err != nil {
+		return "", fmt.Errorf("%w: get %s: %w", errHelper, key, err)
+	}
+	value := res.Value
+	if len(value) == 0 || string(value) == "null" {
+		return "", nil
+	}
+	var str string
+	if json.Unmarshal(value, &str) == nil {
+		return str, nil
+	}
+	return string(value), nil
+}
+
+// helperStoreToken hands token to the helper to persist.
+func (s *svc) helperStoreToken(token *oauth2.Token) error {
+	value, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("%w: store %s: %w", errHelper, keyToken, err)
+	}
+	_, err = s.runHelper(opStore, helperRequest{Key: keyToken, Value: value})
+	return err
+}
+
+// helperEraseToken tells the helper to forget the token.
+func (s *svc) helperEraseToken() error {
+	_, err := s.runHelper(opErase, helperRequest{Key: keyToken})
+	return err
+}
+
+func (s *svc) runHelper(op string, req helperRequest) ([]byte, error) {
+	args := strings.Fields(s.helper)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", errHelper, HelperEnv)
+	}
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s: %w", errHelper, op, req.Key, err)
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(s.ctx, args[0], append(args[1:], op)...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s %s: %w", errHelper, op, req.Key, err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const helperDirEnv = "YUTU_TEST_HELPER_DIR"
+
+// TestHelperProcess is not a test, it is the credential helper the other
+// tests run. It keeps each key in a file under helperDirEnv.
+func TestHelperProcess(t *testing.T) {
+	dir := os.Getenv(helperDirEnv)
+	if dir == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	req := helperRequest{}
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		os.Exit(2)
+	}
+	file := filepath.Join(dir, req.Key+".json")
+	switch os.Args[len(os.Args)-1] {
+	case opGet:
+		if data, err := os.ReadFile(file); err == nil {
+			fmt.Printf(`{"value":%s}`, data)
+		}
+	case opStore:
+		if err := os.WriteFile(file, req.Value, 0600); err != nil {
+			os.Exit(1)
+		}
+	case opErase:
+		if err := os.Remove(file); err != nil {
+			os.Exit(1)
+		}
+	default:
+		os.Exit(2)
+	}
+}
+
+// useHelper makes the test binary the credential helper, storing values in
+// the returned dir, and unsets the variables that take precedence over it.
+func useHelper(t *testing.T, values map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for key, value := range values {
+		err := os.WriteFile(filepath.Join(dir, key+".json"), []byte(value), 0600)
+		if err != nil {
+			t.Fatalf("failed to store %s: %v", key, err)
+		}
+	}
+	t.Setenv(HelperEnv, os.Args[0]+" -test.run=^TestHelperProcess$ --")
+	t.Setenv(helperDirEnv, dir)
+	for _, env := range []string{"YUTU_CREDENTIAL", "YUTU_CACHE_TOKEN"} {
+		t.Setenv(env, "")
+		_ = os.Unsetenv(env)
+	}
+	return dir
+}
+
+func TestHelper_Refresh(t *testing.T) {
+	g := newFakeGoogle(t)
+	root := tokenRoot(t, "")
+	dir := useHelper(
+		t, map[string]string{
+			keyCredential: g.credential(),
+			keyToken:      `{"access_token":"stale","refresh_token":"r1","expiry":"2000-01-01T00:00:00Z"}`,
+		},
+	)
+
+	s := NewY2BService(
+		WithCredential("", os.DirFS(root)), WithCacheToken("", os.DirFS(root)),
+	).(*svc)
+	if s.initErr != nil {
+		t.Fatalf("NewY2BService() error = %v", s.initErr)
+	}
+	if _, err := s.refreshClient(); err != nil {
+		t.Fatalf("refreshClient returned error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, keyToken+".json"))
+	if !strings.Contains(string(data), `"fresh"`) {
+		t.Errorf("refreshed token not stored by the helper: %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(root, "youtube.token.json")); !os.IsNotExist(err) {
+		t.Errorf("token written to disk: %v", err)
+	}
+}
+
+func TestHelper_Get(t *testing.T) {
+	cred := validCredentialJSON("http://localhost")
+	tests := []struct {
+		name       string
+		values     map[string]string
+		credential string
+		cacheToken string
+	}{
+		{
+			name:       "no token stored",
+			values:     map[string]string{keyCredential: cred},
+			credential: cred,
+		},
+		{
+			name: "json",
+			values: map[string]string{
+				keyCredential: cred, keyToken: `{"access_token":"a"}`,
+			},
+			credential: cred, cacheToken: `{"access_token":"a"}`,
+		},
+		{
+			name: "base64 string",
+			values: map[string]string{
+				keyCredential: `"` + base64.StdEncoding.EncodeToString([]byte(cred)) + `"`,
+			},
+			credential: cred,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				root := tokenRoot(t, "")
+				useHelper(t, tt.values)
+				s := NewY2BService(
+					WithCredential("", os.DirFS(root)),
+					WithCacheToken("", os.DirFS(root)),
+				).(*svc)
+				if s.initErr != nil {
+					t.Fatalf("NewY2BService() error = %v", s.initErr)
+				}
+				if s.Credential != tt.credential {
+					t.Errorf("Credential = %q, want %q", s.Credential, tt.credential)
+				}
+				if s.CacheToken != tt.cacheToken {
+					t.Errorf("CacheToken = %q, want %q", s.CacheToken, tt.cacheToken)
+				}
+				if s.tokenFile != "" || !s.keepsToken() {
+					t.Errorf("token kept in %q, want the helper", s.tokenFile)
+				}
+			},
+		)
+	}
+}
+
+func TestHelper_Fails(t *testing.T) {
+	root := tokenRoot(t, "")
+	useHelper(t, nil)
+	t.Setenv(HelperEnv, filepath.Join(root, "no-such-helper"))
+
+	s := NewY2BService(WithCacheToken("", os.DirFS(root))).(*svc)
+	if !errors.Is(s.initErr, errHelper) {
+		t.Errorf("initErr = %v, want %v", s.initErr, errHelper)
+	}
+}
+
+func TestHelper_Erase(t *testing.T) {
+	for name, erase := range map[string]func(*svc) error{
+		"revoke": (*svc).Revoke, "logout": (*svc).Logout,
+	} {
+		t.Run(
+			name, func(t *testing.T) {
+				newFakeGoogle(t)
+				root := tokenRoot(t, "")
+				dir := useHelper(t, map[string]string{keyToken: `{"access_token":"a"}`})
+
+				s := NewY2BService(WithCacheToken("", os.DirFS(root))).(*svc)
+				if err := erase(s); err != nil {
+					t.Fatalf("%s() error = %v", name, err)
+				}
+				_, err := os.Stat(filepath.Join(dir, keyToken+".json"))
+				if !os.IsNotExist(err) {
+					t.Errorf("token not erased by the helper: %v", err)
+				}
+			},
+		)
+	}
+
+	tokenRoot(t, "")
+	useHelper(t, nil)
+	err := NewY2BService(WithCacheToken("", os.DirFS("."))).(*svc).Logout()
+	if !errors.Is(err, errNoToken) {
+		t.Errorf("Logout() with nothing stored error = %v, want %v", err, errNoToken)
+	}
+}
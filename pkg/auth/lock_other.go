@@ -0,0 +1,17 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix && !windows
+
+package auth
+
+import "os"
+
+// Platforms without advisory locks only get atomic writes.
+func tryLockFile(*os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -31,11 +32,16 @@ type svc struct {
 	credFile    string
 	tokenFile   string
 	redirectURL string
+	scopes      []string
+	device      bool
+	helper      string
+	tokenHelper bool
 	initErr     error
 	in          io.Reader
 	out         io.Writer
 
 	service *youtube.Service
+	client  *http.Client
 	ctx     context.Context
 	state   string
 }
@@ -44,6 +50,19 @@ type Svc interface {
 	GetService() (*youtube.Service, error)
 }
 
+// Manager manages the account behind a Svc. The Svc returned by
+// NewY2BService is also a Manager.
+type Manager interface {
+	Svc
+	// HTTPClient returns the authorized client built by the last GetService call.
+	HTTPClient() *http.Client
+	Login() error
+	Status() (*TokenStatus, error)
+	Revoke() error
+	Logout() error
+	TokenFile() string
+}
+
 type Option func(*svc)
 
 func NewY2BService(opts ...Option) Svc {
@@ -53,6 +72,7 @@ func NewY2BService(opts ...Option) Svc {
 	s.state = utils.RandomStage()
 	s.in = os.Stdin
 	s.out = os.Stdout
+	s.helper = os.Getenv(HelperEnv)
 
 	for _, opt := range opts {
 		opt(s)
@@ -66,6 +86,14 @@ func WithRedirectURL(url string) Option {
 	}
 }
 
+// WithDevice makes a new token come from the device authorization grant,
+// for machines without a browser, instead of a local redirect.
+func WithDevice(device bool) Option {
+	return func(s *svc) {
+		s.device = device
+	}
+}
+
 func WithIO(in io.Reader, out io.Writer) Option {
 	return func(s *svc) {
 		s.in = in
@@ -75,11 +103,20 @@ func WithIO(in io.Reader, out io.Writer) Option {
 
 func WithCredential(cred string, fsys fs.FS) Option {
 	return func(s *svc) {
-		// cred > YUTU_CREDENTIAL
+		// cred > YUTU_CREDENTIAL > YUTU_CREDENTIAL_HELPER
 		envCred, ok := os.LookupEnv("YUTU_CREDENTIAL")
 		if cred == "" && ok {
 			cred = envCred
-		} else if cred == "" {
+		} else if cred == "" && s.helper != "" {
+			value, err := s.helperGet(keyCredential)
+			if err != nil {
+				s.initErr = fmt.Errorf("%s: %w", readSecretFailed, err)
+				slog.Error(readSecretFailed, "hint", authHint, "error", err)
+				return
+			}
+			cred = value
+		}
+		if cred == "" {
 			cred = s.credFile
 		}
 		// 1. cred is a file path
@@ -115,10 +152,23 @@ func WithCredential(cred string, fsys fs.FS) Option {
 
 func WithCacheToken(token string, fsys fs.FS) Option {
 	return func(s *svc) {
-		// token > YUTU_CACHE_TOKEN
+		// token > YUTU_CACHE_TOKEN > YUTU_CREDENTIAL_HELPER
 		envToken, ok := os.LookupEnv("YUTU_CACHE_TOKEN")
 		if token == "" && ok {
 			token = envToken
+		} else if token == "" && s.helper != "" {
+			value, err := s.helperGet(keyToken)
+			if err != nil {
+				s.initErr = fmt.Errorf("%s: %w", readTokenFailed, err)
+				slog.Error(readTokenFailed, "error", err)
+				return
+			}
+			// The helper also keeps the token once it is refreshed.
+			s.tokenHelper = true
+			if value == "" {
+				return
+			}
+			token = value
 		} else if token == "" {
 			token = "youtube.token.json"
 		}
@@ -152,3 +202,21 @@ func WithCacheToken(token string, fsys fs.FS) Option {
 		}
 	}
 }
+
+// CacheDir returns the directory, relative to pkg.Root, that holds the token
+// cache file. Tokens supplied inline as Base64 or JSON fall back to the root.
+func CacheDir() string {
+	token, ok := os.LookupEnv("YUTU_CACHE_TOKEN")
+	if !ok || token == "" {
+		token = "youtube.token.json"
+	}
+	if !strings.HasSuffix(token, ".json") || utils.IsJson(token) {
+		return "."
+	}
+	absToken, _ := filepath.Abs(token)
+	relToken, err := filepath.Rel(*pkg.RootDir, absToken)
+	if err != nil || !filepath.IsLocal(relToken) {
+		return "."
+	}
+	return filepath.ToSlash(filepath.Dir(relToken))
+}
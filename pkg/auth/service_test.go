@@ -143,3 +143,27 @@ func TestGetService_Errors(t *testing.T) {
 		t.Fatalf("GetService() error = %v, want contains %q", err, parseSecretFailed)
 	}
 }
+
+func TestCacheDir(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "default", token: "", want: "."},
+		{name: "nested file", token: "secrets/youtube.token.json", want: "secrets"},
+		{name: "inline json", token: cacheToken, want: "."},
+		{name: "base64", token: tokenB64, want: "."},
+		{name: "outside root", token: "../youtube.token.json", want: "."},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Setenv("YUTU_CACHE_TOKEN", tt.token)
+				if got := CacheDir(); got != tt.want {
+					t.Errorf("CacheDir() = %q, want %q", got, tt.want)
+				}
+			},
+		)
+	}
+}
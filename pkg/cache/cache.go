@@ -0,0 +1,465 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache keeps the responses of YouTube Data API list calls on disk,
+// next to the token cache. A response younger than TTL is served without a
+// request; an older one is revalidated with If-None-Match and its ETag, and
+// served again when the API answers 304 Not Modified. Any insert, update or
+// delete drops the cached responses of its resource, and of the resources
+// listing it. Caching is off until TTL is set.
+package cache
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/quota"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	// DirName is the cache directory kept next to the token cache.
+	DirName = "yutu.cache"
+	// TTLEnv sets TTL when no --cache-ttl flag is given.
+	TTLEnv = "YUTU_CACHE_TTL"
+
+	statsFile = "stats.json"
+	// Header tells whether a response came from the cache: hit, revalidated
+	// or miss.
+	Header = "X-Yutu-Cache"
+)
+
+var (
+	errReadCache  = errors.New("failed to read cache")
+	errWriteCache = errors.New("failed to write cache")
+	errClearCache = errors.New("failed to clear cache")
+)
+
+// TTL is how long a response is served without asking the API, or 0 to
+// disable the cache. It defaults to YUTU_CACHE_TTL.
+var TTL = ttlFromEnv()
+
+var (
+	// mu serializes updates of the stats file within a process. Across
+	// processes an update may be lost, but the file is never seen half
+	// written.
+	mu sync.Mutex
+	// now is replaced in tests.
+	now = time.Now
+)
+
+func init() {
+	// quota registers its transport first, so cache hits are never counted.
+	common.RegisterTransport(Wrap)
+}
+
+func ttlFromEnv() time.Duration {
+	value, ok := os.LookupEnv(TTLEnv)
+	if !ok || value == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		slog.Warn("Ignoring invalid cache TTL", "env", TTLEnv, "value", value)
+		return 0
+	}
+	return ttl
+}
+
+// Entry is one cached response.
+type Entry struct {
+	Method      string          `json:"method"`
+	ETag        string          `json:"etag,omitempty"`
+	ContentType string          `json:"content_type,omitempty"`
+	Stored      string          `json:"stored"`
+	Body        json.RawMessage `json:"body"`
+}
+
+// Counters is what the cache has saved so far.
+type Counters struct {
+	Hits        int64 `yaml:"hits" json:"hits"`
+	Revalidated int64 `yaml:"revalidated" json:"revalidated"`
+	Misses      int64 `yaml:"misses" json:"misses"`
+	// UnitsSaved is the quota the hits did not spend.
+	UnitsSaved int64 `yaml:"units_saved" json:"units_saved"`
+}
+
+// Dir returns the cache directory, relative to pkg.Root.
+func Dir() string {
+	return filepath.ToSlash(filepath.Join(auth.CacheDir(), DirName))
+}
+
+// key names the entry of a list call by its resource and a hash of its
+// caller, path and sorted parameters, so that entries can be dropped per
+// resource and --mine of one account is never served to another.
+func key(method string, req *http.Request) string {
+	sum := sha256.Sum256(
+		[]byte(common.Identity(req) + " " + req.URL.Path + "?" + req.URL.Query().Encode()),
+	)
+	resource, _, _ := strings.Cut(method, ".")
+	return resource + "-" + hex.EncodeToString(sum[:12]) + ".json"
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+// Wrap caches the list calls sent through base while TTL is set.
+func Wrap(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, ok := quota.Method(req)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	if req.Method != http.MethodGet {
+		// Entries outlive a TTL of 0, so they are dropped even then.
+		res, err := t.base.RoundTrip(req)
+		if err == nil && res.StatusCode < http.StatusBadRequest {
+			resource, _, _ := strings.Cut(method, ".")
+			drop(resource)
+			for _, r := range listedIn[resource] {
+				drop(r)
+			}
+		}
+		return res, err
+	}
+	if TTL <= 0 || !strings.HasSuffix(method, ".list") {
+		return t.base.RoundTrip(req)
+	}
+
+	name := path.Join(Dir(), key(method, req))
+	entry := load(name)
+	if entry != nil {
+		stored, err := time.Parse(time.RFC3339Nano, entry.Stored)
+		if err == nil && now().Sub(stored) < TTL {
+			count(func(c *Counters) {
+				c.Hits++
+				c.UnitsSaved += quota.Cost(method)
+			})
+			return entry.response(req, "hit"), nil
+		}
+		if entry.ETag != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil && res.StatusCode == http.StatusNotModified {
+		_ = res.Body.Close()
+		entry.Stored = now().Format(time.RFC3339Nano)
+		store(name, entry)
+		count(func(c *Counters) { c.Revalidated++ })
+		return entry.response(req, "revalidated"), nil
+	}
+	count(func(c *Counters) { c.Misses++ })
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	res.Header.Set(Header, "miss")
+	etag := res.Header.Get("ETag")
+	if etag == "" {
+		var payload struct {
+			ETag string `json:"etag"`
+		}
+		_ = json.Unmarshal(body, &payload)
+		etag = payload.ETag
+	}
+	store(
+		name, &Entry{
+			Method: method, ETag: etag, ContentType: res.Header.Get("Content-Type"),
+			Stored: now().Format(time.RFC3339Nano), Body: body,
+		},
+	)
+	return res, nil
+}
+
+func (e *Entry) response(req *http.Request, state string) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", e.ContentType)
+	header.Set(Header, state)
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	return &http.Response{
+		Status: "200 OK", StatusCode: http.StatusOK,
+		Proto: "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1,
+		Header: header, Body: io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)), Request: req,
+	}
+}
+
+// load returns the entry in file name, or nil when there is none or it
+// cannot be read.
+func load(name string) *Entry {
+	data, err := pkg.Root.ReadFile(name)
+	if err != nil {
+		return nil
+	}
+	entry := &Entry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil
+	}
+	return entry
+}
+
+// store writes entry to file name. A cache that cannot be written only
+// costs quota, so failures are logged and otherwise ignored.
+func store(name string, entry *Entry) {
+	if err := writeJSON(name, entry); err != nil {
+		slog.Warn("Response not cached", "method", entry.Method, "error", err)
+	}
+}
+
+func writeJSON(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Join(errWriteCache, err)
+	}
+	if err := pkg.Root.MkdirAll(path.Dir(name), 0700); err != nil {
+		return errors.Join(errWriteCache, err)
+	}
+	// Other processes read the file while it is replaced, so it is written
+	// aside and renamed over.
+	tmp := path.Join(path.Dir(name), "."+path.Base(name)+"."+rand.Text())
+	if err := pkg.Root.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Join(errWriteCache, err)
+	}
+	if err := pkg.Root.Rename(tmp, name); err != nil {
+		_ = pkg.Root.Remove(tmp)
+		return errors.Join(errWriteCache, err)
+	}
+	return nil
+}
+
+// listedIn maps a resource to the list calls whose results change with it:
+// a deleted video leaves its playlists and search results, and playlists
+// count their items.
+var listedIn = map[string][]string{
+	"videos":        {"playlistItems", "playlists", "search"},
+	"playlistItems": {"playlists"},
+}
+
+// drop removes the entries of resource.
+func drop(resource string) {
+	entries, err := fs.ReadDir(pkg.Root.FS(), Dir())
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), resource+"-") {
+			_ = pkg.Root.Remove(path.Join(Dir(), e.Name()))
+		}
+	}
+}
+
+func loadCounters() (*Counters, error) {
+	c := &Counters{}
+	data, err := pkg.Root.ReadFile(path.Join(Dir(), statsFile))
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, errors.Join(errReadCache, err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, errors.Join(errReadCache, err)
+	}
+	return c, nil
+}
+
+func count(update func(*Counters)) {
+	mu.Lock()
+	defer mu.Unlock()
+	c, err := loadCounters()
+	if err != nil {
+		c = &Counters{}
+	}
+	update(c)
+	if err := writeJSON(path.Join(Dir(), statsFile), c); err != nil {
+		slog.Debug("Cache stats not recorded", "error", err)
+	}
+}
+
+// ResourceStats is the cached responses of one resource.
+type ResourceStats struct {
+	Resource string `yaml:"resource" json:"resource"`
+	Entries  int    `yaml:"entries" json:"entries"`
+	Fresh    int    `yaml:"fresh" json:"fresh"`
+	Bytes    int64  `yaml:"bytes" json:"bytes"`
+}
+
+// Stats is the state of the cache.
+type Stats struct {
+	Dir       string `yaml:"dir" json:"dir"`
+	TTL       string `yaml:"ttl" json:"ttl"`
+	Counters  `yaml:",inline"`
+	Resources []*ResourceStats `yaml:"resources" json:"resources"`
+}
+
+// Cache manages the response cache.
+type Cache struct {
+	common.Fields
+	Resources []string `yaml:"resources" json:"resources,omitempty"`
+}
+
+type ICache interface {
+	Clear(io.Writer) error
+	Stats(io.Writer) error
+}
+
+type Option func(*Cache)
+
+func NewCache(opts ...Option) ICache {
+	c := &Cache{Fields: common.Fields{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Clear removes the cached responses of Resources, or all of them along
+// with the counters when Resources is empty.
+func (c *Cache) Clear(writer io.Writer) error {
+	entries, err := fs.ReadDir(pkg.Root.FS(), Dir())
+	if os.IsNotExist(err) {
+		entries = nil
+	} else if err != nil {
+		return errors.Join(errClearCache, err)
+	}
+
+	all, removed := len(c.Resources) == 0, 0
+	for _, e := range entries {
+		resource, _, isEntry := strings.Cut(e.Name(), "-")
+		switch {
+		case isEntry && (all || containsFold(c.Resources, resource)):
+			removed++
+		case all && e.Name() == statsFile:
+		default:
+			continue
+		}
+		if err := pkg.Root.Remove(path.Join(Dir(), e.Name())); err != nil {
+			return errors.Join(errClearCache, err)
+		}
+	}
+
+	common.PrintResult(
+		c.Output, map[string]int{"removed": removed}, writer,
+		"Removed %d cached responses\n", removed,
+	)
+	return nil
+}
+
+// Stats prints the cached responses per resource, how many are still
+// fresh, and the hits, revalidations and misses so far.
+func (c *Cache) Stats(writer io.Writer) error {
+	counters, err := loadCounters()
+	if err != nil {
+		return err
+	}
+	entries, err := fs.ReadDir(pkg.Root.FS(), Dir())
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Join(errReadCache, err)
+	}
+
+	stats := &Stats{
+		Dir: Dir(), TTL: TTL.String(), Counters: *counters,
+		Resources: make([]*ResourceStats, 0),
+	}
+	byResource := map[string]*ResourceStats{}
+	for _, e := range entries {
+		resource, _, ok := strings.Cut(e.Name(), "-")
+		if !ok || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		rs, found := byResource[resource]
+		if !found {
+			rs = &ResourceStats{Resource: resource}
+			byResource[resource] = rs
+			stats.Resources = append(stats.Resources, rs)
+		}
+		rs.Entries++
+		if info, err := e.Info(); err == nil {
+			rs.Bytes += info.Size()
+		}
+		entry := load(path.Join(Dir(), e.Name()))
+		if entry == nil {
+			continue
+		}
+		stored, err := time.Parse(time.RFC3339Nano, entry.Stored)
+		if err == nil && now().Sub(stored) < TTL {
+			rs.Fresh++
+		}
+	}
+
+	switch c.Output {
+	case "json", "yaml":
+		common.PrintResult(c.Output, stats, writer, "")
+	default:
+		if err := common.PrintList(
+			&common.Fields{Output: "table"}, stats.Resources, writer,
+			table.Row{"Resource", "Entries", "Fresh", "Bytes"},
+			func(rs *ResourceStats) table.Row {
+				return table.Row{rs.Resource, rs.Entries, rs.Fresh, rs.Bytes}
+			},
+		); err != nil {
+			return err
+		}
+		ttl := "disabled"
+		if TTL > 0 {
+			ttl = "TTL " + stats.TTL
+		}
+		_, _ = fmt.Fprintf(
+			writer, "%d hits, %d revalidated, %d misses, %d quota units saved (%s)\n",
+			stats.Hits, stats.Revalidated, stats.Misses, stats.UnitsSaved, ttl,
+		)
+	}
+	return nil
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func WithResources(resources []string) Option {
+	return func(c *Cache) {
+		c.Resources = resources
+	}
+}
+
+var WithOutput = common.WithOutput[*Cache]
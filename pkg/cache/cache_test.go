@@ -0,0 +1,247 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+)
+
+func setRoot(t *testing.T) {
+	t.Helper()
+	common.SetTestRoot(t)
+	oldTTL, oldNow := TTL, now
+	TTL = time.Minute
+	t.Cleanup(func() { TTL, now = oldTTL, oldNow })
+}
+
+// api serves a video list with an ETag, answering 304 to a matching
+// If-None-Match, and counts what it was asked.
+type api struct {
+	etag      string
+	calls     int
+	notMods   int
+	mutations int
+}
+
+func (a *api) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.calls++
+	if r.Method != http.MethodGet {
+		a.mutations++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"v1"}`))
+		return
+	}
+	if r.Header.Get("If-None-Match") == a.etag {
+		a.notMods++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", a.etag)
+	_, _ = w.Write([]byte(`{"etag":` + `"` + strings.Trim(a.etag, `"`) + `","items":[{"id":"v1","etag":"x"}]}`))
+}
+
+func newService(t *testing.T, a *api) *youtube.Service {
+	t.Helper()
+	server := httptest.NewServer(a)
+	t.Cleanup(server.Close)
+	svc, err := youtube.NewService(
+		context.Background(), option.WithEndpoint(server.URL),
+		option.WithHTTPClient(&http.Client{Transport: Wrap(http.DefaultTransport)}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create youtube service: %v", err)
+	}
+	return svc
+}
+
+func list(t *testing.T, svc *youtube.Service, ids ...string) (*youtube.VideoListResponse, string) {
+	t.Helper()
+	res, err := svc.Videos.List([]string{"id", "snippet"}).Id(ids...).Do()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(res.Items) != 1 || res.Items[0].Id != "v1" {
+		t.Fatalf("List() items = %+v", res.Items)
+	}
+	return res, res.Header.Get(Header)
+}
+
+func TestTransport(t *testing.T) {
+	setRoot(t)
+	start := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+	a := &api{etag: `"etag-1"`}
+	svc := newService(t, a)
+
+	if _, state := list(t, svc, "v1"); state != "miss" {
+		t.Errorf("first list = %q, want miss", state)
+	}
+	if _, state := list(t, svc, "v1"); state != "hit" || a.calls != 1 {
+		t.Errorf("second list = %q after %d calls, want hit after 1", state, a.calls)
+	}
+	if _, state := list(t, svc, "v2"); state != "miss" || a.calls != 2 {
+		t.Errorf("other parameters = %q after %d calls, want miss after 2", state, a.calls)
+	}
+
+	now = func() time.Time { return start.Add(2 * time.Minute) }
+	if _, state := list(t, svc, "v1"); state != "revalidated" || a.notMods != 1 {
+		t.Errorf("stale list = %q after %d 304s, want revalidated after 1", state, a.notMods)
+	}
+	if _, state := list(t, svc, "v1"); state != "hit" || a.calls != 3 {
+		t.Errorf("revalidated list = %q after %d calls, want hit after 3", state, a.calls)
+	}
+
+	a.etag = `"etag-2"`
+	now = func() time.Time { return start.Add(4 * time.Minute) }
+	if res, state := list(t, svc, "v1"); state != "miss" || res.Etag != "etag-2" {
+		t.Errorf("changed list = %q with etag %q, want miss with etag-2", state, res.Etag)
+	}
+
+	var buf bytes.Buffer
+	if err := NewCache(WithOutput("json")).Stats(&buf); err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	stats := &Stats{}
+	if err := json.Unmarshal(buf.Bytes(), stats); err != nil {
+		t.Fatalf("bad json %q: %v", buf.String(), err)
+	}
+	if stats.Hits != 2 || stats.Revalidated != 1 || stats.Misses != 3 || stats.UnitsSaved != 2 {
+		t.Errorf("counters = %+v", stats.Counters)
+	}
+	if len(stats.Resources) != 1 || stats.Resources[0].Entries != 2 || stats.Resources[0].Fresh != 1 {
+		t.Errorf("resources = %+v", stats.Resources)
+	}
+}
+
+func TestTransport_Identity(t *testing.T) {
+	setRoot(t)
+	a := &api{etag: `"etag-1"`}
+	svc := newService(t, a)
+
+	listAs := func(id string) string {
+		res, err := svc.Videos.List([]string{"id"}).MyRating("like").
+			Context(common.CtxWithIdentity(context.Background(), id)).Do()
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		return res.Header.Get(Header)
+	}
+	if state := listAs("alice"); state != "miss" {
+		t.Errorf("first list of alice = %q, want miss", state)
+	}
+	if state := listAs("bob"); state != "miss" || a.calls != 2 {
+		t.Errorf("list of bob = %q after %d calls, want miss after 2", state, a.calls)
+	}
+	if state := listAs("alice"); state != "hit" || a.calls != 2 {
+		t.Errorf("second list of alice = %q after %d calls, want hit after 2", state, a.calls)
+	}
+}
+
+func TestTransport_Mutation(t *testing.T) {
+	setRoot(t)
+	a := &api{etag: `"etag-1"`}
+	svc := newService(t, a)
+
+	list(t, svc, "v1")
+	if _, err := svc.Videos.Update([]string{"snippet"}, &youtube.Video{Id: "v1"}).Do(); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, state := list(t, svc, "v1"); state != "miss" {
+		t.Errorf("list after update = %q, want miss", state)
+	}
+}
+
+func TestTransport_MutationListedIn(t *testing.T) {
+	setRoot(t)
+	svc := newService(t, &api{etag: `"etag-1"`})
+
+	for _, resource := range []string{"playlistItems", "playlists", "search", "comments"} {
+		if err := writeJSON(path.Join(Dir(), resource+"-x.json"), &Entry{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.Videos.Delete("v1").Do(); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	entries, err := fs.ReadDir(pkg.Root.FS(), Dir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if !slices.Contains(names, "comments-x.json") || slices.ContainsFunc(
+		names, func(name string) bool {
+			return strings.HasPrefix(name, "play") || strings.HasPrefix(name, "search")
+		},
+	) {
+		t.Errorf("cache after video delete = %v", names)
+	}
+}
+
+func TestTransport_Disabled(t *testing.T) {
+	setRoot(t)
+	TTL = 0
+	a := &api{etag: `"etag-1"`}
+	svc := newService(t, a)
+
+	list(t, svc, "v1")
+	list(t, svc, "v1")
+	if a.calls != 2 {
+		t.Errorf("server saw %d calls, want 2", a.calls)
+	}
+	if _, err := pkg.Root.Stat(Dir()); !os.IsNotExist(err) {
+		t.Errorf("cache directory created while disabled: %v", err)
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	setRoot(t)
+	svc := newService(t, &api{etag: `"etag-1"`})
+	list(t, svc, "v1")
+	if _, err := svc.Playlists.List([]string{"id"}).Id("p1").Do(); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewCache(WithResources([]string{"playlists"})).Clear(&buf); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if buf.String() != "Removed 1 cached responses\n" {
+		t.Errorf("Clear() = %q", buf.String())
+	}
+	if _, state := list(t, svc, "v1"); state != "hit" {
+		t.Errorf("videos after clearing playlists = %q, want hit", state)
+	}
+
+	buf.Reset()
+	if err := NewCache().Clear(&buf); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if buf.String() != "Removed 1 cached responses\n" {
+		t.Errorf("Clear() = %q", buf.String())
+	}
+	counters, _ := loadCounters()
+	if *counters != (Counters{}) {
+		t.Errorf("counters after clear = %+v", counters)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"google.golang.org/api/youtube/v3"
 )
@@ -76,6 +77,11 @@ func (c *Caption) Get() ([]*youtube.Caption, error) {
 		call = call.OnBehalfOfContentOwner(c.OnBehalfOfContentOwner)
 	}
 
+	call, err := common.ApplyMask(&c.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetCaption, err)
@@ -90,13 +96,20 @@ func (c *Caption) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		c.Output, captions, writer, table.Row{"ID", "Video ID", "Name", "Language"},
+	return common.PrintList(
+		&c.Fields, captions, writer, table.Row{"ID", "Video ID", "Name", "Language"},
 		func(cap *youtube.Caption) table.Row {
-			return table.Row{cap.Id, cap.Snippet.VideoId, cap.Snippet.Name, cap.Snippet.Language}
+			videoId := ""
+			name := ""
+			language := ""
+			if cap.Snippet != nil {
+				videoId = cap.Snippet.VideoId
+				name = cap.Snippet.Name
+				language = cap.Snippet.Language
+			}
+			return table.Row{cap.Id, videoId, name, language}
 		},
 	)
-	return nil
 }
 
 func (c *Caption) Insert(writer io.Writer) error {
@@ -126,7 +139,9 @@ func (c *Caption) Insert(writer io.Writer) error {
 		},
 	}
 
-	call := c.Service.Captions.Insert([]string{"snippet"}, caption).Media(file)
+	call := c.Service.Captions.Insert([]string{"snippet"}, caption).Media(
+		progress.File(c.Ctx, file),
+	)
 	if c.OnBehalfOf != "" {
 		call = call.OnBehalfOf(c.OnBehalfOf)
 	}
@@ -378,10 +393,15 @@ func WithTlang(tlang string) Option {
 }
 
 var (
-	WithIds     = common.WithIds[*Caption]
-	WithParts   = common.WithParts[*Caption]
-	WithOutput  = common.WithOutput[*Caption]
-	WithService = common.WithService[*Caption]
+	WithIds       = common.WithIds[*Caption]
+	WithParts     = common.WithParts[*Caption]
+	WithColumns   = common.WithColumns[*Caption]
+	WithWhere     = common.WithWhere[*Caption]
+	WithSortBy    = common.WithSortBy[*Caption]
+	WithFieldMask = common.WithFieldMask[*Caption]
+	WithOutput    = common.WithOutput[*Caption]
+	WithService   = common.WithService[*Caption]
+	WithContext   = common.WithContext[*Caption]
 
 	WithOnBehalfOfContentOwner = common.WithOnBehalfOfContentOwner[*Caption]
 )
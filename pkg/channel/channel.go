@@ -6,8 +6,10 @@ package channel
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/lint"
 	"github.com/jedib0t/go-pretty/v6/table"
 
 	"google.golang.org/api/youtube/v3"
@@ -35,6 +37,7 @@ type IChannel[T youtube.Channel] interface {
 	List(io.Writer) error
 	Update(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 }
 
 type Option func(*Channel)
@@ -48,8 +51,12 @@ func NewChannel(opts ...Option) IChannel[youtube.Channel] {
 }
 
 func (c *Channel) Get() ([]*youtube.Channel, error) {
+	return common.Collect(c.All())
+}
+
+func (c *Channel) All() iter.Seq2[*youtube.Channel, error] {
 	if err := c.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Channel](err)
 	}
 	call := c.Service.Channels.List(c.Parts)
 	if c.CategoryId != "" {
@@ -79,22 +86,19 @@ func (c *Channel) Get() ([]*youtube.Channel, error) {
 		call = call.OnBehalfOfContentOwner(c.OnBehalfOfContentOwner)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&c.Fields, call,
-		func(r *youtube.ChannelListResponse) ([]*youtube.Channel, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.ChannelListResponse) (
+			[]*youtube.Channel, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetChannel,
 	)
 }
 
 func (c *Channel) List(writer io.Writer) error {
-	channels, err := c.Get()
-	if err != nil && channels == nil {
-		return err
-	}
-
-	common.PrintList(
-		c.Output, channels, writer, table.Row{"ID", "Title", "Country"},
+	return common.PrintSeq(
+		&c.Fields, c.All(), writer, table.Row{"ID", "Title", "Country"},
 		func(ch *youtube.Channel) table.Row {
 			title := ""
 			country := ""
@@ -105,10 +109,13 @@ func (c *Channel) List(writer io.Writer) error {
 			return table.Row{ch.Id, title, country}
 		},
 	)
-	return err
 }
 
 func (c *Channel) Update(writer io.Writer) error {
+	err := lint.Check(lint.Metadata{Title: c.Title, Description: c.Description})
+	if err != nil {
+		return errors.Join(errUpdateChannel, err)
+	}
 	c.Parts = []string{"snippet"}
 	channels, err := c.Get()
 	if err != nil {
@@ -201,6 +208,12 @@ func WithTitle(title string) Option {
 
 var (
 	WithParts      = common.WithParts[*Channel]
+	WithColumns    = common.WithColumns[*Channel]
+	WithWhere      = common.WithWhere[*Channel]
+	WithSortBy     = common.WithSortBy[*Channel]
+	WithFieldMask  = common.WithFieldMask[*Channel]
+	WithPageToken  = common.WithPageToken[*Channel]
+	WithEnvelope   = common.WithEnvelope[*Channel]
 	WithOutput     = common.WithOutput[*Channel]
 	WithService    = common.WithService[*Channel]
 	WithIds        = common.WithIds[*Channel]
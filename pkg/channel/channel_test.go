@@ -464,6 +464,17 @@ func TestChannel_Update(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "update channel invalid description",
+			opts: []Option{
+				WithIds([]string{"channel-id"}),
+				WithDescription("<b>bold</b>"),
+			},
+			verify: func(r *http.Request) {
+				t.Errorf("invalid metadata should not reach the API: %s", r.Method)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
 	"google.golang.org/api/youtube/v3"
 )
 
@@ -51,7 +52,9 @@ func (cb *ChannelBanner) Insert(writer io.Writer) error {
 	}(file)
 	cbr := &youtube.ChannelBannerResource{}
 
-	call := cb.Service.ChannelBanners.Insert(cbr).ChannelId(cb.ChannelId).Media(file)
+	call := cb.Service.ChannelBanners.Insert(cbr).ChannelId(cb.ChannelId).Media(
+		progress.File(cb.Ctx, file),
+	)
 	if cb.OnBehalfOfContentOwner != "" {
 		call = call.OnBehalfOfContentOwner(cb.OnBehalfOfContentOwner)
 	}
@@ -86,6 +89,7 @@ var (
 	WithChannelId = common.WithChannelId[*ChannelBanner]
 	WithOutput    = common.WithOutput[*ChannelBanner]
 	WithService   = common.WithService[*ChannelBanner]
+	WithContext   = common.WithContext[*ChannelBanner]
 
 	WithOnBehalfOfContentOwner = common.WithOnBehalfOfContentOwner[*ChannelBanner]
 )
@@ -65,6 +65,11 @@ func (cs *ChannelSection) Get() (
 		call = call.OnBehalfOfContentOwner(cs.OnBehalfOfContentOwner)
 	}
 
+	call, err := common.ApplyMask(&cs.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetChannelSection, err)
@@ -78,13 +83,18 @@ func (cs *ChannelSection) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		cs.Output, channelSections, writer, table.Row{"ID", "Channel ID", "Title"},
+	return common.PrintList(
+		&cs.Fields, channelSections, writer, table.Row{"ID", "Channel ID", "Title"},
 		func(s *youtube.ChannelSection) table.Row {
-			return table.Row{s.Id, s.Snippet.ChannelId, s.Snippet.Title}
+			channelId := ""
+			title := ""
+			if s.Snippet != nil {
+				channelId = s.Snippet.ChannelId
+				title = s.Snippet.Title
+			}
+			return table.Row{s.Id, channelId, title}
 		},
 	)
-	return nil
 }
 
 func (cs *ChannelSection) Delete(writer io.Writer) error {
@@ -117,6 +127,10 @@ func WithMine(mine *bool) Option {
 
 var (
 	WithParts     = common.WithParts[*ChannelSection]
+	WithColumns   = common.WithColumns[*ChannelSection]
+	WithWhere     = common.WithWhere[*ChannelSection]
+	WithSortBy    = common.WithSortBy[*ChannelSection]
+	WithFieldMask = common.WithFieldMask[*ChannelSection]
 	WithOutput    = common.WithOutput[*ChannelSection]
 	WithService   = common.WithService[*ChannelSection]
 	WithIds       = common.WithIds[*ChannelSection]
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -37,6 +38,7 @@ type Comment struct {
 
 type IComment[T any] interface {
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	List(io.Writer) error
 	Insert(io.Writer) error
 	Update(io.Writer) error
@@ -56,8 +58,12 @@ func NewComment(opts ...Option) IComment[youtube.Comment] {
 }
 
 func (c *Comment) Get() ([]*youtube.Comment, error) {
+	return common.Collect(c.All())
+}
+
+func (c *Comment) All() iter.Seq2[*youtube.Comment, error] {
 	if err := c.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Comment](err)
 	}
 	call := c.Service.Comments.List(c.Parts)
 	if len(c.Ids) > 0 && c.Ids[0] != "" {
@@ -70,22 +76,19 @@ func (c *Comment) Get() ([]*youtube.Comment, error) {
 		call = call.TextFormat(c.TextFormat)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&c.Fields, call,
-		func(r *youtube.CommentListResponse) ([]*youtube.Comment, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.CommentListResponse) (
+			[]*youtube.Comment, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetComment,
 	)
 }
 
 func (c *Comment) List(writer io.Writer) error {
-	comments, err := c.Get()
-	if err != nil && comments == nil {
-		return err
-	}
-
-	common.PrintList(
-		c.Output, comments, writer,
+	return common.PrintSeq(
+		&c.Fields, c.All(), writer,
 		table.Row{"ID", "Author", "Video ID", "Text Display"},
 		func(cm *youtube.Comment) table.Row {
 			author := ""
@@ -99,7 +102,6 @@ func (c *Comment) List(writer io.Writer) error {
 			return table.Row{cm.Id, author, videoId, textDisplay}
 		},
 	)
-	return err
 }
 
 func (c *Comment) Insert(writer io.Writer) error {
@@ -280,6 +282,12 @@ func WithViewerRating(viewerRating string) Option {
 
 var (
 	WithParts      = common.WithParts[*Comment]
+	WithColumns    = common.WithColumns[*Comment]
+	WithWhere      = common.WithWhere[*Comment]
+	WithSortBy     = common.WithSortBy[*Comment]
+	WithFieldMask  = common.WithFieldMask[*Comment]
+	WithPageToken  = common.WithPageToken[*Comment]
+	WithEnvelope   = common.WithEnvelope[*Comment]
 	WithOutput     = common.WithOutput[*Comment]
 	WithService    = common.WithService[*Comment]
 	WithIds        = common.WithIds[*Comment]
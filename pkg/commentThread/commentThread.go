@@ -6,6 +6,7 @@ package commentThread
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -32,6 +33,7 @@ type CommentThread struct {
 
 type ICommentThread[T any] interface {
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	List(io.Writer) error
 	Insert(io.Writer) error
 }
@@ -47,8 +49,12 @@ func NewCommentThread(opts ...Option) ICommentThread[youtube.CommentThread] {
 }
 
 func (c *CommentThread) Get() ([]*youtube.CommentThread, error) {
+	return common.Collect(c.All())
+}
+
+func (c *CommentThread) All() iter.Seq2[*youtube.CommentThread, error] {
 	if err := c.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.CommentThread](err)
 	}
 	call := c.Service.CommentThreads.List(c.Parts)
 	if len(c.Ids) > 0 {
@@ -76,29 +82,34 @@ func (c *CommentThread) Get() ([]*youtube.CommentThread, error) {
 		call = call.VideoId(c.VideoId)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&c.Fields, call,
-		func(r *youtube.CommentThreadListResponse) ([]*youtube.CommentThread, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.CommentThreadListResponse) (
+			[]*youtube.CommentThread, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetCommentThread,
 	)
 }
 
 func (c *CommentThread) List(writer io.Writer) error {
-	commentThreads, err := c.Get()
-	if err != nil && commentThreads == nil {
-		return err
-	}
-
-	common.PrintList(
-		c.Output, commentThreads, writer,
+	return common.PrintSeq(
+		&c.Fields, c.All(), writer,
 		table.Row{"ID", "Author", "Video ID", "Text Display"},
 		func(cot *youtube.CommentThread) table.Row {
-			snippet := cot.Snippet.TopLevelComment.Snippet
-			return table.Row{cot.Id, snippet.AuthorDisplayName, snippet.VideoId, snippet.TextDisplay}
+			author := ""
+			videoId := ""
+			textDisplay := ""
+			if cot.Snippet != nil && cot.Snippet.TopLevelComment != nil &&
+				cot.Snippet.TopLevelComment.Snippet != nil {
+				snippet := cot.Snippet.TopLevelComment.Snippet
+				author = snippet.AuthorDisplayName
+				videoId = snippet.VideoId
+				textDisplay = snippet.TextDisplay
+			}
+			return table.Row{cot.Id, author, videoId, textDisplay}
 		},
 	)
-	return err
 }
 
 func (c *CommentThread) Insert(writer io.Writer) error {
@@ -182,6 +193,12 @@ func WithVideoId(videoId string) Option {
 
 var (
 	WithParts      = common.WithParts[*CommentThread]
+	WithColumns    = common.WithColumns[*CommentThread]
+	WithWhere      = common.WithWhere[*CommentThread]
+	WithSortBy     = common.WithSortBy[*CommentThread]
+	WithFieldMask  = common.WithFieldMask[*CommentThread]
+	WithPageToken  = common.WithPageToken[*CommentThread]
+	WithEnvelope   = common.WithEnvelope[*CommentThread]
 	WithOutput     = common.WithOutput[*CommentThread]
 	WithService    = common.WithService[*CommentThread]
 	WithIds        = common.WithIds[*CommentThread]
@@ -5,32 +5,137 @@ package common
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"math"
+	"net/http"
+	"os"
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/retry"
 	"github.com/eat-pray-ai/yutu/pkg/utils"
-	"github.com/jedib0t/go-pretty/v6/table"
 	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/googleapi/transport"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
+// APIKeyEnv sets the API key when no --api-key flag is given.
+const APIKeyEnv = "YUTU_API_KEY"
+
+// APIKey, or YUTU_API_KEY, makes EnsureService call the API with a key
+// instead of an OAuth token. Such services can only read public data.
+var APIKey string
+
+// ErrAPIKeyReadOnly refuses a call that would change something, which an
+// API key is never allowed to do.
+var ErrAPIKeyReadOnly = errors.New(
+	"API key mode is read-only, unset --api-key and YUTU_API_KEY and authenticate with OAuth to make changes",
+)
+
 type redirectURLKey struct{}
 
+type pageKey struct{}
+
+type identityKey struct{}
+
 // CtxWithRedirectURL returns a child context carrying the OAuth redirect URL.
 func CtxWithRedirectURL(ctx context.Context, url string) context.Context {
 	return context.WithValue(ctx, redirectURLKey{}, url)
 }
 
+// CtxWithPage returns a child context in which paged lists record where
+// they stopped into page.
+func CtxWithPage(ctx context.Context, page *Page) context.Context {
+	return context.WithValue(ctx, pageKey{}, page)
+}
+
+// CtxWithIdentity returns a child context in which API calls are made for
+// id, see Identity.
+func CtxWithIdentity(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// Page is where a paged list stopped: the token to carry on from, and the
+// total the API estimates. The token is that of the page after the last one
+// used up, or of a page left partly used so that none of it is skipped. It
+// is empty after the last page, and before the first one is used up.
+type Page struct {
+	NextPageToken string `yaml:"nextPageToken,omitempty" json:"nextPageToken,omitempty"`
+	TotalResults  int64  `yaml:"totalResults,omitempty" json:"totalResults,omitempty"`
+}
+
+// transports wrap the client of every service EnsureService creates, see
+// RegisterTransport.
+var transports []func(http.RoundTripper) http.RoundTripper
+
+// RegisterTransport makes EnsureService send API calls through wrap. The
+// transport registered last sees a request first. Services injected through
+// WithService are left alone.
+func RegisterTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	transports = append(transports, wrap)
+}
+
+// wrapClient returns client sending through the registered transports,
+// which see the requests as made for id, see Identity.
+func wrapClient(client *http.Client, id string) *http.Client {
+	if len(transports) == 0 {
+		return client
+	}
+	rt := client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for _, wrap := range transports {
+		rt = wrap(rt)
+	}
+	wrapped := *client
+	wrapped.Transport = &identify{base: rt, id: id}
+	return &wrapped
+}
+
+// Identity returns who req is made for, so that registered transports keep
+// what they store per caller. It is a hash of the bearer token, API key or
+// token cache of the service, and empty for services injected through
+// WithService.
+func Identity(req *http.Request) string {
+	id, _ := req.Context().Value(identityKey{}).(string)
+	return id
+}
+
+// identity hashes what tells callers apart, so it can be kept on disk.
+func identity(kind string, values ...string) string {
+	h := sha256.New()
+	h.Write([]byte(kind))
+	for _, v := range values {
+		h.Write([]byte{0})
+		h.Write([]byte(v))
+	}
+	return kind + "-" + hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// identify marks the requests it sends as made for id.
+type identify struct {
+	base http.RoundTripper
+	id   string
+}
+
+func (t *identify) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(CtxWithIdentity(req.Context(), t.id)))
+}
+
 type Fields struct {
 	Ctx         context.Context  `yaml:"-" json:"-"`
 	Service     *youtube.Service `yaml:"-" json:"-"`
+	Client      *http.Client     `yaml:"-" json:"-"`
 	RedirectURL string           `yaml:"-" json:"-"`
 	Ids         []string         `yaml:"ids" json:"ids,omitempty"`
 	Confirmed   bool             `yaml:"-" json:"confirmed,omitempty"`
@@ -39,6 +144,13 @@ type Fields struct {
 	ChannelId   string           `yaml:"channel_id" json:"channel_id,omitempty"`
 	Parts       []string         `yaml:"parts" json:"parts,omitempty"`
 	Output      string           `yaml:"output" json:"output,omitempty"`
+	Columns     []string         `yaml:"columns" json:"columns,omitempty"`
+	Where       string           `yaml:"where" json:"where,omitempty"`
+	SortBy      string           `yaml:"sort_by" json:"sort_by,omitempty"`
+	FieldMask   string           `yaml:"fields" json:"fields,omitempty"`
+	PageToken   string           `yaml:"page_token" json:"page_token,omitempty"`
+	Envelope    bool             `yaml:"envelope" json:"envelope,omitempty"`
+	Page        Page             `yaml:"-" json:"-"`
 
 	OnBehalfOfContentOwner string `yaml:"on_behalf_of_content_owner" json:"on_behalf_of_content_owner,omitempty"`
 }
@@ -68,38 +180,130 @@ func (d *Fields) EnsureService() error {
 		if tokenInfo := sdkauth.TokenInfoFromContext(d.Ctx); tokenInfo != nil {
 			if rawToken, ok := tokenInfo.Extra["access_token"].(string); ok {
 				ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: rawToken})
-				client := oauth2.NewClient(d.Ctx, ts)
+				client := wrapClient(
+					retry.Client(oauth2.NewClient(d.Ctx, ts)), identity("bearer", rawToken),
+				)
 				svc, err := youtube.NewService(d.Ctx, option.WithHTTPClient(client))
 				if err != nil {
-					return fmt.Errorf("failed to create YouTube service: %w", err)
+					return failure.New(
+						failure.AuthError, fmt.Errorf("failed to create YouTube service: %w", err),
+					)
 				}
 				d.Service = svc
+				d.Client = client
 				return nil
 			}
 		}
 	}
 
+	// API key path: public, read-only calls without a user token.
+	if key := apiKey(); key != "" {
+		client := &http.Client{
+			Transport: &transport.APIKey{Key: key, Transport: http.DefaultTransport},
+		}
+		client = wrapClient(retry.Client(client), identity("key", key))
+		client.Transport = readOnly(client.Transport)
+		svc, err := youtube.NewService(context.Background(), option.WithHTTPClient(client))
+		if err != nil {
+			return fmt.Errorf("failed to create YouTube service: %w", err)
+		}
+		d.Service = svc
+		d.Client = client
+		return nil
+	}
+
 	// File-based auth path (CLI / stdio mode).
 	if d.RedirectURL == "" {
 		d.RedirectURL = "http://localhost:8216"
 	}
-	svc, err := auth.NewY2BService(
+	y2b := auth.NewY2BService(
 		auth.WithCredential("", pkg.Root.FS()),
 		auth.WithCacheToken("", pkg.Root.FS()),
 		auth.WithRedirectURL(d.RedirectURL),
-	).GetService()
+	).(auth.Manager)
+	svc, err := y2b.GetService()
 	if err != nil {
-		return fmt.Errorf("failed to create YouTube service: %w", err)
+		return failure.New(
+			failure.AuthError, fmt.Errorf("failed to create YouTube service: %w", err),
+		)
+	}
+	client := y2b.HTTPClient()
+	if client != nil && len(transports) > 0 {
+		client = wrapClient(client, tokenIdentity(y2b))
+		svc, err = youtube.NewService(context.Background(), option.WithHTTPClient(client))
+		if err != nil {
+			return failure.New(
+				failure.AuthError, fmt.Errorf("failed to create YouTube service: %w", err),
+			)
+		}
 	}
 	d.Service = svc
+	d.Client = client
 	return nil
 }
 
+// tokenIdentity tells the accounts of the file-based auth path apart by
+// their token file, or by where an inline token comes from.
+func tokenIdentity(y2b auth.Manager) string {
+	if file := y2b.TokenFile(); file != "" {
+		return identity("file", file)
+	}
+	return identity(
+		"token", os.Getenv("YUTU_CACHE_TOKEN"), os.Getenv(auth.HelperEnv),
+		os.Getenv("YUTU_CREDENTIAL"),
+	)
+}
+
+func apiKey() string {
+	if APIKey != "" {
+		return APIKey
+	}
+	return os.Getenv(APIKeyEnv)
+}
+
+// readOnly refuses every call through base that is not a read, before it
+// is metered or sent.
+func readOnly(base http.RoundTripper) http.RoundTripper {
+	return roundTripper(
+		func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return nil, failure.New(
+					failure.AuthError, fmt.Errorf(
+						"%w: %s %s", ErrAPIKeyReadOnly, req.Method, req.URL.Path,
+					),
+				)
+			}
+			return base.RoundTrip(req)
+		},
+	)
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// HTTPClient returns the authorized client behind Service. Services injected
+// through WithService carry no client, so http.DefaultClient is used instead.
+func (d *Fields) HTTPClient() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return http.DefaultClient
+}
+
 type HasFields interface {
 	GetFields() *Fields
 	EnsureService() error
 }
 
+func WithContext[T HasFields](ctx context.Context) func(T) {
+	return func(t T) {
+		t.GetFields().SetContext(ctx)
+	}
+}
+
 func WithParts[T HasFields](parts []string) func(T) {
 	return func(t T) {
 		t.GetFields().Parts = parts
@@ -112,6 +316,42 @@ func WithOutput[T HasFields](output string) func(T) {
 	}
 }
 
+func WithColumns[T HasFields](columns []string) func(T) {
+	return func(t T) {
+		t.GetFields().Columns = columns
+	}
+}
+
+func WithWhere[T HasFields](where string) func(T) {
+	return func(t T) {
+		t.GetFields().Where = where
+	}
+}
+
+func WithSortBy[T HasFields](sortBy string) func(T) {
+	return func(t T) {
+		t.GetFields().SortBy = sortBy
+	}
+}
+
+func WithFieldMask[T HasFields](mask string) func(T) {
+	return func(t T) {
+		t.GetFields().FieldMask = mask
+	}
+}
+
+func WithPageToken[T HasFields](pageToken string) func(T) {
+	return func(t T) {
+		t.GetFields().PageToken = pageToken
+	}
+}
+
+func WithEnvelope[T HasFields](envelope bool) func(T) {
+	return func(t T) {
+		t.GetFields().Envelope = envelope
+	}
+}
+
 func WithService[T HasFields](svc *youtube.Service) func(T) {
 	return func(t T) {
 		t.GetFields().Service = svc
@@ -154,26 +394,6 @@ func WithOnBehalfOfContentOwner[T HasFields](owner string) func(T) {
 	}
 }
 
-// PrintList handles the json/yaml/table output switch for List methods.
-// The header and row function are only used for table output.
-func PrintList[T any](output string, items []*T, w io.Writer, header table.Row, row func(*T) table.Row) {
-	switch output {
-	case "json":
-		utils.PrintJSON(items, w)
-	case "yaml":
-		utils.PrintYAML(items, w)
-	case "table":
-		tb := table.NewWriter()
-		defer tb.Render()
-		tb.SetOutputMirror(w)
-		tb.SetStyle(pkg.TableStyle)
-		tb.AppendHeader(header)
-		for _, item := range items {
-			tb.AppendRow(row(item))
-		}
-	}
-}
-
 // PrintResult handles the json/yaml/silent/default output switch for mutation methods.
 func PrintResult(output string, data any, w io.Writer, format string, args ...any) {
 	switch output {
@@ -191,36 +411,97 @@ func PrintResult(output string, data any, w io.Writer, format string, args ...an
 type PagedLister[C any, R any] interface {
 	MaxResults(int64) C
 	PageToken(string) C
+	Fields(s ...googleapi.Field) C
 	Do(opts ...googleapi.CallOption) (*R, error)
 }
 
 // Paginate fetches all pages of results. It handles MaxResults, PageToken,
-// Do(), and error wrapping automatically. The extract function pulls items
-// and the next page token from the response.
+// the field mask of f, Do(), and error wrapping automatically. The extract
+// function pulls items, the next page token and the page info from the
+// response.
 func Paginate[C PagedLister[C, R], R any, T any](
 	f *Fields, call C,
-	extract func(*R) ([]*T, string),
+	extract func(*R) ([]*T, string, *youtube.PageInfo),
 	errWrap error,
 ) ([]*T, error) {
-	var items []*T
-	remaining := f.MaxResults
-	pageToken := ""
-	for remaining > 0 {
-		call = call.MaxResults(min(remaining, pkg.PerPage))
-		if pageToken != "" {
-			call = call.PageToken(pageToken)
-		}
-		res, err := call.Do()
+	return Collect(PaginateSeq(f, call, extract, errWrap))
+}
+
+// PaginateSeq yields the results of Paginate as their pages arrive. The
+// next page is only fetched once the items before it are used, so stopping
+// early fetches no more. An error ends the sequence, which is single use
+// as call keeps the page token. It starts on f.PageToken, and records where
+// it stopped in f.Page and in the Page of f.Ctx, see CtxWithPage. A page only
+// counts as done once all its items are yielded.
+func PaginateSeq[C PagedLister[C, R], R any, T any](
+	f *Fields, call C,
+	extract func(*R) ([]*T, string, *youtube.PageInfo),
+	errWrap error,
+) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		f.Page = Page{}
+		call, err := ApplyMask(f, call, "nextPageToken", "pageInfo")
 		if err != nil {
-			return items, errors.Join(errWrap, err)
+			yield(nil, err)
+			return
+		}
+		remaining := f.MaxResults
+		pageToken := f.PageToken
+		for remaining > 0 {
+			call = call.MaxResults(min(remaining, pkg.PerPage))
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			res, err := call.Do()
+			if err != nil {
+				yield(nil, errors.Join(errWrap, err))
+				return
+			}
+			got, nextToken, info := extract(res)
+			f.recordPage(pageToken, info)
+			for _, item := range got {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			f.recordPage(nextToken, info)
+			remaining -= pkg.PerPage
+			pageToken = nextToken
+			if pageToken == "" || len(got) == 0 {
+				return
+			}
 		}
-		got, nextToken := extract(res)
-		remaining -= pkg.PerPage
-		items = append(items, got...)
-		pageToken = nextToken
-		if pageToken == "" || len(got) == 0 {
-			break
+	}
+}
+
+func (d *Fields) recordPage(nextToken string, info *youtube.PageInfo) {
+	d.Page.NextPageToken = nextToken
+	if info != nil {
+		d.Page.TotalResults = info.TotalResults
+	}
+	if d.Ctx != nil {
+		if page, ok := d.Ctx.Value(pageKey{}).(*Page); ok {
+			*page = d.Page
+		}
+	}
+}
+
+// Collect gathers the items of seq. On an error it returns the items
+// before it along with the error.
+func Collect[T any](seq iter.Seq2[*T, error]) ([]*T, error) {
+	var items []*T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
 		}
+		items = append(items, item)
 	}
 	return items, nil
 }
+
+// FailSeq returns a sequence yielding only err.
+func FailSeq[T any](err error) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		yield(nil, err)
+	}
+}
@@ -6,12 +6,17 @@ package common
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/eat-pray-ai/yutu/pkg/failure"
 	"github.com/jedib0t/go-pretty/v6/table"
 	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
 	"google.golang.org/api/youtube/v3"
@@ -31,8 +36,8 @@ func (r *testResource) EnsureService() error {
 }
 
 // videoExtract is the extract function for Paginate with youtube.VideoListResponse.
-func videoExtract(r *youtube.VideoListResponse) ([]*youtube.Video, string) {
-	return r.Items, r.NextPageToken
+func videoExtract(r *youtube.VideoListResponse) ([]*youtube.Video, string, *youtube.PageInfo) {
+	return r.Items, r.NextPageToken, r.PageInfo
 }
 
 // ---------- TestPaginate ----------
@@ -200,7 +205,7 @@ func TestPrintList(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			PrintList(tt.output, items, &buf, header, rowFn)
+			PrintList(&Fields{Output: tt.output}, items, &buf, header, rowFn)
 			if buf.Len() == 0 {
 				t.Errorf("PrintList(%q) produced empty output", tt.output)
 			}
@@ -310,6 +315,97 @@ func TestEnsureService_FallsBackWithContextNoToken(t *testing.T) {
 	_ = err
 }
 
+func TestEnsureService_APIKey(t *testing.T) {
+	var gotKey string
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				gotKey = r.URL.Query().Get("key")
+				_, _ = w.Write([]byte(`{}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	old := transports
+	t.Cleanup(func() { transports = old })
+	transports = nil
+	t.Setenv(APIKeyEnv, "env-key")
+
+	f := &Fields{}
+	if err := f.EnsureService(); err != nil {
+		t.Fatalf("EnsureService() error = %v", err)
+	}
+	res, err := f.HTTPClient().Get(server.URL + "/youtube/v3/videos?part=id")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	_ = res.Body.Close()
+	if gotKey != "env-key" {
+		t.Errorf("key = %q, want env-key", gotKey)
+	}
+
+	_, err = f.HTTPClient().Post(server.URL+"/youtube/v3/videos", "application/json", nil)
+	if !errors.Is(err, ErrAPIKeyReadOnly) {
+		t.Errorf("POST error = %v, want %v", err, ErrAPIKeyReadOnly)
+	}
+	if failure.Classify(err).Class != failure.AuthError {
+		t.Errorf("POST error class = %s, want %s", failure.Classify(err).Class, failure.AuthError)
+	}
+
+	APIKey = "flag-key"
+	t.Cleanup(func() { APIKey = "" })
+	f = &Fields{}
+	if err := f.EnsureService(); err != nil {
+		t.Fatalf("EnsureService() error = %v", err)
+	}
+	res, err = f.HTTPClient().Get(server.URL + "/youtube/v3/videos?part=id")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	_ = res.Body.Close()
+	if gotKey != "flag-key" {
+		t.Errorf("key = %q, want flag-key", gotKey)
+	}
+}
+
+func TestWrapClient(t *testing.T) {
+	old := transports
+	t.Cleanup(func() { transports = old })
+	transports = nil
+
+	client := &http.Client{}
+	if wrapClient(client, "") != client {
+		t.Error("wrapClient() without transports should return the client")
+	}
+
+	var order []string
+	for _, name := range []string{"inner", "outer"} {
+		RegisterTransport(func(base http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name+":"+Identity(r))
+				return base.RoundTrip(r)
+			})
+		})
+	}
+	wrapped := wrapClient(client, "me")
+	if wrapped == client || client.Transport != nil {
+		t.Fatal("wrapClient() should not modify the client")
+	}
+	_, _ = wrapped.Transport.RoundTrip(
+		&http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "http", Host: "127.0.0.1:0"}},
+	)
+	if strings.Join(order, ",") != "outer:me,inner:me" {
+		t.Errorf("order = %v, want outer:me,inner:me", order)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
 func TestTokenInfoFromContext_Nil(t *testing.T) {
 	info := sdkauth.TokenInfoFromContext(context.Background())
 	if info != nil {
@@ -409,3 +505,102 @@ func TestWithOnBehalfOfContentOwner(t *testing.T) {
 		t.Errorf("WithOnBehalfOfContentOwner() = %q, want %q", r.OnBehalfOfContentOwner, "owner-456")
 	}
 }
+
+func TestPaginateSeq_StopEarly(t *testing.T) {
+	requests := 0
+	svc := NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(
+					w, `{"items": [{"id": "v%d"}, {"id": "w%d"}], "nextPageToken": "p%d"}`,
+					requests, requests, requests,
+				)
+			},
+		),
+	)
+	f := &Fields{Service: svc, MaxResults: math.MaxInt64}
+	seq := PaginateSeq(f, svc.Videos.List([]string{"id"}), videoExtract, errors.New("list"))
+
+	var got []string
+	for video, err := range seq {
+		if err != nil {
+			t.Fatalf("PaginateSeq() error = %v", err)
+		}
+		got = append(got, video.Id)
+		if len(got) == 3 {
+			break
+		}
+	}
+	if want := []string{"v1", "w1", "v2"}; !slices.Equal(got, want) {
+		t.Errorf("PaginateSeq() = %v, want %v", got, want)
+	}
+	if requests != 2 {
+		t.Errorf("PaginateSeq() made %d requests, want 2", requests)
+	}
+}
+
+func TestPaginateSeq_PageToken(t *testing.T) {
+	var tokens []string
+	svc := NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				tokens = append(tokens, r.URL.Query().Get("pageToken"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(
+					w, `{"items": [{"id": "v%d"}], "nextPageToken": "p%d", "pageInfo": {"totalResults": 42}}`,
+					len(tokens), len(tokens)+1,
+				)
+			},
+		),
+	)
+	page := &Page{}
+	f := &Fields{
+		Service: svc, MaxResults: 2 * 20, PageToken: "p5",
+		Ctx: CtxWithPage(context.Background(), page),
+	}
+	got, err := Paginate(f, svc.Videos.List([]string{"id"}), videoExtract, errors.New("list"))
+	if err != nil || len(got) != 2 {
+		t.Fatalf("Paginate() = %v, %v", got, err)
+	}
+	if want := []string{"p5", "p2"}; !slices.Equal(tokens, want) {
+		t.Errorf("page tokens = %v, want %v", tokens, want)
+	}
+	want := Page{NextPageToken: "p3", TotalResults: 42}
+	if f.Page != want || *page != want {
+		t.Errorf("Page = %+v and %+v, want %+v", f.Page, *page, want)
+	}
+}
+
+func TestPaginateSeq_StoppedInPage(t *testing.T) {
+	svc := NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(
+					[]byte(`{"items": [{"id": "v1"}, {"id": "v2"}], "nextPageToken": "p6"}`),
+				)
+			},
+		),
+	)
+	f := &Fields{Service: svc, MaxResults: 2, PageToken: "p5"}
+	seq := PaginateSeq(f, svc.Videos.List([]string{"id"}), videoExtract, errors.New("list"))
+	for _, err := range seq {
+		if err != nil {
+			t.Fatalf("PaginateSeq() error = %v", err)
+		}
+		break
+	}
+	if f.Page.NextPageToken != "p5" {
+		t.Errorf("NextPageToken = %q, want the partly used page p5", f.Page.NextPageToken)
+	}
+
+	seq = PaginateSeq(f, svc.Videos.List([]string{"id"}), videoExtract, errors.New("list"))
+	if _, err := Collect(seq); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if f.Page.NextPageToken != "p6" {
+		t.Errorf("NextPageToken = %q, want p6", f.Page.NextPageToken)
+	}
+}
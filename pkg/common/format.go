@@ -0,0 +1,561 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+var (
+	errUnknownFormat = errors.New("unknown output format")
+	errNoTemplate    = errors.New("output template is empty, use template='{{.Id}}'")
+	errBadTemplate   = errors.New("failed to parse output template")
+	errPrintOutput   = errors.New("failed to print output")
+)
+
+// List is what a Formatter prints: the items, and the header and rows of
+// the table describing them, which --columns replaces with field paths.
+type List struct {
+	Items  []any
+	Header table.Row
+	row    func(int) table.Row
+}
+
+// Rows returns the table rows, one per item. They are only built when a
+// format asks for them, as row functions may expect parts json does not.
+func (l *List) Rows() []table.Row {
+	rows := make([]table.Row, len(l.Items))
+	for i := range l.Items {
+		rows[i] = l.row(i)
+	}
+	return rows
+}
+
+// Formatter prints a list. arg is what follows '=' in the output, such as
+// the template of template='{{.Id}}'.
+type Formatter func(w io.Writer, list *List, arg string) error
+
+// Stream prints a list item by item, as the items arrive.
+type Stream interface {
+	// Item prints item, whose table row is built by row.
+	Item(item any, row func() table.Row) error
+	// Close prints what is left after the last item.
+	Close() error
+}
+
+// StreamFormatter starts a Stream printing to w. header and arg are as
+// for a Formatter.
+type StreamFormatter func(w io.Writer, header table.Row, arg string) (Stream, error)
+
+var (
+	formatters = map[string]Formatter{}
+	streams    = map[string]StreamFormatter{}
+	formats    []string
+)
+
+// RegisterFormat makes name an output format of every list command.
+func RegisterFormat(name string, f Formatter) {
+	if _, ok := formatters[name]; !ok {
+		formats = append(formats, name)
+	}
+	formatters[name] = f
+}
+
+// RegisterStream lets PrintSeq print the output format name, registered
+// with RegisterFormat, as the items arrive rather than after the last.
+func RegisterStream(name string, s StreamFormatter) {
+	streams[name] = s
+}
+
+// registerStreamed registers start both as a Stream and as a Formatter.
+func registerStreamed(name string, start StreamFormatter) {
+	RegisterFormat(name, streamed(start))
+	RegisterStream(name, start)
+}
+
+// streamed prints a whole list with a StreamFormatter.
+func streamed(start StreamFormatter) Formatter {
+	return func(w io.Writer, list *List, arg string) error {
+		stream, err := start(w, list.Header, arg)
+		if err != nil {
+			return err
+		}
+		for i, item := range list.Items {
+			if err := stream.Item(item, func() table.Row { return list.row(i) }); err != nil {
+				return err
+			}
+		}
+		return stream.Close()
+	}
+}
+
+// Formats returns the names of the registered output formats.
+func Formats() []string {
+	return slices.Clone(formats)
+}
+
+// ListUsage describes the output flag of list commands, one of Formats.
+func ListUsage() string {
+	names := Formats()
+	i := slices.Index(names, "template")
+	if i < 0 {
+		return strings.Join(names, "|")
+	}
+	names[i] = "template=TEMPLATE"
+	return strings.Join(names, "|") + ", a Go template such as template='{{.Id}}'"
+}
+
+// OutputPattern matches the output argument of list tools: one of Formats,
+// followed by '=' and what it takes, such as a template.
+func OutputPattern() string {
+	names := Formats()
+	for i, name := range names {
+		names[i] = regexp.QuoteMeta(name)
+	}
+	return `^(` + strings.Join(names, "|") + `)(=[\s\S]*)?$`
+}
+
+// CheckOutput returns an InvalidArgument error for an output that is
+// neither silent nor a registered format, or whose template does not parse.
+func CheckOutput(output string) error {
+	name, arg, _ := strings.Cut(output, "=")
+	if name == "" || name == "silent" {
+		return nil
+	}
+	if _, ok := formatters[name]; !ok {
+		return failure.New(
+			failure.InvalidArgument, fmt.Errorf(
+				"%w: %s, use one of %s", errUnknownFormat, name,
+				strings.Join(formats, "|"),
+			),
+		)
+	}
+	if name == "template" {
+		if _, err := parseTemplate(arg); err != nil {
+			return failure.New(failure.InvalidArgument, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	RegisterFormat(
+		"json", func(w io.Writer, list *List, _ string) error {
+			utils.PrintJSON(list.Items, w)
+			return nil
+		},
+	)
+	RegisterFormat(
+		"yaml", func(w io.Writer, list *List, _ string) error {
+			utils.PrintYAML(list.Items, w)
+			return nil
+		},
+	)
+	RegisterFormat(
+		"table", func(w io.Writer, list *List, _ string) error {
+			tb := table.NewWriter()
+			tb.SetOutputMirror(w)
+			tb.SetStyle(pkg.TableStyle)
+			tb.AppendHeader(list.Header)
+			tb.AppendRows(list.Rows())
+			tb.Render()
+			return nil
+		},
+	)
+	RegisterStream(
+		"table", func(w io.Writer, header table.Row, _ string) (Stream, error) {
+			return &tableStream{w: w, header: header}, nil
+		},
+	)
+	registerStreamed("csv", delimited(','))
+	registerStreamed("tsv", delimited('\t'))
+	registerStreamed("ndjson", startNDJSON)
+	registerStreamed("template", startTemplate)
+}
+
+// delimited prints the header and rows separated by comma, quoting cells
+// as RFC 4180 asks.
+func delimited(comma rune) StreamFormatter {
+	return func(w io.Writer, header table.Row, _ string) (Stream, error) {
+		cw := csv.NewWriter(w)
+		cw.Comma = comma
+		stream := &delimitedStream{cw}
+		if err := stream.write(header); err != nil {
+			return nil, err
+		}
+		return stream, nil
+	}
+}
+
+type delimitedStream struct {
+	cw *csv.Writer
+}
+
+func (s *delimitedStream) Item(_ any, row func() table.Row) error {
+	return s.write(row())
+}
+
+func (s *delimitedStream) Close() error {
+	return nil
+}
+
+func (s *delimitedStream) write(row table.Row) error {
+	if err := s.cw.Write(cells(row)); err != nil {
+		return err
+	}
+	s.cw.Flush()
+	return s.cw.Error()
+}
+
+type ndjsonStream struct {
+	enc *json.Encoder
+}
+
+func startNDJSON(w io.Writer, _ table.Row, _ string) (Stream, error) {
+	return &ndjsonStream{json.NewEncoder(w)}, nil
+}
+
+func (s *ndjsonStream) Item(item any, _ func() table.Row) error {
+	return s.enc.Encode(item)
+}
+
+func (s *ndjsonStream) Close() error {
+	return nil
+}
+
+type templateStream struct {
+	w       io.Writer
+	tmpl    *template.Template
+	newline bool
+}
+
+func startTemplate(w io.Writer, _ table.Row, arg string) (Stream, error) {
+	tmpl, err := parseTemplate(arg)
+	if err != nil {
+		return nil, err
+	}
+	return &templateStream{w, tmpl, !strings.HasSuffix(arg, "\n")}, nil
+}
+
+func (s *templateStream) Item(item any, _ func() table.Row) error {
+	if err := s.tmpl.Execute(s.w, item); err != nil {
+		var execErr template.ExecError
+		if errors.As(err, &execErr) {
+			return failure.New(failure.InvalidArgument, err)
+		}
+		return err
+	}
+	if s.newline {
+		_, err := io.WriteString(s.w, "\n")
+		return err
+	}
+	return nil
+}
+
+func (s *templateStream) Close() error {
+	return nil
+}
+
+// tableStream renders the rows a page at a time, the header with the
+// first. Columns keep at least the widths of the first page, so pages line
+// up unless a later cell is wider.
+type tableStream struct {
+	w       io.Writer
+	header  table.Row
+	rows    []table.Row
+	widths  []table.ColumnConfig
+	started bool
+}
+
+func (s *tableStream) Item(_ any, row func() table.Row) error {
+	s.rows = append(s.rows, row())
+	if len(s.rows) >= pkg.PerPage {
+		s.flush()
+	}
+	return nil
+}
+
+func (s *tableStream) Close() error {
+	if len(s.rows) > 0 || !s.started {
+		s.flush()
+	}
+	return nil
+}
+
+func (s *tableStream) flush() {
+	tb := table.NewWriter()
+	tb.SetOutputMirror(s.w)
+	tb.SetStyle(pkg.TableStyle)
+	if !s.started {
+		s.started = true
+		tb.AppendHeader(s.header)
+		for i := range s.header {
+			width := text.LongestLineLen(fmt.Sprint(s.header[i]))
+			for _, row := range s.rows {
+				if i < len(row) {
+					width = max(width, text.LongestLineLen(fmt.Sprint(row[i])))
+				}
+			}
+			s.widths = append(s.widths, table.ColumnConfig{Number: i + 1, WidthMin: width})
+		}
+	}
+	tb.SetColumnConfigs(s.widths)
+	tb.AppendRows(s.rows)
+	tb.Render()
+	s.rows = s.rows[:0]
+}
+
+func cells(row table.Row) []string {
+	record := make([]string, len(row))
+	for i, cell := range row {
+		record[i] = fmt.Sprint(cell)
+	}
+	return record
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, errNoTemplate
+	}
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, errors.Join(errBadTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// PrintList prints the items matching f.Where, sorted by f.SortBy, in the
+// output format of f. The header and row function describe the table, csv
+// and tsv columns, unless f.Columns names field paths to print instead.
+// It returns what kept the list from printing, such as a template that does
+// not fit the items.
+func PrintList[T any](
+	f *Fields, items []*T, w io.Writer, header table.Row, row func(*T) table.Row,
+) error {
+	name, arg, _ := strings.Cut(f.Output, "=")
+	format, ok := formatters[name]
+	if !ok {
+		return nil
+	}
+	items, err := refine(f, items)
+	if err != nil {
+		return err
+	}
+
+	header, row = columns(f, header, row)
+	list := &List{Header: header}
+	if items != nil {
+		list.Items = make([]any, len(items))
+		for i, item := range items {
+			list.Items[i] = item
+		}
+	}
+	list.row = func(i int) table.Row {
+		return row(items[i])
+	}
+	if err := format(w, list, arg); err != nil {
+		return errors.Join(errPrintOutput, err)
+	}
+	return nil
+}
+
+// Envelope wraps the items of a paged list in json and yaml output with
+// where the list stopped, when f.Envelope asks for it.
+type Envelope[T any] struct {
+	Items         []*T   `yaml:"items" json:"items"`
+	NextPageToken string `yaml:"nextPageToken,omitempty" json:"nextPageToken,omitempty"`
+	TotalResults  int64  `yaml:"totalResults,omitempty" json:"totalResults,omitempty"`
+}
+
+// PrintSeq prints the items of seq as PrintList does, each as it arrives
+// in the formats registered with RegisterStream. Other formats, and
+// f.SortBy, wait for the last item. It returns the error ending seq, after
+// printing the items before it, and stops reading seq once printing fails.
+// Where a paged list stopped, f.Page, is printed in an Envelope when
+// f.Envelope asks for one in json or yaml output, and logged otherwise.
+func PrintSeq[T any](
+	f *Fields, seq iter.Seq2[*T, error], w io.Writer, header table.Row,
+	row func(*T) table.Row,
+) error {
+	if f.Envelope && (f.Output == "json" || f.Output == "yaml") {
+		return printEnvelope(f, seq, w)
+	}
+	err := printSeq(f, seq, w, header, row)
+	if f.Page.NextPageToken != "" {
+		slog.Info(
+			"More results are available", "nextPageToken", f.Page.NextPageToken,
+			"totalResults", f.Page.TotalResults,
+		)
+	}
+	return err
+}
+
+func printEnvelope[T any](f *Fields, seq iter.Seq2[*T, error], w io.Writer) error {
+	items, err := Collect(seq)
+	if err != nil && items == nil {
+		return err
+	}
+	refined, refineErr := refine(f, items)
+	if refineErr != nil {
+		return errors.Join(err, refineErr)
+	}
+	envelope := &Envelope[T]{
+		Items:         refined,
+		NextPageToken: f.Page.NextPageToken,
+		TotalResults:  f.Page.TotalResults,
+	}
+	if envelope.Items == nil {
+		envelope.Items = []*T{}
+	}
+	if f.Output == "json" {
+		utils.PrintJSON(envelope, w)
+	} else {
+		utils.PrintYAML(envelope, w)
+	}
+	return err
+}
+
+func printSeq[T any](
+	f *Fields, seq iter.Seq2[*T, error], w io.Writer, header table.Row,
+	row func(*T) table.Row,
+) error {
+	name, arg, _ := strings.Cut(f.Output, "=")
+	start, ok := streams[name]
+	if !ok || f.SortBy != "" {
+		items, err := Collect(seq)
+		if err != nil && items == nil {
+			return err
+		}
+		return errors.Join(err, PrintList(f, items, w, header, row))
+	}
+	match, err := whereOf(f)
+	if err != nil {
+		return err
+	}
+
+	header, row = columns(f, header, row)
+	var stream Stream
+	for item, seqErr := range seq {
+		if seqErr != nil {
+			if stream != nil {
+				_ = stream.Close()
+			}
+			return seqErr
+		}
+		if f.Where != "" && !match(toDoc(item)) {
+			continue
+		}
+		if stream == nil {
+			if stream, err = start(w, header, arg); err != nil {
+				break
+			}
+		}
+		if err = stream.Item(item, func() table.Row { return row(item) }); err != nil {
+			break
+		}
+	}
+	if err == nil && stream == nil {
+		stream, err = start(w, header, arg)
+	}
+	if err == nil {
+		err = stream.Close()
+	}
+	if err != nil {
+		return errors.Join(errPrintOutput, err)
+	}
+	return nil
+}
+
+// columns returns the header and row function of f.Columns, or else those
+// given. Row functions must allow for items lacking parts, as --parts or a
+// field mask may leave them.
+func columns[T any](
+	f *Fields, header table.Row, row func(*T) table.Row,
+) (table.Row, func(*T) table.Row) {
+	if len(f.Columns) > 0 {
+		header = make(table.Row, len(f.Columns))
+		for i, column := range f.Columns {
+			header[i] = column
+		}
+		return header, func(item *T) table.Row {
+			return columnRow(item, f.Columns)
+		}
+	}
+	return header, row
+}
+
+// columnRow looks up each column, a dot separated path of JSON field names
+// such as snippet.title, in item. Names match case-insensitively, and
+// indexes select array elements.
+func columnRow(item any, columns []string) table.Row {
+	doc := toDoc(item)
+	row := make(table.Row, len(columns))
+	for i, column := range columns {
+		row[i] = cellString(lookup(doc, column))
+	}
+	return row
+}
+
+func lookup(doc any, path string) any {
+	for key := range strings.SplitSeq(path, ".") {
+		switch v := doc.(type) {
+		case map[string]any:
+			value, ok := v[key]
+			if !ok {
+				for k, kv := range v {
+					if strings.EqualFold(k, key) {
+						value, ok = kv, true
+						break
+					}
+				}
+			}
+			if !ok {
+				return nil
+			}
+			doc = value
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			doc = v[i]
+		default:
+			return nil
+		}
+	}
+	return doc
+}
+
+func cellString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
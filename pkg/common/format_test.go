@@ -0,0 +1,316 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestPrintList_Formats(t *testing.T) {
+	items := []*youtube.Video{
+		{
+			Id: "v1", Snippet: &youtube.VideoSnippet{
+				Title: "Hello, world", Tags: []string{"a", "b"},
+			},
+			Statistics: &youtube.VideoStatistics{ViewCount: 42},
+		},
+		{Id: "v2"},
+	}
+	header := table.Row{"ID", "Title"}
+	rowFn := func(v *youtube.Video) table.Row {
+		title := ""
+		if v.Snippet != nil {
+			title = v.Snippet.Title
+		}
+		return table.Row{v.Id, title}
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		columns []string
+		want    string
+	}{
+		{
+			name: "csv", output: "csv",
+			want: "ID,Title\nv1,\"Hello, world\"\nv2,\n",
+		},
+		{
+			name: "tsv", output: "tsv",
+			want: "ID\tTitle\nv1\tHello, world\nv2\t\n",
+		},
+		{
+			name: "csv columns", output: "csv",
+			columns: []string{"id", "Snippet.Title", "snippet.tags.1", "statistics.viewCount", "snippet.tags"},
+			want:    "id,Snippet.Title,snippet.tags.1,statistics.viewCount,snippet.tags\nv1,\"Hello, world\",b,42,\"[\"\"a\"\",\"\"b\"\"]\"\nv2,,,,\n",
+		},
+		{
+			name: "ndjson", output: "ndjson",
+			want: `{"id":"v1","snippet":{"tags":["a","b"],"title":"Hello, world"},"statistics":{"viewCount":"42"}}` +
+				"\n" + `{"id":"v2"}` + "\n",
+		},
+		{
+			name: "template", output: "template={{.Id}} {{len .Id}}",
+			want: "v1 2\nv2 2\n",
+		},
+		{name: "unknown", output: "xml", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				f := &Fields{Output: tt.output, Columns: tt.columns}
+				PrintList(f, items, &buf, header, rowFn)
+				if buf.String() != tt.want {
+					t.Errorf("PrintList(%q) = %q, want %q", tt.output, buf.String(), tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestPrintList_LazyRows(t *testing.T) {
+	items := []*youtube.Video{{Id: "v1"}}
+	rowFn := func(v *youtube.Video) table.Row {
+		return table.Row{v.Snippet.Title}
+	}
+	var buf bytes.Buffer
+	PrintList(&Fields{Output: "json"}, items, &buf, table.Row{"Title"}, rowFn)
+	if buf.Len() == 0 {
+		t.Error("PrintList(json) produced empty output")
+	}
+}
+
+func TestPrintList_TemplateError(t *testing.T) {
+	items := []*youtube.Video{{Id: "v1"}}
+	rowFn := func(v *youtube.Video) table.Row { return table.Row{v.Id} }
+	f := &Fields{Output: "template={{.Nope}}"}
+
+	var buf bytes.Buffer
+	err := PrintList(f, items, &buf, table.Row{"ID"}, rowFn)
+	if !errors.Is(err, errPrintOutput) || failure.Classify(err).Class != failure.InvalidArgument {
+		t.Errorf("PrintList() error = %v, want invalid argument", err)
+	}
+	seq := func(yield func(*youtube.Video, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+	err = PrintSeq(f, seq, &buf, table.Row{"ID"}, rowFn)
+	if !errors.Is(err, errPrintOutput) || failure.Classify(err).Class != failure.InvalidArgument {
+		t.Errorf("PrintSeq() error = %v, want invalid argument", err)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat(
+		"count", func(w io.Writer, list *List, _ string) error {
+			_, err := fmt.Fprintln(w, len(list.Items))
+			return err
+		},
+	)
+	t.Cleanup(
+		func() {
+			delete(formatters, "count")
+			formats = formats[:len(formats)-1]
+		},
+	)
+
+	var buf bytes.Buffer
+	PrintList(
+		&Fields{Output: "count"}, []*youtube.Video{{}, {}}, &buf, nil,
+		func(*youtube.Video) table.Row { return nil },
+	)
+	if buf.String() != "2\n" {
+		t.Errorf("PrintList(count) = %q, want 2", buf.String())
+	}
+	if err := CheckOutput("count"); err != nil {
+		t.Errorf("CheckOutput(count) error = %v", err)
+	}
+}
+
+func TestListUsage(t *testing.T) {
+	want := "json|yaml|table|csv|tsv|ndjson|template=TEMPLATE, a Go template such as template='{{.Id}}'"
+	if got := ListUsage(); got != want {
+		t.Errorf("ListUsage() = %q, want %q", got, want)
+	}
+
+	pattern := regexp.MustCompile(OutputPattern())
+	for output, want := range map[string]bool{
+		"json": true, "ndjson": true, "template={{.Id}}\n": true,
+		"xml": false, "jsonx": false, "": false,
+	} {
+		if got := pattern.MatchString(output); got != want {
+			t.Errorf("OutputPattern() matches %q = %v, want %v", output, got, want)
+		}
+	}
+}
+
+func TestCheckOutput(t *testing.T) {
+	tests := []struct {
+		output  string
+		wantErr bool
+	}{
+		{output: ""},
+		{output: "silent"},
+		{output: "table"},
+		{output: "ndjson"},
+		{output: "template={{.Id}}"},
+		{output: "xml", wantErr: true},
+		{output: "template", wantErr: true},
+		{output: "template={{.Id", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := CheckOutput(tt.output)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckOutput(%q) error = %v, wantErr %v", tt.output, err, tt.wantErr)
+		}
+		if err != nil && failure.Classify(err).Class != failure.InvalidArgument {
+			t.Errorf("CheckOutput(%q) error = %v, want invalid argument", tt.output, err)
+		}
+	}
+}
+
+func TestPrintSeq(t *testing.T) {
+	rowFn := func(v *youtube.Video) table.Row { return table.Row{v.Id} }
+	errList := errors.New("list failed")
+
+	tests := []struct {
+		name   string
+		fields Fields
+		items  []string
+		err    error
+		want   string
+	}{
+		{name: "csv", fields: Fields{Output: "csv"}, items: []string{"v1", "v2"}, want: "ID\nv1\nv2\n"},
+		{name: "empty csv", fields: Fields{Output: "csv"}, want: "ID\n"},
+		{
+			name: "where", fields: Fields{Output: "ndjson", Where: `id != "v1"`},
+			items: []string{"v1", "v2"}, want: `{"id":"v2"}` + "\n",
+		},
+		{
+			name: "sort", fields: Fields{Output: "csv", SortBy: "id:desc"},
+			items: []string{"v1", "v2"}, want: "ID\nv2\nv1\n",
+		},
+		{
+			name: "error", fields: Fields{Output: "csv"}, items: []string{"v1"},
+			err: errList, want: "ID\nv1\n",
+		},
+		{name: "error first", fields: Fields{Output: "csv"}, err: errList, want: ""},
+		{name: "json", fields: Fields{Output: "json"}, items: []string{"v1"}, want: `[{"id":"v1"}]` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				seq := func(yield func(*youtube.Video, error) bool) {
+					for _, id := range tt.items {
+						if !yield(&youtube.Video{Id: id}, nil) {
+							return
+						}
+					}
+					if tt.err != nil {
+						yield(nil, tt.err)
+					}
+				}
+				var buf bytes.Buffer
+				err := PrintSeq(&tt.fields, seq, &buf, table.Row{"ID"}, rowFn)
+				if !errors.Is(err, tt.err) {
+					t.Errorf("PrintSeq() error = %v, want %v", err, tt.err)
+				}
+				if buf.String() != tt.want {
+					t.Errorf("PrintSeq() = %q, want %q", buf.String(), tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestPrintSeq_Incremental(t *testing.T) {
+	for _, output := range []string{"ndjson", "csv", "template={{.Id}}"} {
+		var buf bytes.Buffer
+		seq := func(yield func(*youtube.Video, error) bool) {
+			for i := range 3 {
+				if i > 0 && !strings.Contains(buf.String(), fmt.Sprintf("v%d", i-1)) {
+					t.Errorf("PrintSeq(%s) had not printed v%d before the next item", output, i-1)
+				}
+				if !yield(&youtube.Video{Id: fmt.Sprintf("v%d", i)}, nil) {
+					return
+				}
+			}
+		}
+		err := PrintSeq(
+			&Fields{Output: output}, seq, &buf, table.Row{"ID"},
+			func(v *youtube.Video) table.Row { return table.Row{v.Id} },
+		)
+		if err != nil {
+			t.Errorf("PrintSeq(%s) error = %v", output, err)
+		}
+	}
+}
+
+func TestPrintSeq_Table(t *testing.T) {
+	var items []*youtube.Video
+	for i := range pkg.PerPage + 2 {
+		items = append(items, &youtube.Video{Id: fmt.Sprintf("video-%02d", i)})
+	}
+	rowFn := func(v *youtube.Video) table.Row { return table.Row{v.Id, "x"} }
+
+	var streamed, whole bytes.Buffer
+	seq := func(yield func(*youtube.Video, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+	err := PrintSeq(&Fields{Output: "table"}, seq, &streamed, table.Row{"ID", "Title"}, rowFn)
+	if err != nil {
+		t.Fatalf("PrintSeq() error = %v", err)
+	}
+	PrintList(&Fields{Output: "table"}, items, &whole, table.Row{"ID", "Title"}, rowFn)
+	if streamed.String() != whole.String() {
+		t.Errorf("PrintSeq(table) =\n%s\nwant\n%s", streamed.String(), whole.String())
+	}
+}
+
+func TestPrintSeq_Envelope(t *testing.T) {
+	seq := func(yield func(*youtube.Video, error) bool) {
+		yield(&youtube.Video{Id: "v1"}, nil)
+	}
+	rowFn := func(v *youtube.Video) table.Row { return table.Row{v.Id} }
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{output: "json", want: `{"items":[{"id":"v1"}],"nextPageToken":"p2","totalResults":7}` + "\n"},
+		{output: "yaml", want: "nextPageToken: p2\ntotalResults: 7\n\n"},
+		{output: "csv", want: "ID\nv1\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		f := &Fields{
+			Output: tt.output, Envelope: true,
+			Page: Page{NextPageToken: "p2", TotalResults: 7},
+		}
+		if err := PrintSeq(f, seq, &buf, table.Row{"ID"}, rowFn); err != nil {
+			t.Errorf("PrintSeq(%s) error = %v", tt.output, err)
+		}
+		if !strings.HasSuffix(buf.String(), tt.want) || !strings.Contains(buf.String(), "v1") {
+			t.Errorf("PrintSeq(%s) = %q, want %q", tt.output, buf.String(), tt.want)
+		}
+	}
+}
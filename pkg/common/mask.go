@@ -0,0 +1,86 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"google.golang.org/api/googleapi"
+)
+
+var (
+	errBadFields  = errors.New("failed to parse fields")
+	errFieldsPart = errors.New("fields must be within the requested parts")
+)
+
+// maskAlways are the item fields every part comes with.
+var maskAlways = []string{"id", "kind", "etag"}
+
+// Masker is satisfied by all YouTube API *XxxListCall types.
+type Masker[C any] interface {
+	Fields(s ...googleapi.Field) C
+}
+
+// ApplyMask asks call for a partial response holding only f.FieldMask of
+// each item, and the top level fields given, such as nextPageToken. The
+// mask takes the syntax of the API, id,snippet(title,channelId), with '.'
+// as well as '/' between names, and may only name the parts of f. call is
+// returned as is when there is no mask.
+func ApplyMask[C Masker[C]](f *Fields, call C, top ...string) (C, error) {
+	if f.FieldMask == "" {
+		return call, nil
+	}
+	mask, err := itemMask(f.FieldMask, f.Parts)
+	if err != nil {
+		return call, failure.New(failure.InvalidArgument, err)
+	}
+	fields := append([]string{fmt.Sprintf("items(%s)", mask)}, top...)
+	return call.Fields(googleapi.Field(strings.Join(fields, ","))), nil
+}
+
+// itemMask returns mask in the API syntax after checking that each of its
+// top level names is a part.
+func itemMask(mask string, parts []string) (string, error) {
+	mask = strings.ReplaceAll(strings.Join(strings.Fields(mask), ""), ".", "/")
+	depth, start := 0, 0
+	for i := 0; i <= len(mask); i++ {
+		if i < len(mask) {
+			switch mask[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				if depth--; depth < 0 {
+					return "", fmt.Errorf("%w: unmatched ) in %s", errBadFields, mask)
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		} else if depth != 0 {
+			return "", fmt.Errorf("%w: unmatched ( in %s", errBadFields, mask)
+		}
+		field := mask[start:i]
+		start = i + 1
+		name, _, _ := strings.Cut(field, "/")
+		name, _, _ = strings.Cut(name, "(")
+		if name == "" {
+			return "", fmt.Errorf("%w: empty field in %s", errBadFields, mask)
+		}
+		if !slices.Contains(maskAlways, name) && !slices.Contains(parts, name) {
+			return "", fmt.Errorf(
+				"%w: %s is not in %s", errFieldsPart, name, strings.Join(parts, ","),
+			)
+		}
+	}
+	return mask, nil
+}
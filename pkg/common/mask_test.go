@@ -0,0 +1,74 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+)
+
+func TestItemMask(t *testing.T) {
+	parts := []string{"id", "snippet", "statistics"}
+	tests := []struct {
+		mask    string
+		want    string
+		wantErr error
+	}{
+		{mask: "id", want: "id"},
+		{mask: "id, snippet.title", want: "id,snippet/title"},
+		{mask: "snippet(title,thumbnails/default/url),etag", want: "snippet(title,thumbnails/default/url),etag"},
+		{mask: "statistics/viewCount,kind", want: "statistics/viewCount,kind"},
+		{mask: "contentDetails.duration", wantErr: errFieldsPart},
+		{mask: "id,,snippet", wantErr: errBadFields},
+		{mask: "snippet(title", wantErr: errBadFields},
+		{mask: "snippet)title(", wantErr: errBadFields},
+	}
+	for _, tt := range tests {
+		got, err := itemMask(tt.mask, parts)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("itemMask(%q) error = %v, want %v", tt.mask, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("itemMask(%q) = %q, want %q", tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestPaginate_FieldMask(t *testing.T) {
+	var fields []string
+	svc := NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				fields = append(fields, r.URL.Query().Get("fields"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, `{"items": [{"id": "v1"}]}`)
+			},
+		),
+	)
+
+	f := &Fields{
+		Service: svc, MaxResults: 5, Parts: []string{"id", "snippet"},
+		FieldMask: "id,snippet.title",
+	}
+	got, err := Paginate(f, svc.Videos.List(f.Parts), videoExtract, errors.New("list"))
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Paginate() = %v, %v", got, err)
+	}
+	if want := "items(id,snippet/title),nextPageToken,pageInfo"; len(fields) != 1 || fields[0] != want {
+		t.Errorf("fields = %q, want %q", fields, want)
+	}
+
+	f.FieldMask = "statistics"
+	_, err = Paginate(f, svc.Videos.List(f.Parts), videoExtract, errors.New("list"))
+	if failure.Classify(err).Class != failure.InvalidArgument {
+		t.Errorf("Paginate() error = %v, want invalid argument", err)
+	}
+	if len(fields) != 1 {
+		t.Errorf("Paginate() called the API with a bad mask")
+	}
+}
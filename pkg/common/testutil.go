@@ -10,13 +10,37 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/eat-pray-ai/yutu/pkg"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
+// SetTestRoot points pkg.Root and pkg.RootDir at a fresh temporary directory
+// until the test ends and returns it. YUTU_CACHE_TOKEN is unset, so files
+// kept next to the token cache land at the top of it.
+func SetTestRoot(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatalf("failed to open root: %v", err)
+	}
+	oldRoot, oldRootDir := pkg.Root, pkg.RootDir
+	pkg.Root, pkg.RootDir = root, &dir
+	t.Setenv("YUTU_CACHE_TOKEN", "")
+	t.Cleanup(
+		func() {
+			pkg.Root, pkg.RootDir = oldRoot, oldRootDir
+			_ = root.Close()
+		},
+	)
+	return dir
+}
+
 // NewTestService creates a youtube.Service backed by the given handler for testing.
 // It registers cleanup of the test server automatically.
 func NewTestService(t *testing.T, handler http.Handler) *youtube.Service {
@@ -70,8 +94,9 @@ func PaginationHandler(idPrefix string, itemFmt ...func(string, int) string) htt
 	}
 }
 
-// RunListTest runs the standard json/yaml/table output test matrix.
-// mockResponse is the JSON the mock server returns.
+// RunListTest runs the standard json/yaml/table output test matrix, and
+// prints a table of an item lacking all parts, as --parts or --fields may
+// leave it. mockResponse is the JSON the mock server returns.
 // listFn receives a *youtube.Service and output format, returns a function
 // that calls List on the resource with a writer.
 func RunListTest(
@@ -102,4 +127,20 @@ func RunListTest(
 			},
 		)
 	}
+
+	bare := NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"items": [{}]}`))
+			},
+		),
+	)
+	t.Run(
+		"list missing parts", func(t *testing.T) {
+			if err := listFn(bare, "table")(&bytes.Buffer{}); err != nil {
+				t.Errorf("List(table) error = %v", err)
+			}
+		},
+	)
 }
@@ -0,0 +1,420 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+)
+
+var (
+	errBadWhere  = errors.New("failed to parse where expression")
+	errBadSortBy = errors.New("sort by must be field[:asc|desc], comma separated")
+	errEnvelope  = errors.New("envelope needs json or yaml output")
+)
+
+// predicate reports whether the JSON document of an item matches.
+type predicate func(doc any) bool
+
+// compareOps are the operators of a comparison, longest first so that >=
+// is not read as >.
+var compareOps = []string{
+	"==", "!=", ">=", "<=", ">", "<",
+	"contains", "startsWith", "endsWith", "matches",
+}
+
+// parseWhere compiles an expression such as
+//
+//	statistics.viewCount > 1000 && snippet.title contains "Go"
+//
+// Comparisons take a field path, as --columns does, an operator of
+// compareOps, and a number, a quoted string, true, false or null. They
+// combine with &&, ||, ! and parentheses. Numbers, and ISO 8601 durations
+// such as PT1M30S as seconds, compare as numbers, the rest as strings.
+func parseWhere(expr string) (predicate, error) {
+	p := &whereParser{src: expr}
+	pred, err := p.or()
+	if err == nil && p.skipSpace() < len(p.src) {
+		err = fmt.Errorf("unexpected %q", p.src[p.pos:])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errBadWhere, err)
+	}
+	return pred, nil
+}
+
+type whereParser struct {
+	src string
+	pos int
+}
+
+func (p *whereParser) skipSpace() int {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.pos
+}
+
+// accept consumes token if it comes next.
+func (p *whereParser) accept(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *whereParser) or() (predicate, error) {
+	left, err := p.and()
+	for err == nil && p.accept("||") {
+		var right predicate
+		right, err = p.and()
+		l, r := left, right
+		left = func(doc any) bool { return l(doc) || r(doc) }
+	}
+	return left, err
+}
+
+func (p *whereParser) and() (predicate, error) {
+	left, err := p.unary()
+	for err == nil && p.accept("&&") {
+		var right predicate
+		right, err = p.unary()
+		l, r := left, right
+		left = func(doc any) bool { return l(doc) && r(doc) }
+	}
+	return left, err
+}
+
+func (p *whereParser) unary() (predicate, error) {
+	switch {
+	case p.accept("!") && !p.accept("="):
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(doc any) bool { return !inner(doc) }, nil
+	case p.accept("("):
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing ) at %d", p.pos)
+		}
+		return inner, nil
+	}
+	return p.comparison()
+}
+
+func (p *whereParser) comparison() (predicate, error) {
+	path := p.word()
+	if path == "" {
+		return nil, fmt.Errorf("expected a field at %d", p.pos)
+	}
+	p.skipSpace()
+	op := ""
+	for _, candidate := range compareOps {
+		if strings.HasPrefix(p.src[p.pos:], candidate) {
+			op = candidate
+			p.pos += len(candidate)
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("expected an operator after %s", path)
+	}
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if op == "matches" {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("matches needs a quoted pattern")
+		}
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, err
+		}
+		return func(doc any) bool {
+			v := lookup(doc, path)
+			return v != nil && re.MatchString(cellString(v))
+		}, nil
+	}
+	return func(doc any) bool {
+		return compare(lookup(doc, path), op, value)
+	}, nil
+}
+
+// word reads a field path or a bare literal.
+func (p *whereParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := rune(p.src[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && !strings.ContainsRune("_.-", c) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *whereParser) value() (any, error) {
+	p.skipSpace()
+	if p.pos < len(p.src) && (p.src[p.pos] == '"' || p.src[p.pos] == '\'') {
+		quote := p.src[p.pos]
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != quote {
+			if p.src[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.src) {
+			return nil, fmt.Errorf("unterminated string at %d", p.pos)
+		}
+		raw := p.src[p.pos : end+1]
+		p.pos = end + 1
+		if quote == '\'' {
+			raw = strconv.Quote(strings.ReplaceAll(raw[1:len(raw)-1], `\'`, `'`))
+		}
+		return strconv.Unquote(raw)
+	}
+
+	word := p.word()
+	switch word {
+	case "":
+		return nil, fmt.Errorf("expected a value at %d", p.pos)
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		return n, nil
+	}
+	return nil, fmt.Errorf("expected a value, got %s, quote strings", word)
+}
+
+func compare(left any, op string, right any) bool {
+	switch op {
+	case "contains":
+		if items, ok := left.([]any); ok {
+			return slices.ContainsFunc(
+				items, func(item any) bool { return cellString(item) == cellString(right) },
+			)
+		}
+		return left != nil && strings.Contains(cellString(left), cellString(right))
+	case "startsWith":
+		return left != nil && strings.HasPrefix(cellString(left), cellString(right))
+	case "endsWith":
+		return left != nil && strings.HasSuffix(cellString(left), cellString(right))
+	}
+
+	if left == nil || right == nil {
+		equal := left == nil && right == nil
+		return op == "==" && equal || op == "!=" && !equal
+	}
+	c := compareValues(left, right)
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// compareValues orders two values as numbers when both are, else as
+// strings.
+func compareValues(a, b any) int {
+	x, xok := number(a)
+	y, yok := number(b)
+	if xok && yok {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(cellString(a), cellString(b))
+}
+
+// iso8601 matches durations such as PT1H2M3S and P1DT30S.
+var iso8601 = regexp.MustCompile(
+	`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`,
+)
+
+// number reads numbers, numeric strings as the API sends 64 bit counts,
+// and ISO 8601 durations in seconds.
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n, true
+		}
+		m := iso8601.FindStringSubmatch(v)
+		if m == nil || v == "P" || strings.HasSuffix(v, "T") {
+			return 0, false
+		}
+		var seconds float64
+		for i, unit := range []float64{86400, 3600, 60, 1} {
+			n, _ := strconv.ParseFloat(m[i+1], 64)
+			seconds += n * unit
+		}
+		return seconds, true
+	}
+	return 0, false
+}
+
+type sortKey struct {
+	path string
+	desc bool
+}
+
+func parseSortBy(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	for field := range strings.SplitSeq(spec, ",") {
+		path, order, _ := strings.Cut(strings.TrimSpace(field), ":")
+		if path == "" {
+			return nil, errBadSortBy
+		}
+		switch strings.ToLower(order) {
+		case "", "asc":
+			keys = append(keys, sortKey{path: path})
+		case "desc":
+			keys = append(keys, sortKey{path: path, desc: true})
+		default:
+			return nil, fmt.Errorf("%w: %s", errBadSortBy, field)
+		}
+	}
+	return keys, nil
+}
+
+// CheckList returns an InvalidArgument error for an output, envelope,
+// where expression or sort order of f that cannot be used.
+func CheckList(f *Fields) error {
+	if err := CheckOutput(f.Output); err != nil {
+		return err
+	}
+	if f.Envelope && f.Output != "json" && f.Output != "yaml" {
+		return failure.New(failure.InvalidArgument, errEnvelope)
+	}
+	if f.Where != "" {
+		if _, err := parseWhere(f.Where); err != nil {
+			return failure.New(failure.InvalidArgument, err)
+		}
+	}
+	if f.SortBy != "" {
+		if _, err := parseSortBy(f.SortBy); err != nil {
+			return failure.New(failure.InvalidArgument, err)
+		}
+	}
+	return nil
+}
+
+// whereOf returns the predicate of f.Where, which matches every item when
+// there is none.
+func whereOf(f *Fields) (predicate, error) {
+	if f.Where == "" {
+		return func(any) bool { return true }, nil
+	}
+	return parseWhere(f.Where)
+}
+
+// refine keeps the items matching f.Where, sorted by f.SortBy. Items
+// without a sort field come last.
+func refine[T any](f *Fields, items []*T) ([]*T, error) {
+	if f.Where == "" && f.SortBy == "" {
+		return items, nil
+	}
+	match, err := whereOf(f)
+	if err != nil {
+		return nil, err
+	}
+	var keys []sortKey
+	if f.SortBy != "" {
+		if keys, err = parseSortBy(f.SortBy); err != nil {
+			return nil, err
+		}
+	}
+
+	type entry struct {
+		item *T
+		doc  any
+	}
+	var kept []entry
+	for _, item := range items {
+		doc := toDoc(item)
+		if match(doc) {
+			kept = append(kept, entry{item, doc})
+		}
+	}
+	slices.SortStableFunc(
+		kept, func(a, b entry) int {
+			for _, key := range keys {
+				x, y := lookup(a.doc, key.path), lookup(b.doc, key.path)
+				var c int
+				switch {
+				case x == nil && y == nil:
+				case x == nil:
+					return 1
+				case y == nil:
+					return -1
+				default:
+					c = compareValues(x, y)
+				}
+				if key.desc {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		},
+	)
+
+	refined := make([]*T, len(kept))
+	for i, e := range kept {
+		refined[i] = e.item
+	}
+	return refined, nil
+}
+
+// toDoc returns the JSON document of item, keeping numbers as json.Number.
+func toDoc(item any) any {
+	var doc any
+	if data, err := json.Marshal(item); err == nil {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		_ = dec.Decode(&doc)
+	}
+	return doc
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"google.golang.org/api/youtube/v3"
+)
+
+func testVideos() []*youtube.Video {
+	return []*youtube.Video{
+		{
+			Id: "a",
+			Snippet: &youtube.VideoSnippet{
+				Title: "Learn Go", Tags: []string{"go", "tutorial"},
+			},
+			Statistics:     &youtube.VideoStatistics{ViewCount: 5000},
+			ContentDetails: &youtube.VideoContentDetails{Duration: "PT1H2M"},
+		},
+		{
+			Id:             "b",
+			Snippet:        &youtube.VideoSnippet{Title: "Rust tips"},
+			Statistics:     &youtube.VideoStatistics{ViewCount: 20000},
+			ContentDetails: &youtube.VideoContentDetails{Duration: "PT9M30S"},
+		},
+		{
+			Id:             "c",
+			Snippet:        &youtube.VideoSnippet{Title: "Go generics"},
+			Statistics:     &youtube.VideoStatistics{ViewCount: 800},
+			ContentDetails: &youtube.VideoContentDetails{Duration: "PT45S"},
+		},
+		{Id: "d"},
+	}
+}
+
+func ids(videos []*youtube.Video) []string {
+	got := []string{}
+	for _, v := range videos {
+		got = append(got, v.Id)
+	}
+	return got
+}
+
+func TestRefine(t *testing.T) {
+	tests := []struct {
+		name   string
+		where  string
+		sortBy string
+		want   []string
+	}{
+		{
+			name:  "number and contains",
+			where: `statistics.viewCount > 1000 && snippet.title contains "Go"`,
+			want:  []string{"a"},
+		},
+		{
+			name:  "or, not and parentheses",
+			where: `!(snippet.title startsWith 'Go') && (id == "b" || id == "c")`,
+			want:  []string{"b"},
+		},
+		{name: "array contains", where: `snippet.tags contains "go"`, want: []string{"a"}},
+		{name: "duration", where: `contentDetails.duration >= 570`, want: []string{"a", "b"}},
+		{name: "matches", where: `snippet.title matches "(?i)^go"`, want: []string{"c"}},
+		{name: "missing field", where: `snippet == null`, want: []string{"d"}},
+		{
+			name: "sort desc", sortBy: "statistics.viewCount:desc",
+			want: []string{"b", "a", "c", "d"},
+		},
+		{
+			name: "sort duration", sortBy: "contentDetails.duration",
+			want: []string{"c", "b", "a", "d"},
+		},
+		{
+			name: "where and sort", where: "statistics.viewCount < 10000",
+			sortBy: "snippet.title", want: []string{"c", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, err := refine(&Fields{Where: tt.where, SortBy: tt.sortBy}, testVideos())
+				if err != nil {
+					t.Fatalf("refine() error = %v", err)
+				}
+				if !reflect.DeepEqual(ids(got), tt.want) {
+					t.Errorf("refine() = %v, want %v", ids(got), tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestCheckList(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  Fields
+		wantErr error
+	}{
+		{name: "empty"},
+		{name: "valid", fields: Fields{Where: `a.b != "x"`, SortBy: "a:desc,b"}},
+		{name: "no operator", fields: Fields{Where: "a.b"}, wantErr: errBadWhere},
+		{name: "unquoted", fields: Fields{Where: "a == b"}, wantErr: errBadWhere},
+		{name: "trailing", fields: Fields{Where: "a == 1 b"}, wantErr: errBadWhere},
+		{name: "unclosed", fields: Fields{Where: "(a == 1"}, wantErr: errBadWhere},
+		{name: "bad regexp", fields: Fields{Where: `a matches "("`}, wantErr: errBadWhere},
+		{name: "bad order", fields: Fields{SortBy: "a:up"}, wantErr: errBadSortBy},
+		{name: "bad output", fields: Fields{Output: "xml"}, wantErr: errUnknownFormat},
+		{name: "envelope", fields: Fields{Output: "json", Envelope: true}},
+		{name: "envelope table", fields: Fields{Output: "table", Envelope: true}, wantErr: errEnvelope},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := CheckList(&tt.fields)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CheckList() error = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil && failure.Classify(err).Class != failure.InvalidArgument {
+					t.Errorf("CheckList() error = %v, want invalid argument", err)
+				}
+			},
+		)
+	}
+}
+
+func TestPrintList_Where(t *testing.T) {
+	var buf bytes.Buffer
+	f := &Fields{
+		Output: "csv", Where: "statistics.viewCount >= 800",
+		SortBy: "statistics.viewCount",
+	}
+	PrintList(
+		f, testVideos(), &buf, table.Row{"ID"},
+		func(v *youtube.Video) table.Row { return table.Row{v.Id} },
+	)
+	if want := "ID\nc\na\nb\n"; buf.String() != want {
+		t.Errorf("PrintList() = %q, want %q", buf.String(), want)
+	}
+}
@@ -12,6 +12,12 @@ const (
 	MRUsage        = "The maximum number of items that should be returned, 0 for no limit"
 	TableUsage     = "json|yaml|table"
 	SilentUsage    = "json|yaml|silent"
+	ColumnsUsage   = "Comma separated field paths to print as table, csv and tsv columns, e.g. id,snippet.title"
+	WhereUsage     = `Only list items matching the expression, e.g. statistics.viewCount > 1000 && snippet.title contains "Go"`
+	SortByUsage    = "Comma separated field paths to sort by, each optionally followed by :desc"
+	FieldsUsage    = "Only fetch these fields of each item, within the parts, e.g. id,snippet.title or snippet(title,channelId)"
+	PageTokenUsage = "Start from this page, the nextPageToken of an earlier list"
+	EnvelopeUsage  = "Wrap json and yaml output in {items, nextPageToken, totalResults}"
 	JsonMIME       = "application/json"
 	PerPage        = 20
 	OBOUsage       = "ID of the YouTube account that the content owner is acting on behalf of"
@@ -0,0 +1,124 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package failure sorts errors into a few classes that scripts can act on,
+// each with a stable exit code. YouTube Data API errors are classified by
+// their HTTP status and reason; other errors are marked with a class where
+// they are created.
+package failure
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Class is the kind of an error.
+type Class string
+
+const (
+	// Unknown is any error that fits no other class.
+	Unknown Class = "unknown"
+	// InvalidArgument is a bad flag, parameter or request.
+	InvalidArgument Class = "invalidArgument"
+	// AuthError is missing, unreadable or rejected credentials.
+	AuthError Class = "authError"
+	// Forbidden is a request the authorized account may not make.
+	Forbidden Class = "forbidden"
+	// NotFound is a resource that does not exist.
+	NotFound Class = "notFound"
+	// QuotaExceeded is an exhausted API quota or local quota budget.
+	QuotaExceeded Class = "quotaExceeded"
+)
+
+// exitCodes are documented in the README and must not change.
+var exitCodes = map[Class]int{
+	Unknown:         1,
+	InvalidArgument: 2,
+	AuthError:       3,
+	Forbidden:       4,
+	NotFound:        5,
+	QuotaExceeded:   6,
+}
+
+// ExitCode returns the process exit code of c.
+func (c Class) ExitCode() int {
+	if code, ok := exitCodes[c]; ok {
+		return code
+	}
+	return exitCodes[Unknown]
+}
+
+// quotaReasons are the googleapi reasons of an exhausted quota.
+var quotaReasons = map[string]bool{
+	"quotaExceeded":      true,
+	"dailyLimitExceeded": true,
+}
+
+// Error is a classified error. Status and Reason come from the API
+// response, if the error has one.
+type Error struct {
+	Class    Class  `yaml:"class" json:"class"`
+	ExitCode int    `yaml:"exit_code" json:"exit_code"`
+	Status   int    `yaml:"status,omitempty" json:"status,omitempty"`
+	Reason   string `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Message  string `yaml:"message" json:"message"`
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// New marks err as class.
+func New(class Class, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		Class: class, ExitCode: class.ExitCode(), Message: err.Error(), err: err,
+	}
+}
+
+// Classify returns the class and details of err. An error marked with New
+// keeps its class; otherwise the first googleapi.Error in its chain decides.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var marked *Error
+	if errors.As(err, &marked) {
+		e := *marked
+		e.Message, e.err = err.Error(), err
+		return &e
+	}
+
+	e := &Error{Class: Unknown, Message: err.Error(), err: err}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		e.Status = apiErr.Code
+		if len(apiErr.Errors) > 0 {
+			e.Reason = apiErr.Errors[0].Reason
+		}
+		switch {
+		case quotaReasons[e.Reason]:
+			e.Class = QuotaExceeded
+		case apiErr.Code == http.StatusNotFound:
+			e.Class = NotFound
+		case apiErr.Code == http.StatusUnauthorized:
+			e.Class = AuthError
+		case apiErr.Code == http.StatusForbidden:
+			e.Class = Forbidden
+		case apiErr.Code == http.StatusBadRequest:
+			e.Class = InvalidArgument
+		}
+	}
+	e.ExitCode = e.Class.ExitCode()
+	return e
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package failure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func apiError(code int, reason string) error {
+	return &googleapi.Error{
+		Code: code, Message: reason,
+		Errors: []googleapi.ErrorItem{{Reason: reason, Message: reason}},
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		class  Class
+		code   int
+		status int
+		reason string
+	}{
+		{name: "plain", err: errors.New("boom"), class: Unknown, code: 1},
+		{name: "not found", err: apiError(404, "videoNotFound"), class: NotFound, code: 5, status: 404, reason: "videoNotFound"},
+		{name: "forbidden", err: apiError(403, "forbidden"), class: Forbidden, code: 4, status: 403, reason: "forbidden"},
+		{name: "quota", err: apiError(403, "quotaExceeded"), class: QuotaExceeded, code: 6, status: 403, reason: "quotaExceeded"},
+		{name: "daily limit", err: apiError(403, "dailyLimitExceeded"), class: QuotaExceeded, code: 6, status: 403, reason: "dailyLimitExceeded"},
+		{name: "bad request", err: apiError(400, "invalidTitle"), class: InvalidArgument, code: 2, status: 400, reason: "invalidTitle"},
+		{name: "unauthorized", err: apiError(401, "authError"), class: AuthError, code: 3, status: 401, reason: "authError"},
+		{name: "server", err: apiError(500, "backendError"), class: Unknown, code: 1, status: 500, reason: "backendError"},
+		{
+			name:  "joined",
+			err:   errors.Join(errors.New("failed to get video"), apiError(404, "videoNotFound")),
+			class: NotFound, code: 5, status: 404, reason: "videoNotFound",
+		},
+		{
+			name:  "marked",
+			err:   fmt.Errorf("wrapped: %w", New(AuthError, errors.New("no token"))),
+			class: AuthError, code: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got := Classify(tt.err)
+				if got.Class != tt.class || got.ExitCode != tt.code ||
+					got.Status != tt.status || got.Reason != tt.reason {
+					t.Errorf(
+						"Classify() = %s/%d/%d/%q, want %s/%d/%d/%q", got.Class,
+						got.ExitCode, got.Status, got.Reason, tt.class, tt.code,
+						tt.status, tt.reason,
+					)
+				}
+				if got.Message != tt.err.Error() || !errors.Is(got, tt.err) {
+					t.Errorf("Classify() lost the error: %q", got.Message)
+				}
+			},
+		)
+	}
+}
+
+func TestClassify_Nil(t *testing.T) {
+	if Classify(nil) != nil || New(NotFound, nil) != nil {
+		t.Error("nil errors should stay nil")
+	}
+}
+
+func TestNew(t *testing.T) {
+	cause := errors.New("budget spent")
+	err := New(QuotaExceeded, cause)
+	if !errors.Is(err, cause) || err.Error() != "budget spent" {
+		t.Errorf("New() = %v", err)
+	}
+	data, _ := json.Marshal(Classify(err))
+	want := `{"class":"quotaExceeded","exit_code":6,"message":"budget spent"}`
+	if string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
+
+func TestClass_ExitCode(t *testing.T) {
+	if Class("bogus").ExitCode() != 1 {
+		t.Error("unknown classes should exit 1")
+	}
+}
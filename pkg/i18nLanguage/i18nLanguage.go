@@ -46,6 +46,11 @@ func (i *I18nLanguage) Get() (
 		call = call.Hl(i.Hl)
 	}
 
+	call, err := common.ApplyMask(&i.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetI18nLanguage, err)
@@ -60,18 +65,27 @@ func (i *I18nLanguage) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		i.Output, languages, writer, table.Row{"ID", "Hl", "Name"},
+	return common.PrintList(
+		&i.Fields, languages, writer, table.Row{"ID", "Hl", "Name"},
 		func(l *youtube.I18nLanguage) table.Row {
-			return table.Row{l.Id, l.Snippet.Hl, l.Snippet.Name}
+			hl := ""
+			name := ""
+			if l.Snippet != nil {
+				hl = l.Snippet.Hl
+				name = l.Snippet.Name
+			}
+			return table.Row{l.Id, hl, name}
 		},
 	)
-	return nil
 }
 
 var (
-	WithHl      = common.WithHl[*I18nLanguage]
-	WithParts   = common.WithParts[*I18nLanguage]
-	WithOutput  = common.WithOutput[*I18nLanguage]
-	WithService = common.WithService[*I18nLanguage]
+	WithHl        = common.WithHl[*I18nLanguage]
+	WithParts     = common.WithParts[*I18nLanguage]
+	WithColumns   = common.WithColumns[*I18nLanguage]
+	WithWhere     = common.WithWhere[*I18nLanguage]
+	WithSortBy    = common.WithSortBy[*I18nLanguage]
+	WithFieldMask = common.WithFieldMask[*I18nLanguage]
+	WithOutput    = common.WithOutput[*I18nLanguage]
+	WithService   = common.WithService[*I18nLanguage]
 )
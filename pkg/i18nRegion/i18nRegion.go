@@ -44,6 +44,11 @@ func (i *I18nRegion) Get() ([]*youtube.I18nRegion, error) {
 		call = call.Hl(i.Hl)
 	}
 
+	call, err := common.ApplyMask(&i.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetI18nRegion, err)
@@ -58,18 +63,27 @@ func (i *I18nRegion) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		i.Output, regions, writer, table.Row{"ID", "Gl", "Name"},
+	return common.PrintList(
+		&i.Fields, regions, writer, table.Row{"ID", "Gl", "Name"},
 		func(r *youtube.I18nRegion) table.Row {
-			return table.Row{r.Id, r.Snippet.Gl, r.Snippet.Name}
+			gl := ""
+			name := ""
+			if r.Snippet != nil {
+				gl = r.Snippet.Gl
+				name = r.Snippet.Name
+			}
+			return table.Row{r.Id, gl, name}
 		},
 	)
-	return nil
 }
 
 var (
-	WithHl      = common.WithHl[*I18nRegion]
-	WithParts   = common.WithParts[*I18nRegion]
-	WithOutput  = common.WithOutput[*I18nRegion]
-	WithService = common.WithService[*I18nRegion]
+	WithHl        = common.WithHl[*I18nRegion]
+	WithParts     = common.WithParts[*I18nRegion]
+	WithColumns   = common.WithColumns[*I18nRegion]
+	WithWhere     = common.WithWhere[*I18nRegion]
+	WithSortBy    = common.WithSortBy[*I18nRegion]
+	WithFieldMask = common.WithFieldMask[*I18nRegion]
+	WithOutput    = common.WithOutput[*I18nRegion]
+	WithService   = common.WithService[*I18nRegion]
 )
@@ -5,12 +5,17 @@ package pkg
 
 import (
 	"io"
+	"iter"
 )
 
 type Getter[T any] interface {
 	Get() ([]*T, error)
 }
 
+type Iterator[T any] interface {
+	All() iter.Seq2[*T, error]
+}
+
 type Lister interface {
 	List(io.Writer) error
 }
@@ -0,0 +1,338 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// FileName is the ledger file kept next to the token cache.
+const FileName = "yutu.ledger.json"
+
+var (
+	errReadLedger  = errors.New("failed to read upload ledger")
+	errWriteLedger = errors.New("failed to write upload ledger")
+	errHashFile    = errors.New("failed to hash file")
+	errNoQuery     = errors.New("search needs a query")
+	errPruneFilter = errors.New("prune needs ids, before or missing")
+	errBefore      = errors.New("before must be a date (2006-01-02) or RFC3339 time")
+)
+
+// mu serializes read-modify-write cycles of the ledger file within a process.
+var mu sync.Mutex
+
+// Entry records one successful upload. Path is empty for uploads read from
+// a stream.
+type Entry struct {
+	Hash     string `yaml:"hash" json:"hash"`
+	Size     int64  `yaml:"size" json:"size"`
+	Path     string `yaml:"path,omitempty" json:"path,omitempty"`
+	VideoId  string `yaml:"video_id" json:"video_id"`
+	Title    string `yaml:"title,omitempty" json:"title,omitempty"`
+	Uploaded string `yaml:"uploaded" json:"uploaded"`
+}
+
+// Path returns the ledger file, relative to pkg.Root.
+func Path() string {
+	return filepath.ToSlash(filepath.Join(auth.CacheDir(), FileName))
+}
+
+// HashFile returns the hex encoded SHA-256 of r and the number of bytes read.
+func HashFile(r io.Reader) (string, int64, error) {
+	hr := NewHashReader(r)
+	if _, err := io.Copy(io.Discard, hr); err != nil {
+		return "", 0, errors.Join(errHashFile, err)
+	}
+	hash, size := hr.Sum()
+	return hash, size, nil
+}
+
+// HashReader hashes everything read through it, for streams that can only
+// be read once.
+type HashReader struct {
+	r io.Reader
+	h hash.Hash
+	n int64
+}
+
+func NewHashReader(r io.Reader) *HashReader {
+	return &HashReader{r: r, h: sha256.New()}
+}
+
+func (hr *HashReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n])
+	hr.n += int64(n)
+	return n, err
+}
+
+// Sum returns the hex encoded SHA-256 and size of the bytes read so far.
+func (hr *HashReader) Sum() (string, int64) {
+	return hex.EncodeToString(hr.h.Sum(nil)), hr.n
+}
+
+// Load returns every entry in the ledger. A missing ledger is empty.
+func Load() ([]*Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+// Lookup returns the entry recorded for hash, or nil.
+func Lookup(hash string) (*Entry, error) {
+	entries, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Hash == hash {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+// Record appends e to the ledger, stamping the upload time if unset.
+func Record(e *Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	if e.Uploaded == "" {
+		e.Uploaded = time.Now().UTC().Format(time.RFC3339)
+	}
+	return save(append(entries, e))
+}
+
+func load() ([]*Entry, error) {
+	data, err := pkg.Root.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Join(errReadLedger, err)
+	}
+	var entries []*Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Join(errReadLedger, err)
+	}
+	return entries, nil
+}
+
+func save(entries []*Entry) error {
+	path := Path()
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return errors.Join(errWriteLedger, err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := pkg.Root.MkdirAll(dir, 0755); err != nil {
+			return errors.Join(errWriteLedger, err)
+		}
+	}
+	if err := pkg.Root.WriteFile(path, data, 0600); err != nil {
+		return errors.Join(errWriteLedger, err)
+	}
+	return nil
+}
+
+// Ledger lists, searches and prunes the local upload ledger.
+type Ledger struct {
+	common.Fields
+	Query   string `yaml:"query" json:"query,omitempty"`
+	Before  string `yaml:"before" json:"before,omitempty"`
+	Missing bool   `yaml:"missing" json:"missing,omitempty"`
+}
+
+type ILedger[T any] interface {
+	List(io.Writer) error
+	Search(io.Writer) error
+	Prune(io.Writer) error
+	Get() ([]*T, error)
+}
+
+type Option func(*Ledger)
+
+func NewLedger(opts ...Option) ILedger[Entry] {
+	l := &Ledger{Fields: common.Fields{}}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Get returns the entries whose video ID or hash prefix is in Ids and whose
+// path, title, video ID or hash contains Query, case-insensitively.
+func (l *Ledger) Get() ([]*Entry, error) {
+	entries, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(l.Query)
+	return slices.DeleteFunc(
+		entries, func(e *Entry) bool {
+			if len(l.Ids) > 0 && !l.matchId(e) {
+				return true
+			}
+			if query == "" {
+				return false
+			}
+			for _, s := range []string{e.Path, e.Title, e.VideoId, e.Hash} {
+				if strings.Contains(strings.ToLower(s), query) {
+					return false
+				}
+			}
+			return true
+		},
+	), nil
+}
+
+func (l *Ledger) List(writer io.Writer) error {
+	entries, err := l.Get()
+	if err != nil {
+		return err
+	}
+	return l.print(entries, writer)
+}
+
+func (l *Ledger) Search(writer io.Writer) error {
+	if l.Query == "" {
+		return errNoQuery
+	}
+	return l.List(writer)
+}
+
+// Prune removes entries matching Ids, uploaded before Before, or whose file
+// no longer exists when Missing is set, and prints the removed entries.
+// Entries of stream uploads have no file and are never missing.
+func (l *Ledger) Prune(writer io.Writer) error {
+	if len(l.Ids) == 0 && l.Before == "" && !l.Missing {
+		return errPruneFilter
+	}
+	var before time.Time
+	if l.Before != "" {
+		var err error
+		if before, err = parseTime(l.Before); err != nil {
+			return err
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+
+	var removed []*Entry
+	kept := slices.DeleteFunc(
+		entries, func(e *Entry) bool {
+			prune := len(l.Ids) > 0 && l.matchId(e)
+			if !before.IsZero() {
+				uploaded, err := time.Parse(time.RFC3339, e.Uploaded)
+				prune = prune || err == nil && uploaded.Before(before)
+			}
+			if l.Missing && e.Path != "" {
+				_, err := pkg.Root.Stat(e.Path)
+				prune = prune || os.IsNotExist(err)
+			}
+			if prune {
+				removed = append(removed, e)
+			}
+			return prune
+		},
+	)
+	if len(removed) > 0 {
+		if err := save(kept); err != nil {
+			return err
+		}
+	}
+
+	switch l.Output {
+	case "json", "yaml", "silent":
+		common.PrintResult(l.Output, removed, writer, "")
+	default:
+		if err := l.print(removed, writer); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(
+			writer, "Pruned %d of %d ledger entries\n",
+			len(removed), len(removed)+len(kept),
+		)
+	}
+	return nil
+}
+
+func (l *Ledger) matchId(e *Entry) bool {
+	return slices.ContainsFunc(
+		l.Ids, func(id string) bool {
+			return id == e.VideoId || id != "" && strings.HasPrefix(e.Hash, id)
+		},
+	)
+}
+
+func (l *Ledger) print(entries []*Entry, writer io.Writer) error {
+	return common.PrintList(
+		&l.Fields, entries, writer,
+		table.Row{"Video ID", "Path", "Size", "Hash", "Uploaded"},
+		func(e *Entry) table.Row {
+			return table.Row{e.VideoId, e.Path, e.Size, e.Hash[:min(12, len(e.Hash))], e.Uploaded}
+		},
+	)
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.Join(errBefore, err)
+	}
+	return t, nil
+}
+
+func WithQuery(query string) Option {
+	return func(l *Ledger) {
+		l.Query = query
+	}
+}
+
+func WithBefore(before string) Option {
+	return func(l *Ledger) {
+		l.Before = before
+	}
+}
+
+func WithMissing(missing bool) Option {
+	return func(l *Ledger) {
+		l.Missing = missing
+	}
+}
+
+var (
+	WithIds     = common.WithIds[*Ledger]
+	WithColumns = common.WithColumns[*Ledger]
+	WithWhere   = common.WithWhere[*Ledger]
+	WithSortBy  = common.WithSortBy[*Ledger]
+	WithOutput  = common.WithOutput[*Ledger]
+)
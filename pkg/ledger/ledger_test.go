@@ -0,0 +1,162 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+)
+
+func seed(t *testing.T) {
+	t.Helper()
+	entries := []*Entry{
+		{
+			Hash: "aaaa1111", Size: 10, Path: "intro.mp4", VideoId: "vid1",
+			Title: "Intro", Uploaded: "2026-01-01T00:00:00Z",
+		},
+		{
+			Hash: "bbbb2222", Size: 20, Path: "outro.mp4", VideoId: "vid2",
+			Title: "Outro", Uploaded: "2026-06-01T00:00:00Z",
+		},
+	}
+	for _, e := range entries {
+		if err := Record(e); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	hash, size, err := HashFile(strings.NewReader("yutu"))
+	if err != nil {
+		t.Fatalf("HashFile() error = %v", err)
+	}
+	if size != 4 {
+		t.Errorf("size = %d, want 4", size)
+	}
+	const want = "a820218ba7974a0b87111be72119144eb843969568e0fb0047d4fce875e9d779"
+	if hash != want {
+		t.Errorf("hash = %q, want %q", hash, want)
+	}
+}
+
+func TestRecordLookup(t *testing.T) {
+	common.SetTestRoot(t)
+	if e, err := Lookup("aaaa1111"); err != nil || e != nil {
+		t.Fatalf("Lookup() on empty ledger = %v, %v", e, err)
+	}
+	seed(t)
+
+	e, err := Lookup("bbbb2222")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if e == nil || e.VideoId != "vid2" {
+		t.Errorf("Lookup() = %+v, want vid2", e)
+	}
+
+	e = &Entry{Hash: "cccc", VideoId: "vid3"}
+	if err := Record(e); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if e.Uploaded == "" {
+		t.Error("Record() should stamp the upload time")
+	}
+}
+
+func TestLedger_Search(t *testing.T) {
+	common.SetTestRoot(t)
+	seed(t)
+
+	var buf bytes.Buffer
+	l := NewLedger(WithQuery("OUTRO"), WithOutput("json"))
+	if err := l.Search(&buf); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	var got []*Entry
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(got) != 1 || got[0].VideoId != "vid2" {
+		t.Errorf("Search() = %+v, want vid2 only", got)
+	}
+
+	if err := NewLedger().Search(&buf); !errors.Is(err, errNoQuery) {
+		t.Errorf("Search() without query error = %v, want %v", err, errNoQuery)
+	}
+
+	entries, err := NewLedger(WithIds([]string{"aaaa"})).Get()
+	if err != nil || len(entries) != 1 || entries[0].VideoId != "vid1" {
+		t.Errorf("Get() by hash prefix = %+v, %v", entries, err)
+	}
+}
+
+func TestLedger_Prune(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    []string
+		wantErr error
+	}{
+		{name: "no filter", wantErr: errPruneFilter},
+		{
+			name: "by id", opts: []Option{WithIds([]string{"vid1"})},
+			want: []string{"vid2", "vid3"},
+		},
+		{
+			name: "before", opts: []Option{WithBefore("2026-03-01")},
+			want: []string{"vid2", "vid3"},
+		},
+		{
+			name: "missing", opts: []Option{WithMissing(true)},
+			want: []string{"vid1", "vid3"},
+		},
+		{
+			name: "bad before", opts: []Option{WithBefore("March")},
+			wantErr: errBefore,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				common.SetTestRoot(t)
+				seed(t)
+				if err := pkg.Root.WriteFile("intro.mp4", []byte("v"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				// A stream upload has no file to go missing.
+				if err := Record(&Entry{Hash: "cccc3333", VideoId: "vid3"}); err != nil {
+					t.Fatal(err)
+				}
+
+				opts := append(tt.opts, WithOutput("silent"))
+				err := NewLedger(opts...).Prune(&bytes.Buffer{})
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Prune() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil {
+					return
+				}
+
+				entries, err := Load()
+				if err != nil {
+					t.Fatalf("Load() error = %v", err)
+				}
+				var got []string
+				for _, e := range entries {
+					got = append(got, e.VideoId)
+				}
+				if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+					t.Errorf("remaining = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lint checks titles, descriptions and tags against the limits of
+// the YouTube Data API, so that requests it would reject are never sent.
+package lint
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MaxTitle is the longest title in characters.
+	MaxTitle = 100
+	// MaxDescription is the longest description in bytes.
+	MaxDescription = 5000
+	// MaxTags is the longest tag list in characters, counted as YouTube
+	// does: the comma between tags counts, and a tag containing a space
+	// counts its surrounding quotes.
+	MaxTags = 500
+	// Tag is added by yutu to the tags of every video it uploads or updates,
+	// so it counts towards MaxTags.
+	Tag = "yutu🐰"
+)
+
+var (
+	errInvalid   = errors.New("invalid metadata")
+	errReadFile  = errors.New("failed to read metadata file")
+	errNoFile    = errors.New("metadata file is required")
+	errViolation = errors.New("metadata violates YouTube limits")
+)
+
+// Violation is one field the API would reject. Entry is the 1-based
+// position in a metadata file, or 0 when checking a single request.
+type Violation struct {
+	Entry   int    `yaml:"entry,omitempty" json:"entry,omitempty"`
+	Field   string `yaml:"field" json:"field"`
+	Message string `yaml:"message" json:"message"`
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// Metadata is the user-written text of a video, playlist or channel.
+type Metadata struct {
+	Title       string   `yaml:"title" json:"title,omitempty"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Tags        []string `yaml:"tags" json:"tags,omitempty"`
+}
+
+// Violations returns every limit m breaks, in field order.
+func (m *Metadata) Violations() []*Violation {
+	var vs []*Violation
+	add := func(field, format string, args ...any) {
+		vs = append(vs, &Violation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n := utf8.RuneCountInString(m.Title); n > MaxTitle {
+		add("title", "is %d characters, the limit is %d", n, MaxTitle)
+	}
+	if strings.ContainsAny(m.Title, "<>") {
+		add("title", "must not contain < or >")
+	}
+	if n := len(m.Description); n > MaxDescription {
+		add("description", "is %d bytes, the limit is %d", n, MaxDescription)
+	}
+	if strings.ContainsAny(m.Description, "<>") {
+		add("description", "must not contain < or >")
+	}
+	if n := tagsLength(m.Tags); n > MaxTags {
+		add("tags", "are %d characters in total, the limit is %d", n, MaxTags)
+	}
+	for i, tag := range m.Tags {
+		if strings.ContainsAny(tag, "<>") {
+			add(fmt.Sprintf("tags[%d]", i), "must not contain < or >")
+		}
+	}
+	return vs
+}
+
+// Check returns an error naming every violation of m, or nil.
+func Check(m Metadata) error {
+	vs := m.Violations()
+	if len(vs) == 0 {
+		return nil
+	}
+	errs := []error{errInvalid}
+	for _, v := range vs {
+		errs = append(errs, v)
+	}
+	return failure.New(failure.InvalidArgument, errors.Join(errs...))
+}
+
+// Tagged returns tags with Tag appended, unless it is there already.
+func Tagged(tags []string) []string {
+	if slices.Contains(tags, Tag) {
+		return tags
+	}
+	return append(slices.Clip(tags), Tag)
+}
+
+func tagsLength(tags []string) int {
+	n := 0
+	for i, tag := range tags {
+		n += utf8.RuneCountInString(tag)
+		if strings.Contains(tag, " ") {
+			n += 2
+		}
+		if i > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Linter checks every entry of a metadata file: YAML or JSON holding one
+// entry or a list of them, or CSV with a header row. Video manifests can be
+// linted as is.
+type Linter struct {
+	common.Fields
+	File string `yaml:"file" json:"file"`
+}
+
+type ILinter interface {
+	Lint(io.Writer) error
+}
+
+type Option func(*Linter)
+
+func NewLinter(opts ...Option) ILinter {
+	l := &Linter{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Lint prints the violations of every entry in l.File and fails when there
+// are any.
+func (l *Linter) Lint(writer io.Writer) error {
+	entries, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	vs := make([]*Violation, 0)
+	for i, entry := range entries {
+		entry.Tags = Tagged(entry.Tags)
+		for _, v := range entry.Violations() {
+			v.Entry = i + 1
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) == 0 {
+		common.PrintResult(
+			l.Output, vs, writer, "%s: %d entries, no violations\n",
+			l.File, len(entries),
+		)
+		return nil
+	}
+
+	if err := common.PrintList(
+		&l.Fields, vs, writer, table.Row{"Entry", "Field", "Message"},
+		func(v *Violation) table.Row {
+			return table.Row{v.Entry, v.Field, v.Message}
+		},
+	); err != nil {
+		return err
+	}
+	return failure.New(
+		failure.InvalidArgument, fmt.Errorf(
+			"%w: %d violations in %d entries", errViolation, len(vs), len(entries),
+		),
+	)
+}
+
+func (l *Linter) read() ([]*Metadata, error) {
+	if l.File == "" {
+		return nil, errNoFile
+	}
+	data, err := pkg.Root.ReadFile(l.File)
+	if err != nil {
+		return nil, errors.Join(errReadFile, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(l.File), ".csv") {
+		return readCSV(data)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, errors.Join(errReadFile, err)
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+	var entries []*Metadata
+	if node.Content[0].Kind == yaml.SequenceNode {
+		err = node.Decode(&entries)
+	} else {
+		entry := &Metadata{}
+		err = node.Decode(entry)
+		entries = append(entries, entry)
+	}
+	if err != nil {
+		return nil, errors.Join(errReadFile, err)
+	}
+	return entries, nil
+}
+
+// readCSV reads the title, description and comma separated tags columns of
+// a CSV file with a header row, ignoring other columns.
+func readCSV(data []byte) ([]*Metadata, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, errors.Join(errReadFile, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	entries := make([]*Metadata, 0, len(records)-1)
+	for _, record := range records[1:] {
+		entry := &Metadata{}
+		for i, name := range records[0] {
+			if i >= len(record) || record[i] == "" {
+				continue
+			}
+			switch strings.TrimSpace(name) {
+			case "title":
+				entry.Title = record[i]
+			case "description":
+				entry.Description = record[i]
+			case "tags":
+				for tag := range strings.SplitSeq(record[i], ",") {
+					entry.Tags = append(entry.Tags, strings.TrimSpace(tag))
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func WithFile(file string) Option {
+	return func(l *Linter) {
+		l.File = file
+	}
+}
+
+var (
+	WithColumns = common.WithColumns[*Linter]
+	WithWhere   = common.WithWhere[*Linter]
+	WithSortBy  = common.WithSortBy[*Linter]
+	WithOutput  = common.WithOutput[*Linter]
+)
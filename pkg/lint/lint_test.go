@@ -0,0 +1,169 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+)
+
+func fields(vs []*Violation) []string {
+	var got []string
+	for _, v := range vs {
+		got = append(got, v.Field)
+	}
+	return got
+}
+
+func TestMetadata_Violations(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Metadata
+		want []string
+	}{
+		{
+			name: "valid",
+			m: Metadata{
+				Title:       strings.Repeat("兔", MaxTitle),
+				Description: strings.Repeat("a", MaxDescription),
+				Tags:        []string{"go", "cli tool"},
+			},
+		},
+		{
+			name: "every limit",
+			m: Metadata{
+				Title:       strings.Repeat("a", MaxTitle+1) + "<",
+				Description: strings.Repeat("兔", MaxDescription/3+1),
+				Tags:        []string{"ok", "a>b"},
+			},
+			want: []string{"title", "title", "description", "tags[1]"},
+		},
+		{
+			// 50 tags of 9 characters with commas are 499 characters, the
+			// quotes around a tag with a space push it over.
+			name: "tags total",
+			m: Metadata{
+				Tags: append(slices.Repeat([]string{"abcdefghi"}, 49), "abc defgh"),
+			},
+			want: []string{"tags"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := fields(tt.m.Violations()); !slices.Equal(got, tt.want) {
+					t.Errorf("Violations() fields = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(Metadata{Title: "fine"}); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	err := Check(Metadata{Title: "<a>", Description: "<b>"})
+	if !errors.Is(err, errInvalid) {
+		t.Fatalf("Check() error = %v, want %v", err, errInvalid)
+	}
+	for _, field := range []string{"title:", "description:"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Check() error %q does not name %s", err, field)
+		}
+	}
+}
+
+func TestLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "single entry",
+			file:    "metadata.yaml",
+			content: "title: Hello\ndescription: World\ntags: [a, b]\n",
+		},
+		{
+			name: "manifest",
+			file: "uploads.yaml",
+			content: `- file: a.mp4
+  title: "<intro>"
+- file: b.mp4
+  title: Fine
+  description: "x > y"
+`,
+			want:    2,
+			wantErr: errViolation,
+		},
+		{
+			name:    "csv",
+			file:    "uploads.csv",
+			content: "file,title,tags\na.mp4,ok,\"x, <y>\"\n",
+			want:    1,
+			wantErr: errViolation,
+		},
+		{
+			name:    "tags too long once tagged",
+			file:    "metadata.yaml",
+			content: "tags: [" + strings.Repeat("a", MaxTags-4) + "]\n",
+			want:    1,
+			wantErr: errViolation,
+		},
+		{name: "missing file", file: "none.yaml", wantErr: errReadFile},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				root, err := os.OpenRoot(dir)
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				if tt.content != "" {
+					if err := os.WriteFile(dir+"/"+tt.file, []byte(tt.content), 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				var buf bytes.Buffer
+				err = NewLinter(WithFile(tt.file), WithOutput("json")).Lint(&buf)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Lint() error = %v, want %v", err, tt.wantErr)
+				}
+				if errors.Is(err, errReadFile) {
+					return
+				}
+				if err != nil && failure.Classify(err).Class != failure.InvalidArgument {
+					t.Errorf("Lint() error = %v, want invalid argument", err)
+				}
+				var got []*Violation
+				if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+					t.Fatalf("invalid json: %v\n%s", err, buf.String())
+				}
+				if len(got) != tt.want {
+					t.Errorf("Lint() = %s, want %d violations", buf.String(), tt.want)
+				}
+				if tt.want > 0 && got[len(got)-1].Entry == 0 {
+					t.Error("violations should carry their entry")
+				}
+			},
+		)
+	}
+}
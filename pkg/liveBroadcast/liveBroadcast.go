@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -46,6 +47,7 @@ type LiveBroadcast struct {
 type ILiveBroadcast[T any] interface {
 	List(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	Insert(io.Writer) error
 	Update(io.Writer) error
 	Delete(io.Writer) error
@@ -65,8 +67,12 @@ func NewLiveBroadcast(opts ...Option) ILiveBroadcast[youtube.LiveBroadcast] {
 }
 
 func (b *LiveBroadcast) Get() ([]*youtube.LiveBroadcast, error) {
+	return common.Collect(b.All())
+}
+
+func (b *LiveBroadcast) All() iter.Seq2[*youtube.LiveBroadcast, error] {
 	if err := b.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.LiveBroadcast](err)
 	}
 	call := b.Service.LiveBroadcasts.List(b.Parts)
 	if len(b.Ids) > 0 {
@@ -88,22 +94,19 @@ func (b *LiveBroadcast) Get() ([]*youtube.LiveBroadcast, error) {
 		call = call.OnBehalfOfContentOwnerChannel(b.OnBehalfOfContentOwnerChannel)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&b.Fields, call,
-		func(r *youtube.LiveBroadcastListResponse) ([]*youtube.LiveBroadcast, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.LiveBroadcastListResponse) (
+			[]*youtube.LiveBroadcast, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetLiveBroadcast,
 	)
 }
 
 func (b *LiveBroadcast) List(writer io.Writer) error {
-	broadcasts, err := b.Get()
-	if err != nil && broadcasts == nil {
-		return err
-	}
-
-	common.PrintList(
-		b.Output, broadcasts, writer,
+	return common.PrintSeq(
+		&b.Fields, b.All(), writer,
 		table.Row{"ID", "Title", "Status", "Privacy"},
 		func(bc *youtube.LiveBroadcast) table.Row {
 			title := ""
@@ -119,7 +122,6 @@ func (b *LiveBroadcast) List(writer io.Writer) error {
 			return table.Row{bc.Id, title, status, privacy}
 		},
 	)
-	return err
 }
 
 func (b *LiveBroadcast) Insert(writer io.Writer) error {
@@ -422,6 +424,12 @@ func WithOnBehalfOfContentOwnerChannel(channel string) Option {
 var (
 	WithMaxResults = common.WithMaxResults[*LiveBroadcast]
 	WithParts      = common.WithParts[*LiveBroadcast]
+	WithColumns    = common.WithColumns[*LiveBroadcast]
+	WithWhere      = common.WithWhere[*LiveBroadcast]
+	WithSortBy     = common.WithSortBy[*LiveBroadcast]
+	WithFieldMask  = common.WithFieldMask[*LiveBroadcast]
+	WithPageToken  = common.WithPageToken[*LiveBroadcast]
+	WithEnvelope   = common.WithEnvelope[*LiveBroadcast]
 	WithOutput     = common.WithOutput[*LiveBroadcast]
 	WithService    = common.WithService[*LiveBroadcast]
 	WithIds        = common.WithIds[*LiveBroadcast]
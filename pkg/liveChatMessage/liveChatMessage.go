@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -30,6 +31,7 @@ type LiveChatMessage struct {
 type ILiveChatMessage[T any] interface {
 	List(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	Insert(io.Writer) error
 	Delete(io.Writer) error
 	Transition(io.Writer) error
@@ -46,48 +48,49 @@ func NewLiveChatMessage(opts ...Option) ILiveChatMessage[youtube.LiveChatMessage
 }
 
 func (m *LiveChatMessage) Get() ([]*youtube.LiveChatMessage, error) {
+	return common.Collect(m.All())
+}
+
+func (m *LiveChatMessage) All() iter.Seq2[*youtube.LiveChatMessage, error] {
 	if err := m.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.LiveChatMessage](err)
 	}
 	call := m.Service.LiveChatMessages.List(m.LiveChatId, m.Parts)
 	if m.Hl != "" {
 		call = call.Hl(m.Hl)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&m.Fields, call,
-		func(r *youtube.LiveChatMessageListResponse) ([]*youtube.LiveChatMessage, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.LiveChatMessageListResponse) (
+			[]*youtube.LiveChatMessage, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetLiveChatMessage,
 	)
 }
 
 func (m *LiveChatMessage) List(writer io.Writer) error {
-	messages, err := m.Get()
-	if err != nil && messages == nil {
-		return err
-	}
-
-	common.PrintList(
-		m.Output, messages, writer,
+	return common.PrintSeq(
+		&m.Fields, m.All(), writer,
 		table.Row{"ID", "Type", "Author", "Message"},
 		func(msg *youtube.LiveChatMessage) table.Row {
-			var authorName, msgText string
+			var msgType, authorName, msgText string
 			if msg.AuthorDetails != nil {
 				authorName = msg.AuthorDetails.DisplayName
 			}
 			if msg.Snippet != nil {
+				msgType = msg.Snippet.Type
 				msgText = msg.Snippet.DisplayMessage
 			}
 			return table.Row{
 				msg.Id,
-				msg.Snippet.Type,
+				msgType,
 				authorName,
 				msgText,
 			}
 		},
 	)
-	return err
 }
 
 func (m *LiveChatMessage) Insert(writer io.Writer) error {
@@ -173,6 +176,12 @@ var (
 	WithHl         = common.WithHl[*LiveChatMessage]
 	WithMaxResults = common.WithMaxResults[*LiveChatMessage]
 	WithParts      = common.WithParts[*LiveChatMessage]
+	WithColumns    = common.WithColumns[*LiveChatMessage]
+	WithWhere      = common.WithWhere[*LiveChatMessage]
+	WithSortBy     = common.WithSortBy[*LiveChatMessage]
+	WithFieldMask  = common.WithFieldMask[*LiveChatMessage]
+	WithPageToken  = common.WithPageToken[*LiveChatMessage]
+	WithEnvelope   = common.WithEnvelope[*LiveChatMessage]
 	WithOutput     = common.WithOutput[*LiveChatMessage]
 	WithService    = common.WithService[*LiveChatMessage]
 	WithIds        = common.WithIds[*LiveChatMessage]
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -28,6 +29,7 @@ type LiveChatModerator struct {
 type ILiveChatModerator[T any] interface {
 	List(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	Insert(io.Writer) error
 	Delete(io.Writer) error
 }
@@ -43,37 +45,39 @@ func NewLiveChatModerator(opts ...Option) ILiveChatModerator[youtube.LiveChatMod
 }
 
 func (m *LiveChatModerator) Get() ([]*youtube.LiveChatModerator, error) {
+	return common.Collect(m.All())
+}
+
+func (m *LiveChatModerator) All() iter.Seq2[*youtube.LiveChatModerator, error] {
 	if err := m.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.LiveChatModerator](err)
 	}
 	call := m.Service.LiveChatModerators.List(m.LiveChatId, m.Parts)
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&m.Fields, call,
-		func(r *youtube.LiveChatModeratorListResponse) ([]*youtube.LiveChatModerator, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.LiveChatModeratorListResponse) (
+			[]*youtube.LiveChatModerator, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetLiveChatModerator,
 	)
 }
 
 func (m *LiveChatModerator) List(writer io.Writer) error {
-	moderators, err := m.Get()
-	if err != nil && moderators == nil {
-		return err
-	}
-
-	common.PrintList(
-		m.Output, moderators, writer,
+	return common.PrintSeq(
+		&m.Fields, m.All(), writer,
 		table.Row{"ID", "Channel ID", "Display Name"},
 		func(mod *youtube.LiveChatModerator) table.Row {
-			return table.Row{
-				mod.Id,
-				mod.Snippet.ModeratorDetails.ChannelId,
-				mod.Snippet.ModeratorDetails.DisplayName,
+			channelId := ""
+			displayName := ""
+			if mod.Snippet != nil && mod.Snippet.ModeratorDetails != nil {
+				channelId = mod.Snippet.ModeratorDetails.ChannelId
+				displayName = mod.Snippet.ModeratorDetails.DisplayName
 			}
+			return table.Row{mod.Id, channelId, displayName}
 		},
 	)
-	return err
 }
 
 func (m *LiveChatModerator) Insert(writer io.Writer) error {
@@ -132,6 +136,12 @@ func WithModeratorChannelId(channelId string) Option {
 var (
 	WithMaxResults = common.WithMaxResults[*LiveChatModerator]
 	WithParts      = common.WithParts[*LiveChatModerator]
+	WithColumns    = common.WithColumns[*LiveChatModerator]
+	WithWhere      = common.WithWhere[*LiveChatModerator]
+	WithSortBy     = common.WithSortBy[*LiveChatModerator]
+	WithFieldMask  = common.WithFieldMask[*LiveChatModerator]
+	WithPageToken  = common.WithPageToken[*LiveChatModerator]
+	WithEnvelope   = common.WithEnvelope[*LiveChatModerator]
 	WithOutput     = common.WithOutput[*LiveChatModerator]
 	WithService    = common.WithService[*LiveChatModerator]
 	WithIds        = common.WithIds[*LiveChatModerator]
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -35,6 +36,7 @@ type LiveStream struct {
 type ILiveStream[T any] interface {
 	List(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	Insert(io.Writer) error
 	Update(io.Writer) error
 	Delete(io.Writer) error
@@ -51,8 +53,12 @@ func NewLiveStream(opts ...Option) ILiveStream[youtube.LiveStream] {
 }
 
 func (s *LiveStream) Get() ([]*youtube.LiveStream, error) {
+	return common.Collect(s.All())
+}
+
+func (s *LiveStream) All() iter.Seq2[*youtube.LiveStream, error] {
 	if err := s.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.LiveStream](err)
 	}
 	call := s.Service.LiveStreams.List(s.Parts)
 	if len(s.Ids) > 0 {
@@ -68,22 +74,19 @@ func (s *LiveStream) Get() ([]*youtube.LiveStream, error) {
 		call = call.OnBehalfOfContentOwnerChannel(s.OnBehalfOfContentOwnerChannel)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&s.Fields, call,
-		func(r *youtube.LiveStreamListResponse) ([]*youtube.LiveStream, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.LiveStreamListResponse) (
+			[]*youtube.LiveStream, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetLiveStream,
 	)
 }
 
 func (s *LiveStream) List(writer io.Writer) error {
-	streams, err := s.Get()
-	if err != nil && streams == nil {
-		return err
-	}
-
-	common.PrintList(
-		s.Output, streams, writer,
+	return common.PrintSeq(
+		&s.Fields, s.All(), writer,
 		table.Row{"ID", "Title", "Status"},
 		func(stream *youtube.LiveStream) table.Row {
 			title := ""
@@ -97,7 +100,6 @@ func (s *LiveStream) List(writer io.Writer) error {
 			return table.Row{stream.Id, title, status}
 		},
 	)
-	return err
 }
 
 func (s *LiveStream) Insert(writer io.Writer) error {
@@ -268,6 +270,12 @@ func WithOnBehalfOfContentOwnerChannel(channel string) Option {
 var (
 	WithMaxResults = common.WithMaxResults[*LiveStream]
 	WithParts      = common.WithParts[*LiveStream]
+	WithColumns    = common.WithColumns[*LiveStream]
+	WithWhere      = common.WithWhere[*LiveStream]
+	WithSortBy     = common.WithSortBy[*LiveStream]
+	WithFieldMask  = common.WithFieldMask[*LiveStream]
+	WithPageToken  = common.WithPageToken[*LiveStream]
+	WithEnvelope   = common.WithEnvelope[*LiveStream]
 	WithOutput     = common.WithOutput[*LiveStream]
 	WithService    = common.WithService[*LiveStream]
 	WithIds        = common.WithIds[*LiveStream]
@@ -6,6 +6,7 @@ package member
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -26,6 +27,7 @@ type Member struct {
 type IMember[T any] interface {
 	List(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 }
 
 type Option func(*Member)
@@ -39,8 +41,12 @@ func NewMember(opts ...Option) IMember[youtube.Member] {
 }
 
 func (m *Member) Get() ([]*youtube.Member, error) {
+	return common.Collect(m.All())
+}
+
+func (m *Member) All() iter.Seq2[*youtube.Member, error] {
 	if err := m.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Member](err)
 	}
 	call := m.Service.Members.List(m.Parts)
 	if m.MemberChannelId != "" {
@@ -53,27 +59,29 @@ func (m *Member) Get() ([]*youtube.Member, error) {
 		call = call.Mode(m.Mode)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&m.Fields, call,
-		func(r *youtube.MemberListResponse) ([]*youtube.Member, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.MemberListResponse) (
+			[]*youtube.Member, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetMember,
 	)
 }
 
 func (m *Member) List(writer io.Writer) error {
-	members, err := m.Get()
-	if err != nil && members == nil {
-		return err
-	}
-
-	common.PrintList(
-		m.Output, members, writer, table.Row{"Channel ID", "Display Name"},
+	return common.PrintSeq(
+		&m.Fields, m.All(), writer, table.Row{"Channel ID", "Display Name"},
 		func(m *youtube.Member) table.Row {
-			return table.Row{m.Snippet.MemberDetails.ChannelId, m.Snippet.MemberDetails.DisplayName}
+			channelId := ""
+			displayName := ""
+			if m.Snippet != nil && m.Snippet.MemberDetails != nil {
+				channelId = m.Snippet.MemberDetails.ChannelId
+				displayName = m.Snippet.MemberDetails.DisplayName
+			}
+			return table.Row{channelId, displayName}
 		},
 	)
-	return err
 }
 
 func WithMemberChannelId(channelId string) Option {
@@ -97,6 +105,12 @@ func WithMode(mode string) Option {
 var (
 	WithMaxResults = common.WithMaxResults[*Member]
 	WithParts      = common.WithParts[*Member]
+	WithColumns    = common.WithColumns[*Member]
+	WithWhere      = common.WithWhere[*Member]
+	WithSortBy     = common.WithSortBy[*Member]
+	WithFieldMask  = common.WithFieldMask[*Member]
+	WithPageToken  = common.WithPageToken[*Member]
+	WithEnvelope   = common.WithEnvelope[*Member]
 	WithOutput     = common.WithOutput[*Member]
 	WithService    = common.WithService[*Member]
 )
@@ -41,6 +41,11 @@ func (m *MembershipsLevel) Get() ([]*youtube.MembershipsLevel, error) {
 		return nil, err
 	}
 	call := m.Service.MembershipsLevels.List(m.Parts)
+	call, err := common.ApplyMask(&m.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetMembershipsLevel, err)
@@ -55,17 +60,24 @@ func (m *MembershipsLevel) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		m.Output, levels, writer, table.Row{"ID", "Display Name"},
+	return common.PrintList(
+		&m.Fields, levels, writer, table.Row{"ID", "Display Name"},
 		func(ml *youtube.MembershipsLevel) table.Row {
-			return table.Row{ml.Id, ml.Snippet.LevelDetails.DisplayName}
+			displayName := ""
+			if ml.Snippet != nil && ml.Snippet.LevelDetails != nil {
+				displayName = ml.Snippet.LevelDetails.DisplayName
+			}
+			return table.Row{ml.Id, displayName}
 		},
 	)
-	return nil
 }
 
 var (
-	WithParts   = common.WithParts[*MembershipsLevel]
-	WithOutput  = common.WithOutput[*MembershipsLevel]
-	WithService = common.WithService[*MembershipsLevel]
+	WithParts     = common.WithParts[*MembershipsLevel]
+	WithColumns   = common.WithColumns[*MembershipsLevel]
+	WithWhere     = common.WithWhere[*MembershipsLevel]
+	WithSortBy    = common.WithSortBy[*MembershipsLevel]
+	WithFieldMask = common.WithFieldMask[*MembershipsLevel]
+	WithOutput    = common.WithOutput[*MembershipsLevel]
+	WithService   = common.WithService[*MembershipsLevel]
 )
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/lint"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"google.golang.org/api/youtube/v3"
 )
@@ -38,6 +40,7 @@ type IPlaylist[T any] interface {
 	Update(io.Writer) error
 	Delete(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 }
 
 type Option func(*Playlist)
@@ -51,8 +54,12 @@ func NewPlaylist(opts ...Option) IPlaylist[youtube.Playlist] {
 }
 
 func (p *Playlist) Get() ([]*youtube.Playlist, error) {
+	return common.Collect(p.All())
+}
+
+func (p *Playlist) All() iter.Seq2[*youtube.Playlist, error] {
 	if err := p.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Playlist](err)
 	}
 	call := p.Service.Playlists.List(p.Parts)
 
@@ -75,22 +82,19 @@ func (p *Playlist) Get() ([]*youtube.Playlist, error) {
 		call = call.OnBehalfOfContentOwnerChannel(p.OnBehalfOfContentOwnerChannel)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&p.Fields, call,
-		func(r *youtube.PlaylistListResponse) ([]*youtube.Playlist, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.PlaylistListResponse) (
+			[]*youtube.Playlist, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetPlaylist,
 	)
 }
 
 func (p *Playlist) List(writer io.Writer) error {
-	playlists, err := p.Get()
-	if err != nil && playlists == nil {
-		return err
-	}
-
-	common.PrintList(
-		p.Output, playlists, writer, table.Row{"ID", "Channel ID", "Title"},
+	return common.PrintSeq(
+		&p.Fields, p.All(), writer, table.Row{"ID", "Channel ID", "Title"},
 		func(pl *youtube.Playlist) table.Row {
 			channelId := ""
 			title := ""
@@ -101,10 +105,12 @@ func (p *Playlist) List(writer io.Writer) error {
 			return table.Row{pl.Id, channelId, title}
 		},
 	)
-	return err
 }
 
 func (p *Playlist) Insert(writer io.Writer) error {
+	if err := p.lint(); err != nil {
+		return errors.Join(errInsertPlaylist, err)
+	}
 	if err := p.EnsureService(); err != nil {
 		return err
 	}
@@ -138,6 +144,9 @@ func (p *Playlist) Insert(writer io.Writer) error {
 }
 
 func (p *Playlist) Update(writer io.Writer) error {
+	if err := p.lint(); err != nil {
+		return errors.Join(errUpdatePlaylist, err)
+	}
 	if err := p.EnsureService(); err != nil {
 		return err
 	}
@@ -179,6 +188,13 @@ func (p *Playlist) Update(writer io.Writer) error {
 	return nil
 }
 
+// lint checks the metadata of p against the API limits before any call.
+func (p *Playlist) lint() error {
+	return lint.Check(
+		lint.Metadata{Title: p.Title, Description: p.Description, Tags: p.Tags},
+	)
+}
+
 func (p *Playlist) Delete(writer io.Writer) error {
 	if err := p.EnsureService(); err != nil {
 		return err
@@ -244,6 +260,12 @@ func WithOnBehalfOfContentOwnerChannel(channel string) Option {
 
 var (
 	WithParts      = common.WithParts[*Playlist]
+	WithColumns    = common.WithColumns[*Playlist]
+	WithWhere      = common.WithWhere[*Playlist]
+	WithSortBy     = common.WithSortBy[*Playlist]
+	WithFieldMask  = common.WithFieldMask[*Playlist]
+	WithPageToken  = common.WithPageToken[*Playlist]
+	WithEnvelope   = common.WithEnvelope[*Playlist]
 	WithOutput     = common.WithOutput[*Playlist]
 	WithService    = common.WithService[*Playlist]
 	WithIds        = common.WithIds[*Playlist]
@@ -373,6 +373,17 @@ func TestPlaylist_Insert(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "insert playlist invalid title",
+			opts: []Option{
+				WithTitle(strings.Repeat("a", 101)),
+				WithPrivacy("public"),
+			},
+			verify: func(r *http.Request) {
+				t.Errorf("invalid metadata should not reach the API: %s", r.Method)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
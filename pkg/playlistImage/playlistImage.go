@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"os"
 
 	"github.com/eat-pray-ai/yutu/pkg"
@@ -36,6 +37,7 @@ type PlaylistImage struct {
 
 type IPlaylistImage[T any] interface {
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	List(io.Writer) error
 	Insert(io.Writer) error
 	Update(io.Writer) error
@@ -53,8 +55,12 @@ func NewPlaylistImage(opts ...Option) IPlaylistImage[youtube.PlaylistImage] {
 }
 
 func (pi *PlaylistImage) Get() ([]*youtube.PlaylistImage, error) {
+	return common.Collect(pi.All())
+}
+
+func (pi *PlaylistImage) All() iter.Seq2[*youtube.PlaylistImage, error] {
 	if err := pi.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.PlaylistImage](err)
 	}
 	call := pi.Service.PlaylistImages.List()
 	call = call.Part(pi.Parts...)
@@ -68,28 +74,30 @@ func (pi *PlaylistImage) Get() ([]*youtube.PlaylistImage, error) {
 		call = call.OnBehalfOfContentOwnerChannel(pi.OnBehalfOfContentOwnerChannel)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&pi.Fields, call,
-		func(r *youtube.PlaylistImageListResponse) ([]*youtube.PlaylistImage, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.PlaylistImageListResponse) (
+			[]*youtube.PlaylistImage, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetPlaylistImage,
 	)
 }
 
 func (pi *PlaylistImage) List(writer io.Writer) error {
-	playlistImages, err := pi.Get()
-	if err != nil && playlistImages == nil {
-		return err
-	}
-
-	common.PrintList(
-		pi.Output, playlistImages, writer,
+	return common.PrintSeq(
+		&pi.Fields, pi.All(), writer,
 		table.Row{"ID", "Kind", "Playlist ID", "Type"},
 		func(img *youtube.PlaylistImage) table.Row {
-			return table.Row{img.Id, img.Kind, img.Snippet.PlaylistId, img.Snippet.Type}
+			playlistId := ""
+			imageType := ""
+			if img.Snippet != nil {
+				playlistId = img.Snippet.PlaylistId
+				imageType = img.Snippet.Type
+			}
+			return table.Row{img.Id, img.Kind, playlistId, imageType}
 		},
 	)
-	return err
 }
 
 func (pi *PlaylistImage) Insert(writer io.Writer) error {
@@ -252,6 +260,12 @@ func WithOnBehalfOfContentOwnerChannel(onBehalfOfContentOwnerChannel string) Opt
 
 var (
 	WithParts      = common.WithParts[*PlaylistImage]
+	WithColumns    = common.WithColumns[*PlaylistImage]
+	WithWhere      = common.WithWhere[*PlaylistImage]
+	WithSortBy     = common.WithSortBy[*PlaylistImage]
+	WithFieldMask  = common.WithFieldMask[*PlaylistImage]
+	WithPageToken  = common.WithPageToken[*PlaylistImage]
+	WithEnvelope   = common.WithEnvelope[*PlaylistImage]
 	WithOutput     = common.WithOutput[*PlaylistImage]
 	WithService    = common.WithService[*PlaylistImage]
 	WithIds        = common.WithIds[*PlaylistImage]
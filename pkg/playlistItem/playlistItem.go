@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -39,6 +40,7 @@ type IPlaylistItem[T any] interface {
 	Update(io.Writer) error
 	Delete(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 }
 
 type Option func(*PlaylistItem)
@@ -52,8 +54,12 @@ func NewPlaylistItem(opts ...Option) IPlaylistItem[youtube.PlaylistItem] {
 }
 
 func (pi *PlaylistItem) Get() ([]*youtube.PlaylistItem, error) {
+	return common.Collect(pi.All())
+}
+
+func (pi *PlaylistItem) All() iter.Seq2[*youtube.PlaylistItem, error] {
 	if err := pi.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.PlaylistItem](err)
 	}
 	call := pi.Service.PlaylistItems.List(pi.Parts)
 	if len(pi.Ids) > 0 {
@@ -69,22 +75,19 @@ func (pi *PlaylistItem) Get() ([]*youtube.PlaylistItem, error) {
 		call = call.VideoId(pi.VideoId)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&pi.Fields, call,
-		func(r *youtube.PlaylistItemListResponse) ([]*youtube.PlaylistItem, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.PlaylistItemListResponse) (
+			[]*youtube.PlaylistItem, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetPlaylistItem,
 	)
 }
 
 func (pi *PlaylistItem) List(writer io.Writer) error {
-	playlistItems, err := pi.Get()
-	if err != nil && playlistItems == nil {
-		return err
-	}
-
-	common.PrintList(
-		pi.Output, playlistItems, writer,
+	return common.PrintSeq(
+		&pi.Fields, pi.All(), writer,
 		table.Row{"ID", "Title", "Kind", "Resource ID"},
 		func(item *youtube.PlaylistItem) table.Row {
 			title := ""
@@ -107,7 +110,6 @@ func (pi *PlaylistItem) List(writer io.Writer) error {
 			return table.Row{item.Id, title, kind, resourceId}
 		},
 	)
-	return err
 }
 
 func (pi *PlaylistItem) Insert(writer io.Writer) error {
@@ -283,6 +285,12 @@ func WithPrivacy(privacy string) Option {
 
 var (
 	WithParts      = common.WithParts[*PlaylistItem]
+	WithColumns    = common.WithColumns[*PlaylistItem]
+	WithWhere      = common.WithWhere[*PlaylistItem]
+	WithSortBy     = common.WithSortBy[*PlaylistItem]
+	WithFieldMask  = common.WithFieldMask[*PlaylistItem]
+	WithPageToken  = common.WithPageToken[*PlaylistItem]
+	WithEnvelope   = common.WithEnvelope[*PlaylistItem]
 	WithOutput     = common.WithOutput[*PlaylistItem]
 	WithService    = common.WithService[*PlaylistItem]
 	WithIds        = common.WithIds[*PlaylistItem]
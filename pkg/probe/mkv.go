@@ -0,0 +1,226 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// Matroska element IDs, with their length markers.
+const (
+	mkvSegment       = 0x18538067
+	mkvInfo          = 0x1549a966
+	mkvTimecodeScale = 0x2ad7b1
+	mkvDuration      = 0x4489
+	mkvDateUTC       = 0x4461
+	mkvTracks        = 0x1654ae6b
+	mkvTrackEntry    = 0xae
+	mkvTrackType     = 0x83
+	mkvCodecID       = 0x86
+	mkvVideo         = 0xe0
+	mkvPixelWidth    = 0xb0
+	mkvPixelHeight   = 0xba
+	mkvCluster       = 0x1f43b675
+)
+
+// mkvEpoch is where Matroska DateUTC counts nanoseconds from.
+var mkvEpoch = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// probeMatroska reads the Info and Tracks elements of the first segment.
+// Matroska keeps no per-track duration, so every track gets the segment's.
+func probeMatroska(r io.ReadSeeker, size int64) (*Info, error) {
+	info := &Info{Container: ContainerMatroska}
+	scale := uint64(time.Millisecond)
+	var duration float64
+	hasDuration := false
+
+	err := walkElements(
+		r, 0, size, func(id uint32, body, end int64) error {
+			if id != mkvSegment {
+				return nil
+			}
+			return walkElements(
+				r, body, end, func(id uint32, body, end int64) error {
+					switch id {
+					case mkvInfo:
+						return walkElements(
+							r, body, end, func(id uint32, body, end int64) error {
+								b, err := readBody(r, body, end, 8)
+								if err != nil {
+									return err
+								}
+								switch id {
+								case mkvTimecodeScale:
+									scale = readUint(b)
+								case mkvDuration:
+									duration = readFloat(b)
+									hasDuration = true
+								case mkvDateUTC:
+									info.Created = mkvEpoch.Add(time.Duration(int64(readUint(b))))
+								}
+								return nil
+							},
+						)
+					case mkvTracks:
+						return walkElements(
+							r, body, end, func(id uint32, body, end int64) error {
+								if id != mkvTrackEntry {
+									return nil
+								}
+								t := &Track{Type: TrackOther}
+								info.Tracks = append(info.Tracks, t)
+								return parseTrackEntry(r, body, end, t)
+							},
+						)
+					}
+					return nil
+				},
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Live muxers and unfinished recordings leave the Duration element out.
+	info.Duration = time.Duration(duration * float64(scale))
+	for _, t := range info.Tracks {
+		t.Duration = info.Duration
+		t.UnknownDuration = !hasDuration
+	}
+	return info, nil
+}
+
+func parseTrackEntry(r io.ReadSeeker, body, end int64, t *Track) error {
+	return walkElements(
+		r, body, end, func(id uint32, body, end int64) error {
+			switch id {
+			case mkvTrackType:
+				b, err := readBody(r, body, end, 8)
+				if err != nil {
+					return err
+				}
+				switch readUint(b) {
+				case 1:
+					t.Type = TrackVideo
+				case 2:
+					t.Type = TrackAudio
+				case 0x11:
+					t.Type = TrackSubtitle
+				}
+			case mkvCodecID:
+				b, err := readBody(r, body, end, 64)
+				if err != nil {
+					return err
+				}
+				t.Codec = string(b)
+			case mkvVideo:
+				return walkElements(
+					r, body, end, func(id uint32, body, end int64) error {
+						b, err := readBody(r, body, end, 8)
+						if err != nil {
+							return err
+						}
+						switch id {
+						case mkvPixelWidth:
+							t.Width = int(readUint(b))
+						case mkvPixelHeight:
+							t.Height = int(readUint(b))
+						}
+						return nil
+					},
+				)
+			}
+			return nil
+		},
+	)
+}
+
+// walkElements calls visit with the ID, body offset and end offset of every
+// EBML element between start and end. An element of unknown size runs to
+// end; a cluster of unknown size cannot be skipped, so the walk stops there.
+func walkElements(
+	r io.ReadSeeker, start, end int64,
+	visit func(id uint32, body, end int64) error,
+) error {
+	for off := start; off < end; {
+		if _, err := r.Seek(off, io.SeekStart); err != nil {
+			return err
+		}
+		id, n, err := readVint(r, 4, false)
+		if err != nil {
+			return err
+		}
+		size, m, err := readVint(r, 8, true)
+		if err != nil {
+			return err
+		}
+		body := off + int64(n+m)
+		next := end
+		if size != math.MaxUint64 {
+			if size > uint64(end-body) {
+				return fmt.Errorf("element %x at %d: bad size %d", id, off, size)
+			}
+			next = body + int64(size)
+		} else if id == mkvCluster {
+			return nil
+		}
+		if err := visit(uint32(id), body, next); err != nil {
+			return err
+		}
+		off = next
+	}
+	return nil
+}
+
+// readVint reads an EBML variable-length integer of at most maxLen bytes.
+// IDs keep their length marker; sizes drop it and report all ones, meaning
+// unknown, as math.MaxUint64.
+func readVint(r io.Reader, maxLen int, size bool) (uint64, int, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:1]); err != nil {
+		return 0, 0, err
+	}
+	n := bits.LeadingZeros8(b[0]) + 1
+	if n > maxLen {
+		return 0, 0, fmt.Errorf("bad vint %#x", b[0])
+	}
+	if _, err := io.ReadFull(r, b[1:n]); err != nil {
+		return 0, 0, err
+	}
+	v := uint64(b[0])
+	if size {
+		v &= 0xff >> n
+	}
+	for _, c := range b[1:n] {
+		v = v<<8 | uint64(c)
+	}
+	if size && v == 1<<(7*n)-1 {
+		return math.MaxUint64, n, nil
+	}
+	return v, n, nil
+}
+
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func readFloat(b []byte) float64 {
+	switch len(b) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
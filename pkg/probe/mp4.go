@@ -0,0 +1,235 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// mp4Epoch is where ISO-BMFF creation times count seconds from.
+var mp4Epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var errNoMoov = errors.New("no moov box")
+
+// probeMP4 reads the moov box of an ISO-BMFF file: mvhd for the creation
+// time and duration, and every trak for its handler, codec, duration and
+// size.
+func probeMP4(r io.ReadSeeker, size int64) (*Info, error) {
+	info := &Info{Container: ContainerMP4}
+	found := false
+	err := walkBoxes(
+		r, 0, size, func(typ string, body, end int64) error {
+			if typ != "moov" {
+				return nil
+			}
+			found = true
+			return walkBoxes(
+				r, body, end, func(typ string, body, end int64) error {
+					switch typ {
+					case "mvhd":
+						return parseMvhd(r, body, end, info)
+					case "mvex":
+						info.Fragmented = true
+					case "trak":
+						t := &Track{Type: TrackOther}
+						if err := parseTrak(r, body, end, t); err != nil {
+							return err
+						}
+						info.Tracks = append(info.Tracks, t)
+					}
+					return nil
+				},
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errNoMoov
+	}
+	return info, nil
+}
+
+func parseMvhd(r io.ReadSeeker, body, end int64, info *Info) error {
+	b, err := readBody(r, body, end, 32)
+	if err != nil {
+		return err
+	}
+	var created uint64
+	var timescale uint32
+	var duration uint64
+	switch {
+	case len(b) >= 32 && b[0] == 1:
+		created = binary.BigEndian.Uint64(b[4:12])
+		timescale = binary.BigEndian.Uint32(b[20:24])
+		duration = binary.BigEndian.Uint64(b[24:32])
+	case len(b) >= 20 && b[0] == 0:
+		created = uint64(binary.BigEndian.Uint32(b[4:8]))
+		timescale = binary.BigEndian.Uint32(b[12:16])
+		duration = uint64(binary.BigEndian.Uint32(b[16:20]))
+		if duration == math.MaxUint32 {
+			duration = math.MaxUint64
+		}
+	default:
+		return fmt.Errorf("mvhd: %d bytes", len(b))
+	}
+	if created != 0 {
+		info.Created = mp4Epoch.Add(time.Duration(created) * time.Second)
+	}
+	info.Duration = scaled(duration, timescale)
+	return nil
+}
+
+func parseTrak(r io.ReadSeeker, body, end int64, t *Track) error {
+	var width, height int
+	err := walkBoxes(
+		r, body, end, func(typ string, body, end int64) error {
+			switch typ {
+			case "tkhd":
+				b, err := readBody(r, body, end, 96)
+				if err != nil {
+					return err
+				}
+				// Width and height are 16.16 fixed point after the matrix.
+				at := 76
+				if len(b) > 0 && b[0] == 1 {
+					at = 88
+				}
+				if len(b) >= at+8 {
+					width = int(binary.BigEndian.Uint32(b[at:at+4]) >> 16)
+					height = int(binary.BigEndian.Uint32(b[at+4:at+8]) >> 16)
+				}
+			case "mdia":
+				return parseMdia(r, body, end, t)
+			}
+			return nil
+		},
+	)
+	if t.Type == TrackVideo {
+		t.Width, t.Height = width, height
+	}
+	return err
+}
+
+func parseMdia(r io.ReadSeeker, body, end int64, t *Track) error {
+	return walkBoxes(
+		r, body, end, func(typ string, body, end int64) error {
+			switch typ {
+			case "mdhd":
+				b, err := readBody(r, body, end, 32)
+				if err != nil {
+					return err
+				}
+				var timescale uint32
+				var duration uint64
+				switch {
+				case len(b) >= 32 && b[0] == 1:
+					timescale = binary.BigEndian.Uint32(b[20:24])
+					duration = binary.BigEndian.Uint64(b[24:32])
+				case len(b) >= 20:
+					timescale = binary.BigEndian.Uint32(b[12:16])
+					duration = uint64(binary.BigEndian.Uint32(b[16:20]))
+					if duration == math.MaxUint32 {
+						duration = math.MaxUint64
+					}
+				}
+				t.Duration = scaled(duration, timescale)
+				t.UnknownDuration = duration == math.MaxUint64
+			case "hdlr":
+				b, err := readBody(r, body, end, 12)
+				if err != nil {
+					return err
+				}
+				if len(b) >= 12 {
+					switch string(b[8:12]) {
+					case "vide":
+						t.Type = TrackVideo
+					case "soun":
+						t.Type = TrackAudio
+					case "sbtl", "subt", "text", "clcp":
+						t.Type = TrackSubtitle
+					}
+				}
+			case "minf", "stbl":
+				return parseMdia(r, body, end, t)
+			case "stsd":
+				// The first sample entry follows version, flags, entry count
+				// and its own size.
+				b, err := readBody(r, body, end, 16)
+				if err != nil {
+					return err
+				}
+				if len(b) >= 16 {
+					t.Codec = string(b[12:16])
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// walkBoxes calls visit with the type, body offset and end offset of every
+// box between start and end.
+func walkBoxes(
+	r io.ReadSeeker, start, end int64,
+	visit func(typ string, body, end int64) error,
+) error {
+	var hdr [16]byte
+	for off := start; off+8 <= end; {
+		if _, err := r.Seek(off, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(r, hdr[:8]); err != nil {
+			return err
+		}
+		typ := string(hdr[4:8])
+		size := int64(binary.BigEndian.Uint32(hdr[:4]))
+		body := off + 8
+		switch size {
+		case 0:
+			size = end - off
+		case 1:
+			if _, err := io.ReadFull(r, hdr[8:]); err != nil {
+				return err
+			}
+			size = int64(binary.BigEndian.Uint64(hdr[8:]))
+			body += 8
+		}
+		if size < body-off || size > end-off {
+			return fmt.Errorf("box %q at %d: bad size %d", typ, off, size)
+		}
+		if err := visit(typ, body, off+size); err != nil {
+			return err
+		}
+		off += size
+	}
+	return nil
+}
+
+// readBody reads up to limit bytes of a box body.
+func readBody(r io.ReadSeeker, body, end int64, limit int64) ([]byte, error) {
+	if _, err := r.Seek(body, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, min(end-body, limit))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// scaled converts a duration in timescale units, where all ones means
+// unknown.
+func scaled(duration uint64, timescale uint32) time.Duration {
+	if timescale == 0 || duration == math.MaxUint64 {
+		return 0
+	}
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+}
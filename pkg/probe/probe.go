@@ -0,0 +1,168 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package probe reads recording date, duration, resolution and codecs from
+// ISO-BMFF (MP4, MOV) and Matroska (MKV, WebM) containers without decoding
+// any media.
+package probe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ContainerMP4      = "mp4"
+	ContainerMatroska = "matroska"
+
+	TrackVideo    = "video"
+	TrackAudio    = "audio"
+	TrackSubtitle = "subtitle"
+	TrackOther    = "other"
+)
+
+var (
+	errUnknownContainer = errors.New("unknown container format")
+	errMalformed        = errors.New("malformed container")
+)
+
+// videoCodecs and audioCodecs are the codecs YouTube accepts, as ISO-BMFF
+// sample entry types and Matroska codec IDs. Matroska IDs ending in "/"
+// match by prefix.
+var (
+	videoCodecs = []string{
+		"avc1", "avc3", "hvc1", "hev1", "mp4v", "av01", "vp08", "vp09",
+		"apch", "apcn", "apcs", "apco", "ap4h", "ap4x", "mjpa", "mjpb", "jpeg",
+		"V_MPEG4/ISO/AVC", "V_MPEGH/ISO/HEVC", "V_MPEG4/ISO/ASP", "V_VP8",
+		"V_VP9", "V_AV1", "V_PRORES", "V_MJPEG",
+	}
+	audioCodecs = []string{
+		"mp4a", "ac-3", "ec-3", "Opus", "fLaC", "alac", "lpcm", "sowt", "twos",
+		"in24", "ipcm", ".mp3",
+		"A_AAC", "A_AAC/", "A_OPUS", "A_VORBIS", "A_AC3", "A_EAC3", "A_FLAC",
+		"A_ALAC", "A_MPEG/L3", "A_PCM/",
+	}
+)
+
+// Track describes one track of a container.
+type Track struct {
+	Type     string        `yaml:"type" json:"type"`
+	Codec    string        `yaml:"codec" json:"codec"`
+	Duration time.Duration `yaml:"duration" json:"duration"`
+	Width    int           `yaml:"width,omitempty" json:"width,omitempty"`
+	Height   int           `yaml:"height,omitempty" json:"height,omitempty"`
+	// UnknownDuration is set when the container marks the duration as not
+	// known, as live and unfinished recordings do, rather than zero.
+	UnknownDuration bool `yaml:"unknown_duration,omitempty" json:"unknown_duration,omitempty"`
+}
+
+// Info is what Probe found in a container. Zero values mean the container
+// did not say.
+type Info struct {
+	Container  string        `yaml:"container" json:"container"`
+	Created    time.Time     `yaml:"created" json:"created"`
+	Duration   time.Duration `yaml:"duration" json:"duration"`
+	Width      int           `yaml:"width,omitempty" json:"width,omitempty"`
+	Height     int           `yaml:"height,omitempty" json:"height,omitempty"`
+	Tracks     []*Track      `yaml:"tracks" json:"tracks"`
+	Fragmented bool          `yaml:"fragmented,omitempty" json:"fragmented,omitempty"`
+}
+
+// Probe reads the container in r, which holds size bytes. It only reads
+// the headers it needs and seeks over media data.
+func Probe(r io.ReadSeeker, size int64) (*Info, error) {
+	head := make([]byte, 12)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, errors.Join(errMalformed, err)
+	}
+	head = head[:n]
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var info *Info
+	switch {
+	case len(head) >= 8 && slices.Contains(
+		[]string{"ftyp", "moov", "mdat", "wide", "free", "skip"},
+		string(head[4:8]),
+	):
+		info, err = probeMP4(r, size)
+	case bytes.HasPrefix(head, []byte{0x1a, 0x45, 0xdf, 0xa3}):
+		info, err = probeMatroska(r, size)
+	default:
+		return nil, errUnknownContainer
+	}
+	if err != nil {
+		return nil, errors.Join(errMalformed, err)
+	}
+
+	for _, t := range info.Tracks {
+		if t.Type == TrackVideo && info.Width == 0 {
+			info.Width, info.Height = t.Width, t.Height
+		}
+		info.Duration = max(info.Duration, t.Duration)
+	}
+	return info, nil
+}
+
+// Resolution formats the size of the first video track, or returns "".
+func (i *Info) Resolution() string {
+	if i.Width == 0 || i.Height == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", i.Width, i.Height)
+}
+
+// Warnings lists what would make YouTube reject the file or fail to
+// process it.
+func (i *Info) Warnings() []string {
+	var warnings []string
+	hasVideo := false
+	for n, t := range i.Tracks {
+		switch t.Type {
+		case TrackVideo:
+			hasVideo = true
+			if !supported(videoCodecs, t.Codec) {
+				warnings = append(
+					warnings,
+					fmt.Sprintf("track %d: unsupported video codec %q", n+1, t.Codec),
+				)
+			}
+		case TrackAudio:
+			if !supported(audioCodecs, t.Codec) {
+				warnings = append(
+					warnings,
+					fmt.Sprintf("track %d: unsupported audio codec %q", n+1, t.Codec),
+				)
+			}
+		}
+		// Fragmented files carry their samples outside the header, and live
+		// recordings may not know their length, so an empty track there says
+		// nothing.
+		if !i.Fragmented && !t.UnknownDuration && t.Duration == 0 &&
+			(t.Type == TrackVideo || t.Type == TrackAudio) {
+			warnings = append(
+				warnings, fmt.Sprintf("track %d: zero-length %s track", n+1, t.Type),
+			)
+		}
+	}
+	if !hasVideo {
+		warnings = append(warnings, "no video track")
+	}
+	return warnings
+}
+
+func supported(codecs []string, codec string) bool {
+	for _, c := range codecs {
+		if c == codec || strings.HasSuffix(c, "/") && strings.HasPrefix(codec, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func box(typ string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(b, typ...), body...)
+}
+
+func u32(v ...uint32) []byte {
+	var b []byte
+	for _, x := range v {
+		b = binary.BigEndian.AppendUint32(b, x)
+	}
+	return b
+}
+
+func mp4Track(handler, codec string, duration uint32, width, height uint32) []byte {
+	tkhd := append(make([]byte, 76), u32(width<<16, height<<16)...)
+	return box(
+		"trak",
+		box("tkhd", tkhd),
+		box(
+			"mdia",
+			box("mdhd", u32(0, 0, 0, 1000, duration)),
+			box("hdlr", u32(0, 0), []byte(handler), make([]byte, 12)),
+			box(
+				"minf", box(
+					"stbl", box("stsd", u32(0, 1, 16), []byte(codec), make([]byte, 8)),
+				),
+			),
+		),
+	)
+}
+
+func ebml(id uint32, payload ...[]byte) []byte {
+	var b []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if c := byte(id >> shift); c != 0 || len(b) > 0 {
+			b = append(b, c)
+		}
+	}
+	body := bytes.Join(payload, nil)
+	size := binary.BigEndian.AppendUint64(nil, uint64(len(body)))
+	size[0] = 0x01
+	return append(append(b, size...), body...)
+}
+
+func TestProbe_MP4(t *testing.T) {
+	created := time.Date(2026, 5, 4, 12, 30, 0, 0, time.UTC)
+	file := bytes.Join(
+		[][]byte{
+			box("ftyp", []byte("isom"), u32(0)),
+			box("mdat", make([]byte, 64)),
+			box(
+				"moov",
+				box("mvhd", u32(0, uint32(created.Sub(mp4Epoch).Seconds()), 0, 600, 6000)),
+				mp4Track("vide", "avc1", 10000, 1920, 1080),
+				mp4Track("soun", "mp4a", 9500, 0, 0),
+			),
+		}, nil,
+	)
+
+	info, err := Probe(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	if info.Container != ContainerMP4 {
+		t.Errorf("Container = %q", info.Container)
+	}
+	if !info.Created.Equal(created) {
+		t.Errorf("Created = %v, want %v", info.Created, created)
+	}
+	if info.Duration != 10*time.Second {
+		t.Errorf("Duration = %v, want 10s", info.Duration)
+	}
+	if info.Resolution() != "1920x1080" {
+		t.Errorf("Resolution() = %q", info.Resolution())
+	}
+	if len(info.Tracks) != 2 || info.Tracks[1].Type != TrackAudio ||
+		info.Tracks[1].Codec != "mp4a" || info.Tracks[1].Width != 0 {
+		t.Errorf("Tracks = %+v", info.Tracks)
+	}
+	if w := info.Warnings(); len(w) != 0 {
+		t.Errorf("Warnings() = %v", w)
+	}
+}
+
+func TestProbe_Matroska(t *testing.T) {
+	created := time.Date(2026, 5, 4, 12, 30, 0, 0, time.UTC)
+	date := binary.BigEndian.AppendUint64(nil, uint64(created.Sub(mkvEpoch)))
+	duration := binary.BigEndian.AppendUint64(nil, math.Float64bits(12500))
+	file := bytes.Join(
+		[][]byte{
+			ebml(0x1a45dfa3, ebml(0x4282, []byte("webm"))),
+			ebml(
+				mkvSegment,
+				ebml(
+					mkvInfo, ebml(mkvTimecodeScale, []byte{0x0f, 0x42, 0x40}),
+					ebml(mkvDuration, duration), ebml(mkvDateUTC, date),
+				),
+				ebml(
+					mkvTracks,
+					ebml(
+						mkvTrackEntry, ebml(mkvTrackType, []byte{1}),
+						ebml(mkvCodecID, []byte("V_VP9")),
+						ebml(
+							mkvVideo, ebml(mkvPixelWidth, []byte{0x05, 0x00}),
+							ebml(mkvPixelHeight, []byte{0x02, 0xd0}),
+						),
+					),
+					ebml(
+						mkvTrackEntry, ebml(mkvTrackType, []byte{2}),
+						ebml(mkvCodecID, []byte("A_DTS")),
+					),
+				),
+				// A live recording ends in a cluster of unknown size.
+				[]byte{0x1f, 0x43, 0xb6, 0x75, 0xff}, make([]byte, 32),
+			),
+		}, nil,
+	)
+
+	info, err := Probe(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	if info.Container != ContainerMatroska {
+		t.Errorf("Container = %q", info.Container)
+	}
+	if !info.Created.Equal(created) {
+		t.Errorf("Created = %v, want %v", info.Created, created)
+	}
+	if info.Duration != 12500*time.Millisecond {
+		t.Errorf("Duration = %v, want 12.5s", info.Duration)
+	}
+	if info.Resolution() != "1280x720" {
+		t.Errorf("Resolution() = %q", info.Resolution())
+	}
+	want := []string{`track 2: unsupported audio codec "A_DTS"`}
+	if w := info.Warnings(); !slices.Equal(w, want) {
+		t.Errorf("Warnings() = %v, want %v", w, want)
+	}
+}
+
+func TestProbe_UnknownDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		file []byte
+	}{
+		{
+			name: "matroska without duration",
+			file: bytes.Join(
+				[][]byte{
+					ebml(0x1a45dfa3, ebml(0x4282, []byte("webm"))),
+					ebml(
+						mkvSegment,
+						ebml(mkvInfo, ebml(mkvTimecodeScale, []byte{0x0f, 0x42, 0x40})),
+						ebml(
+							mkvTracks,
+							ebml(
+								mkvTrackEntry, ebml(mkvTrackType, []byte{1}),
+								ebml(mkvCodecID, []byte("V_VP9")),
+							),
+							ebml(
+								mkvTrackEntry, ebml(mkvTrackType, []byte{2}),
+								ebml(mkvCodecID, []byte("A_OPUS")),
+							),
+						),
+					),
+				}, nil,
+			),
+		},
+		{
+			name: "mp4 with unknown track duration",
+			file: bytes.Join(
+				[][]byte{
+					box("ftyp", []byte("isom"), u32(0)),
+					box(
+						"moov",
+						box("mvhd", u32(0, 0, 0, 600, math.MaxUint32)),
+						mp4Track("vide", "avc1", math.MaxUint32, 1920, 1080),
+					),
+				}, nil,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				info, err := Probe(bytes.NewReader(tt.file), int64(len(tt.file)))
+				if err != nil {
+					t.Fatalf("Probe() error = %v", err)
+				}
+				for _, track := range info.Tracks {
+					if !track.UnknownDuration || track.Duration != 0 {
+						t.Errorf("Tracks = %+v, want unknown durations", info.Tracks)
+					}
+				}
+				if w := info.Warnings(); len(w) != 0 {
+					t.Errorf("Warnings() = %v", w)
+				}
+			},
+		)
+	}
+}
+
+func TestInfo_Warnings(t *testing.T) {
+	tests := []struct {
+		name string
+		info *Info
+		want []string
+	}{
+		{
+			name: "zero-length track",
+			info: &Info{
+				Tracks: []*Track{
+					{Type: TrackVideo, Codec: "avc1"},
+					{Type: TrackAudio, Codec: "mp4a", Duration: time.Second},
+				},
+			},
+			want: []string{"track 1: zero-length video track"},
+		},
+		{
+			name: "fragmented",
+			info: &Info{
+				Fragmented: true,
+				Tracks:     []*Track{{Type: TrackVideo, Codec: "hvc1"}},
+			},
+		},
+		{
+			name: "no video",
+			info: &Info{
+				Tracks: []*Track{{Type: TrackAudio, Codec: "A_PCM/INT/LIT", Duration: 1}},
+			},
+			want: []string{"no video track"},
+		},
+		{
+			name: "unsupported video codec",
+			info: &Info{
+				Tracks: []*Track{{Type: TrackVideo, Codec: "cvid", Duration: 1}},
+			},
+			want: []string{`track 1: unsupported video codec "cvid"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := tt.info.Warnings(); !slices.Equal(got, tt.want) {
+					t.Errorf("Warnings() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestProbe_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file []byte
+		want error
+	}{
+		{name: "unknown", file: []byte("RIFF....AVI LIST"), want: errUnknownContainer},
+		{name: "no moov", file: box("ftyp", []byte("isom")), want: errMalformed},
+		{
+			name: "truncated box",
+			file: append(box("ftyp", []byte("isom")), append(u32(100), "moov"...)...),
+			want: errMalformed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				_, err := Probe(bytes.NewReader(tt.file), int64(len(tt.file)))
+				if !errors.Is(err, tt.want) {
+					t.Errorf("Probe() error = %v, want %v", err, tt.want)
+				}
+			},
+		)
+	}
+}
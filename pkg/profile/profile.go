@@ -0,0 +1,354 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package profile keeps named accounts, each with its own credential, token
+// cache, default channel and content owner, so that one yutu can manage
+// several channels. Profiles are stored in a file under the root directory.
+// Activating a profile points YUTU_CREDENTIAL and YUTU_CACHE_TOKEN at its
+// files, which also gives it its own quota ledger and response cache.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	// FileName is the profile file kept in the root directory.
+	FileName = "yutu.profiles.json"
+	// Env names the active profile when no --profile flag is given.
+	Env = "YUTU_PROFILE"
+	// Dir holds the token caches of profiles added without one.
+	Dir = "yutu.profiles"
+)
+
+var (
+	errReadProfiles  = errors.New("failed to read profiles")
+	errWriteProfiles = errors.New("failed to write profiles")
+	errInvalidName   = errors.New(
+		"profile name may only contain letters, digits, '-' and '_'",
+	)
+	errNotFound = errors.New("profile not found")
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Account is one named profile.
+type Account struct {
+	Name                          string `yaml:"name" json:"name"`
+	Credential                    string `yaml:"credential" json:"credential,omitempty"`
+	CacheToken                    string `yaml:"cache_token" json:"cache_token,omitempty"`
+	ChannelId                     string `yaml:"channel_id" json:"channel_id,omitempty"`
+	OnBehalfOfContentOwner        string `yaml:"on_behalf_of_content_owner" json:"on_behalf_of_content_owner,omitempty"`
+	OnBehalfOfContentOwnerChannel string `yaml:"on_behalf_of_content_owner_channel" json:"on_behalf_of_content_owner_channel,omitempty"`
+	Current                       bool   `yaml:"current" json:"current,omitempty"`
+
+	// explicit is set when --profile or YUTU_PROFILE chose the profile.
+	explicit bool
+}
+
+// Config is the content of the profile file.
+type Config struct {
+	Current  string     `yaml:"current" json:"current,omitempty"`
+	Accounts []*Account `yaml:"profiles" json:"profiles"`
+}
+
+// Find returns the profile called name, or nil.
+func (c *Config) Find(name string) *Account {
+	for _, a := range c.Accounts {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Load returns the stored profiles. A missing file has none.
+func Load() (*Config, error) {
+	c := &Config{Accounts: []*Account{}}
+	data, err := pkg.Root.ReadFile(FileName)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, errors.Join(errReadProfiles, err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, errors.Join(errReadProfiles, err)
+	}
+	if c.Accounts == nil {
+		c.Accounts = []*Account{}
+	}
+	return c, nil
+}
+
+func save(c *Config) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Join(errWriteProfiles, err)
+	}
+	if err := pkg.Root.WriteFile(FileName, data, 0600); err != nil {
+		return errors.Join(errWriteProfiles, err)
+	}
+	return nil
+}
+
+// Active returns the profile called name, else the one YUTU_PROFILE names,
+// else the current one. It returns nil when none of them is set, in which
+// case yutu behaves as if there were no profiles.
+func Active(name string) (*Account, error) {
+	if name == "" {
+		name = os.Getenv(Env)
+	}
+	explicit := name != ""
+	c, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" {
+		return nil, nil
+	}
+	a := c.Find(name)
+	if a == nil {
+		return nil, failure.New(
+			failure.InvalidArgument, fmt.Errorf("%w: %s", errNotFound, name),
+		)
+	}
+	a.explicit = explicit
+	return a, nil
+}
+
+// Activate points YUTU_CREDENTIAL and YUTU_CACHE_TOKEN at the files of a.
+// Relative paths are resolved against the root directory. A profile chosen
+// with --profile or YUTU_PROFILE wins over variables the user exported; the
+// current profile only sets those left unset, and neither while
+// YUTU_CREDENTIAL_HELPER keeps the credentials, unless chosen explicitly.
+func (a *Account) Activate() error {
+	if a.Credential != "" && a.overrides("YUTU_CREDENTIAL") {
+		if err := os.Setenv("YUTU_CREDENTIAL", resolve(a.Credential)); err != nil {
+			return err
+		}
+	}
+	if !a.overrides("YUTU_CACHE_TOKEN") {
+		return nil
+	}
+	return os.Setenv("YUTU_CACHE_TOKEN", resolve(a.cacheToken()))
+}
+
+// overrides tells whether a may set the variable env.
+func (a *Account) overrides(env string) bool {
+	return a.explicit || os.Getenv(env) == "" && os.Getenv(auth.HelperEnv) == ""
+}
+
+// cacheToken returns the token cache of a, by default a file of its own.
+func (a *Account) cacheToken() string {
+	if a.CacheToken != "" {
+		return a.CacheToken
+	}
+	return filepath.ToSlash(filepath.Join(Dir, a.Name, "youtube.token.json"))
+}
+
+// resolve anchors a relative file path at the root directory, leaving
+// absolute paths and inline Base64 or JSON values as they are.
+func resolve(value string) string {
+	if !strings.HasSuffix(value, ".json") || filepath.IsAbs(value) ||
+		strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return value
+	}
+	return filepath.Join(*pkg.RootDir, value)
+}
+
+// Profile manages the stored profiles. The fields describe the profile to
+// add; the other methods only use Name.
+type Profile struct {
+	common.Fields
+	Name                          string `yaml:"name" json:"name"`
+	Credential                    string `yaml:"credential" json:"credential,omitempty"`
+	CacheToken                    string `yaml:"cache_token" json:"cache_token,omitempty"`
+	OnBehalfOfContentOwnerChannel string `yaml:"on_behalf_of_content_owner_channel" json:"on_behalf_of_content_owner_channel,omitempty"`
+}
+
+type IProfile interface {
+	Add(io.Writer) error
+	List(io.Writer) error
+	Remove(io.Writer) error
+	Use(io.Writer) error
+}
+
+type Option func(*Profile)
+
+func NewProfile(opts ...Option) IProfile {
+	p := &Profile{Fields: common.Fields{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Add stores the profile, replacing one with the same name. The first
+// profile added becomes the current one.
+func (p *Profile) Add(writer io.Writer) error {
+	if !validName.MatchString(p.Name) {
+		return failure.New(
+			failure.InvalidArgument, fmt.Errorf("%w: %q", errInvalidName, p.Name),
+		)
+	}
+	c, err := Load()
+	if err != nil {
+		return err
+	}
+
+	a := Account{
+		Name:                          p.Name,
+		Credential:                    p.Credential,
+		CacheToken:                    p.CacheToken,
+		ChannelId:                     p.ChannelId,
+		OnBehalfOfContentOwner:        p.OnBehalfOfContentOwner,
+		OnBehalfOfContentOwnerChannel: p.OnBehalfOfContentOwnerChannel,
+	}
+	if i := slices.IndexFunc(
+		c.Accounts, func(old *Account) bool { return old.Name == a.Name },
+	); i >= 0 {
+		c.Accounts[i] = &a
+	} else {
+		c.Accounts = append(c.Accounts, &a)
+	}
+	if c.Current == "" {
+		c.Current = a.Name
+	}
+	if err := save(c); err != nil {
+		return err
+	}
+
+	common.PrintResult(
+		p.Output, &a, writer, "Profile %s added, token cache %s\n",
+		a.Name, a.cacheToken(),
+	)
+	return nil
+}
+
+// List prints the stored profiles, marking the current one.
+func (p *Profile) List(writer io.Writer) error {
+	c, err := Load()
+	if err != nil {
+		return err
+	}
+	for _, a := range c.Accounts {
+		a.Current = a.Name == c.Current
+	}
+
+	return common.PrintList(
+		&p.Fields, c.Accounts, writer,
+		table.Row{"Current", "Name", "Channel ID", "Content Owner", "Token Cache"},
+		func(a *Account) table.Row {
+			current := ""
+			if a.Current {
+				current = "*"
+			}
+			return table.Row{
+				current, a.Name, a.ChannelId, a.OnBehalfOfContentOwner,
+				a.cacheToken(),
+			}
+		},
+	)
+}
+
+// Remove deletes the profile, leaving its token cache on disk. Removing the
+// current profile leaves none current.
+func (p *Profile) Remove(writer io.Writer) error {
+	c, err := Load()
+	if err != nil {
+		return err
+	}
+	if c.Find(p.Name) == nil {
+		return failure.New(
+			failure.NotFound, fmt.Errorf("%w: %s", errNotFound, p.Name),
+		)
+	}
+	c.Accounts = slices.DeleteFunc(
+		c.Accounts, func(a *Account) bool { return a.Name == p.Name },
+	)
+	if c.Current == p.Name {
+		c.Current = ""
+	}
+	if err := save(c); err != nil {
+		return err
+	}
+
+	common.PrintResult(
+		p.Output, map[string]string{"removed": p.Name}, writer,
+		"Profile %s removed\n", p.Name,
+	)
+	return nil
+}
+
+// Use makes the profile current for commands run without --profile.
+func (p *Profile) Use(writer io.Writer) error {
+	c, err := Load()
+	if err != nil {
+		return err
+	}
+	if c.Find(p.Name) == nil {
+		return failure.New(
+			failure.NotFound, fmt.Errorf("%w: %s", errNotFound, p.Name),
+		)
+	}
+	c.Current = p.Name
+	if err := save(c); err != nil {
+		return err
+	}
+
+	common.PrintResult(
+		p.Output, map[string]string{"current": p.Name}, writer,
+		"Switched to profile %s\n", p.Name,
+	)
+	return nil
+}
+
+func WithName(name string) Option {
+	return func(p *Profile) {
+		p.Name = name
+	}
+}
+
+func WithCredential(credential string) Option {
+	return func(p *Profile) {
+		p.Credential = credential
+	}
+}
+
+func WithCacheToken(cacheToken string) Option {
+	return func(p *Profile) {
+		p.CacheToken = cacheToken
+	}
+}
+
+func WithOnBehalfOfContentOwnerChannel(channel string) Option {
+	return func(p *Profile) {
+		p.OnBehalfOfContentOwnerChannel = channel
+	}
+}
+
+var (
+	WithChannelId              = common.WithChannelId[*Profile]
+	WithOnBehalfOfContentOwner = common.WithOnBehalfOfContentOwner[*Profile]
+	WithColumns                = common.WithColumns[*Profile]
+	WithWhere                  = common.WithWhere[*Profile]
+	WithSortBy                 = common.WithSortBy[*Profile]
+	WithOutput                 = common.WithOutput[*Profile]
+)
@@ -0,0 +1,226 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+)
+
+func setRoot(t *testing.T) string {
+	t.Helper()
+	t.Setenv(Env, "")
+	t.Setenv("YUTU_CREDENTIAL", "")
+	return common.SetTestRoot(t)
+}
+
+func add(t *testing.T, opts ...Option) {
+	t.Helper()
+	opts = append(opts, WithOutput("silent"))
+	if err := NewProfile(opts...).Add(&bytes.Buffer{}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+}
+
+func TestAddUseRemove(t *testing.T) {
+	setRoot(t)
+	add(t, WithName("gaming"), WithChannelId("UC1"))
+	add(
+		t, WithName("music"), WithCredential("music.secret.json"),
+		WithOnBehalfOfContentOwner("owner"),
+		WithOnBehalfOfContentOwnerChannel("UC2"),
+	)
+
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if c.Current != "gaming" || len(c.Accounts) != 2 {
+		t.Fatalf("Load() = %+v, want gaming current of 2 profiles", c)
+	}
+	if got := c.Find("music"); got.OnBehalfOfContentOwnerChannel != "UC2" {
+		t.Errorf("music = %+v", got)
+	}
+
+	add(t, WithName("gaming"), WithChannelId("UC3"))
+	c, _ = Load()
+	if len(c.Accounts) != 2 || c.Find("gaming").ChannelId != "UC3" {
+		t.Errorf("re-adding gaming did not replace it: %+v", c.Accounts)
+	}
+
+	var buf bytes.Buffer
+	if err := NewProfile(WithName("music")).Use(&buf); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Switched to profile music") {
+		t.Errorf("Use() printed %q", buf.String())
+	}
+	if c, _ = Load(); c.Current != "music" {
+		t.Errorf("Current = %q, want music", c.Current)
+	}
+
+	if err := NewProfile(WithName("music")).Remove(&bytes.Buffer{}); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	c, _ = Load()
+	if c.Current != "" || c.Find("music") != nil || len(c.Accounts) != 1 {
+		t.Errorf("after Remove() = %+v", c)
+	}
+}
+
+func TestAddInvalidName(t *testing.T) {
+	setRoot(t)
+	for _, name := range []string{"", "../evil", "my channel"} {
+		err := NewProfile(WithName(name)).Add(&bytes.Buffer{})
+		if !errors.Is(err, errInvalidName) {
+			t.Errorf("Add(%q) error = %v, want %v", name, err, errInvalidName)
+		}
+	}
+}
+
+func TestMissingProfile(t *testing.T) {
+	setRoot(t)
+	for name, fn := range map[string]func(IProfile) error{
+		"Use": func(p IProfile) error { return p.Use(&bytes.Buffer{}) },
+		"Remove": func(p IProfile) error {
+			return p.Remove(&bytes.Buffer{})
+		},
+	} {
+		err := fn(NewProfile(WithName("nope")))
+		if failure.Classify(err).Class != failure.NotFound {
+			t.Errorf("%s() error = %v, want not found", name, err)
+		}
+	}
+}
+
+func TestActive(t *testing.T) {
+	setRoot(t)
+	if a, err := Active(""); a != nil || err != nil {
+		t.Fatalf("Active() without profiles = %v, %v, want nil", a, err)
+	}
+
+	add(t, WithName("gaming"))
+	add(t, WithName("music"))
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{"current", "", "", "gaming"},
+		{"env", "", "music", "music"},
+		{"flag over env", "gaming", "music", "gaming"},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Setenv(Env, tt.env)
+				a, err := Active(tt.flag)
+				if err != nil {
+					t.Fatalf("Active() error = %v", err)
+				}
+				if a.Name != tt.want {
+					t.Errorf("Active() = %s, want %s", a.Name, tt.want)
+				}
+			},
+		)
+	}
+
+	_, err := Active("nope")
+	if failure.Classify(err).Class != failure.InvalidArgument {
+		t.Errorf("Active(nope) error = %v, want invalid argument", err)
+	}
+}
+
+func TestActivate(t *testing.T) {
+	dir := setRoot(t)
+	a := &Account{Name: "gaming", Credential: "gaming.secret.json"}
+	if err := a.Activate(); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if got, want := os.Getenv("YUTU_CREDENTIAL"), filepath.Join(
+		dir, "gaming.secret.json",
+	); got != want {
+		t.Errorf("YUTU_CREDENTIAL = %q, want %q", got, want)
+	}
+	if got, want := os.Getenv("YUTU_CACHE_TOKEN"), filepath.Join(
+		dir, Dir, "gaming", "youtube.token.json",
+	); got != want {
+		t.Errorf("YUTU_CACHE_TOKEN = %q, want %q", got, want)
+	}
+
+	inline := `{"access_token":"x"}`
+	t.Setenv("YUTU_CACHE_TOKEN", "")
+	a = &Account{Name: "music", CacheToken: inline}
+	if err := a.Activate(); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if got := os.Getenv("YUTU_CACHE_TOKEN"); got != inline {
+		t.Errorf("YUTU_CACHE_TOKEN = %q, want the inline token", got)
+	}
+}
+
+func TestActivate_Exported(t *testing.T) {
+	setRoot(t)
+	add(t, WithName("gaming"), WithCredential("gaming.secret.json"))
+	t.Setenv("YUTU_CREDENTIAL", "mine.secret.json")
+	t.Setenv("YUTU_CACHE_TOKEN", "mine.token.json")
+
+	a, err := Active("")
+	if err != nil {
+		t.Fatalf("Active() error = %v", err)
+	}
+	if err := a.Activate(); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if got := os.Getenv("YUTU_CREDENTIAL"); got != "mine.secret.json" {
+		t.Errorf("current profile replaced YUTU_CREDENTIAL with %q", got)
+	}
+	if got := os.Getenv("YUTU_CACHE_TOKEN"); got != "mine.token.json" {
+		t.Errorf("current profile replaced YUTU_CACHE_TOKEN with %q", got)
+	}
+
+	t.Setenv("YUTU_CACHE_TOKEN", "")
+	t.Setenv(auth.HelperEnv, "pass-helper")
+	if err := a.Activate(); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if got := os.Getenv("YUTU_CACHE_TOKEN"); got != "" {
+		t.Errorf("current profile bypassed the credential helper with %q", got)
+	}
+
+	if a, err = Active("gaming"); err != nil {
+		t.Fatalf("Active() error = %v", err)
+	}
+	if err := a.Activate(); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if got := os.Getenv("YUTU_CREDENTIAL"); !strings.HasSuffix(got, "gaming.secret.json") {
+		t.Errorf("--profile did not win over YUTU_CREDENTIAL: %q", got)
+	}
+}
+
+func TestList(t *testing.T) {
+	setRoot(t)
+	add(t, WithName("gaming"), WithChannelId("UC1"))
+	add(t, WithName("music"))
+
+	var buf bytes.Buffer
+	if err := NewProfile(WithOutput("json")).List(&buf); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"current":true`) ||
+		strings.Count(out, `"name"`) != 2 {
+		t.Errorf("List() = %s", out)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// interval throttles how often a Sink is called while a transfer is running.
+const interval = 200 * time.Millisecond
+
+// Stat is a snapshot of a running transfer.
+type Stat struct {
+	Sent  int64
+	Total int64 // -1 when the size is unknown
+	Rate  float64
+	ETA   time.Duration
+	Done  bool
+}
+
+// Sink receives progress snapshots.
+type Sink func(Stat)
+
+type sinkKey struct{}
+
+// NewContext returns a child context that carries the given sink. A nil
+// sink hides any sink inherited from ctx.
+func NewContext(ctx context.Context, sink Sink) context.Context {
+	return context.WithValue(ctx, sinkKey{}, sink)
+}
+
+// FromContext returns the sink stored in ctx, or nil.
+func FromContext(ctx context.Context) Sink {
+	if ctx == nil {
+		return nil
+	}
+	sink, _ := ctx.Value(sinkKey{}).(Sink)
+	return sink
+}
+
+// Tracker turns byte counts into throttled Stat updates for a Sink.
+type Tracker struct {
+	mu    sync.Mutex
+	sink  Sink
+	total int64
+	sent  int64
+	base  int64 // sent by an earlier run, left out of the rate
+	start time.Time
+	last  time.Time
+	now   func() time.Time
+}
+
+// NewTracker returns a tracker for a transfer of total bytes, or nil when
+// sink is nil. All Tracker methods are safe to call on a nil receiver.
+func NewTracker(sink Sink, total int64) *Tracker {
+	if sink == nil {
+		return nil
+	}
+	return &Tracker{sink: sink, total: total, start: time.Now(), now: time.Now}
+}
+
+// Add records n more bytes sent.
+func (t *Tracker) Add(n int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update(t.sent + n)
+}
+
+// Set records the absolute number of bytes sent so far.
+func (t *Tracker) Set(sent int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update(sent)
+}
+
+// Resume records offset bytes sent by an earlier run. They count as sent,
+// but not towards the rate and ETA of this run.
+func (t *Tracker) Resume(offset int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.base = offset
+	t.update(offset)
+}
+
+// Finish emits a final snapshot regardless of throttling.
+func (t *Tracker) Finish() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sink(t.stat(true))
+}
+
+func (t *Tracker) update(sent int64) {
+	t.sent = sent
+	now := t.now()
+	if now.Sub(t.last) < interval {
+		return
+	}
+	t.last = now
+	t.sink(t.stat(false))
+}
+
+func (t *Tracker) stat(done bool) Stat {
+	s := Stat{Sent: t.sent, Total: t.total, Done: done}
+	elapsed := t.now().Sub(t.start).Seconds()
+	if elapsed > 0 {
+		s.Rate = float64(t.sent-t.base) / elapsed
+	}
+	if s.Rate > 0 && t.total > t.sent {
+		s.ETA = time.Duration(float64(t.total-t.sent) / s.Rate * float64(time.Second))
+	}
+	return s
+}
+
+type reader struct {
+	r    io.Reader
+	t    *Tracker
+	done bool
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.t.Add(int64(n))
+	if err == io.EOF && !r.done {
+		r.done = true
+		r.t.Finish()
+	}
+	return n, err
+}
+
+// NewReader wraps r so that reads are reported to the sink in ctx.
+// When ctx carries no sink, r is returned unchanged.
+func NewReader(ctx context.Context, r io.Reader, total int64) io.Reader {
+	t := NewTracker(FromContext(ctx), total)
+	if t == nil {
+		return r
+	}
+	return &reader{r: r, t: t}
+}
+
+// File is NewReader for an opened file, taking the total from its size.
+func File(ctx context.Context, f *os.File) io.Reader {
+	total := int64(-1)
+	if info, err := f.Stat(); err == nil {
+		total = info.Size()
+	}
+	return NewReader(ctx, f, total)
+}
+
+// Bar returns a sink that draws a single-line progress bar on w.
+func Bar(w io.Writer) Sink {
+	const width = 30
+	return func(s Stat) {
+		var line string
+		if s.Total > 0 {
+			filled := int(float64(width) * float64(s.Sent) / float64(s.Total))
+			filled = min(max(filled, 0), width)
+			line = fmt.Sprintf(
+				"[%s%s] %3.0f%% %s/%s %s/s",
+				strings.Repeat("=", filled), strings.Repeat(" ", width-filled),
+				100*float64(s.Sent)/float64(s.Total),
+				Bytes(s.Sent), Bytes(s.Total), Bytes(int64(s.Rate)),
+			)
+			if !s.Done {
+				line += " ETA " + s.ETA.Round(time.Second).String()
+			}
+		} else {
+			line = fmt.Sprintf("%s %s/s", Bytes(s.Sent), Bytes(int64(s.Rate)))
+		}
+		_, _ = fmt.Fprintf(w, "\r\033[K%s", line)
+		if s.Done {
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+}
+
+// MCP returns a sink that sends notifications/progress for req, or nil when
+// the client did not ask for progress.
+func MCP(ctx context.Context, req *mcp.CallToolRequest) Sink {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+	return func(s Stat) {
+		params := &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      float64(s.Sent),
+			Message: fmt.Sprintf(
+				"%s sent, %s/s", Bytes(s.Sent), Bytes(int64(s.Rate)),
+			),
+		}
+		if s.Total > 0 {
+			params.Total = float64(s.Total)
+			if !s.Done {
+				params.Message += ", ETA " + s.ETA.Round(time.Second).String()
+			}
+		}
+		_ = req.Session.NotifyProgress(ctx, params)
+	}
+}
+
+// Bytes formats n using binary units.
+func Bytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
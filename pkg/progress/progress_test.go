@@ -0,0 +1,153 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	if FromContext(nil) != nil {
+		t.Error("FromContext(nil) should be nil")
+	}
+	called := false
+	ctx := NewContext(context.Background(), func(Stat) { called = true })
+	FromContext(ctx)(Stat{})
+	if !called {
+		t.Error("FromContext did not return the stored sink")
+	}
+	if FromContext(NewContext(ctx, nil)) != nil {
+		t.Error("a nil sink should hide the inherited one")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	var stats []Stat
+	tr := NewTracker(func(s Stat) { stats = append(stats, s) }, 1000)
+	clock := tr.start
+	tr.now = func() time.Time { return clock }
+
+	clock = clock.Add(time.Second)
+	tr.Set(100)
+	clock = clock.Add(time.Millisecond)
+	tr.Add(100)
+	clock = clock.Add(time.Second)
+	tr.Add(300)
+	tr.Finish()
+
+	if len(stats) != 3 {
+		t.Fatalf("got %d updates, want 3 (one throttled): %+v", len(stats), stats)
+	}
+	if stats[0].Sent != 100 || stats[0].Rate != 100 || stats[0].ETA != 9*time.Second {
+		t.Errorf("first update = %+v", stats[0])
+	}
+	if stats[1].Sent != 500 {
+		t.Errorf("second update sent = %d, want 500", stats[1].Sent)
+	}
+	if last := stats[2]; !last.Done || last.Sent != 500 {
+		t.Errorf("final update = %+v", last)
+	}
+}
+
+func TestTracker_Resume(t *testing.T) {
+	var last Stat
+	tr := NewTracker(func(s Stat) { last = s }, 1000)
+	clock := tr.start
+	tr.now = func() time.Time { return clock }
+
+	tr.Resume(600)
+	clock = clock.Add(time.Second)
+	tr.Set(700)
+	if last.Sent != 700 || last.Rate != 100 || last.ETA != 3*time.Second {
+		t.Errorf("update after resume = %+v", last)
+	}
+}
+
+func TestTracker_Nil(t *testing.T) {
+	tr := NewTracker(nil, 10)
+	if tr != nil {
+		t.Fatal("NewTracker(nil) should return nil")
+	}
+	tr.Add(1)
+	tr.Set(2)
+	tr.Resume(3)
+	tr.Finish()
+}
+
+func TestNewReader(t *testing.T) {
+	src := strings.NewReader("hello")
+	if NewReader(context.Background(), src, 5) != io.Reader(src) {
+		t.Error("NewReader without sink should return the reader unchanged")
+	}
+
+	var last Stat
+	ctx := NewContext(context.Background(), func(s Stat) { last = s })
+	data, err := io.ReadAll(NewReader(ctx, strings.NewReader("hello"), 5))
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("ReadAll() = %q, %v", data, err)
+	}
+	if !last.Done || last.Sent != 5 || last.Total != 5 {
+		t.Errorf("last update = %+v", last)
+	}
+
+	finished := 0
+	ctx = NewContext(context.Background(), func(s Stat) {
+		if s.Done {
+			finished++
+		}
+	})
+	r := NewReader(ctx, strings.NewReader("hello"), 5)
+	_, _ = io.ReadAll(r)
+	_, _ = r.Read(make([]byte, 1))
+	if finished != 1 {
+		t.Errorf("got %d final updates, want 1", finished)
+	}
+}
+
+func TestBar(t *testing.T) {
+	var buf bytes.Buffer
+	sink := Bar(&buf)
+	sink(Stat{Sent: 512, Total: 1024, Rate: 256, ETA: 2 * time.Second})
+	if got := buf.String(); !strings.Contains(got, " 50% 512 B/1.0 KiB 256 B/s ETA 2s") {
+		t.Errorf("Bar() = %q", got)
+	}
+
+	buf.Reset()
+	sink(Stat{Sent: 1024, Total: 1024, Done: true})
+	if got := buf.String(); !strings.HasSuffix(got, "\n") || strings.Contains(got, "ETA") {
+		t.Errorf("final Bar() = %q", got)
+	}
+
+	buf.Reset()
+	sink(Stat{Sent: 2048, Total: -1, Rate: 1024})
+	if got := buf.String(); !strings.Contains(got, "2.0 KiB 1.0 KiB/s") {
+		t.Errorf("unknown size Bar() = %q", got)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	}
+	for n, want := range tests {
+		if got := Bytes(n); got != want {
+			t.Errorf("Bytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestMCP_NoToken(t *testing.T) {
+	if MCP(context.Background(), nil) != nil {
+		t.Error("MCP(nil request) should return nil")
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package quota meters the YouTube Data API units spent by every service
+// that common.Fields.EnsureService creates. Units are recorded per method in
+// a file next to the token cache, which starts over at midnight Pacific time
+// when YouTube resets the project quota, and calls that would exceed Budget
+// are refused before they are sent.
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/auth"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/retry"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	// FileName is the quota ledger kept next to the token cache.
+	FileName = "yutu.quota.json"
+	// DailyLimit is the default quota of a Google Cloud project.
+	DailyLimit = 10000
+	// BudgetEnv sets Budget when no --quota-budget flag is given.
+	BudgetEnv = "YUTU_QUOTA_BUDGET"
+
+	readCost  = 1
+	writeCost = 50
+)
+
+var (
+	// ErrBudgetExceeded refuses a call that would spend more than Budget.
+	ErrBudgetExceeded = errors.New("quota budget exceeded")
+
+	errReadQuota  = errors.New("failed to read quota ledger")
+	errWriteQuota = errors.New("failed to write quota ledger")
+)
+
+// costs are the units of every method that is not a 1 unit read or a 50
+// unit write, see docs/FEATURES.md.
+var costs = map[string]int64{
+	"captions.list":     50,
+	"captions.download": 200,
+	"captions.insert":   400,
+	"captions.update":   450,
+	"search.list":       100,
+	"videos.insert":     1600,
+}
+
+// Budget is the number of units yutu may spend per Pacific day, or 0 for
+// no limit. It defaults to YUTU_QUOTA_BUDGET.
+var Budget = budgetFromEnv()
+
+var (
+	// mu serializes read-modify-write cycles of the ledger within a process.
+	mu sync.Mutex
+	// now is replaced in tests.
+	now = time.Now
+)
+
+var pacific = sync.OnceValue(
+	func() *time.Location {
+		loc, err := time.LoadLocation("America/Los_Angeles")
+		if err != nil {
+			return time.FixedZone("PST", -8*60*60)
+		}
+		return loc
+	},
+)
+
+func init() {
+	common.RegisterTransport(Wrap)
+}
+
+func budgetFromEnv() int64 {
+	value, ok := os.LookupEnv(BudgetEnv)
+	if !ok || value == "" {
+		return 0
+	}
+	budget, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || budget < 0 {
+		slog.Warn("Ignoring invalid quota budget", "env", BudgetEnv, "value", value)
+		return 0
+	}
+	return budget
+}
+
+// MethodUsage is what one API method has spent today.
+type MethodUsage struct {
+	Method string `yaml:"method" json:"method"`
+	Calls  int64  `yaml:"calls" json:"calls"`
+	Units  int64  `yaml:"units" json:"units"`
+}
+
+// Usage is the content of the quota ledger.
+type Usage struct {
+	Day     string                  `yaml:"day" json:"day"`
+	Methods map[string]*MethodUsage `yaml:"methods" json:"methods"`
+}
+
+// Total returns the units spent by all methods.
+func (u *Usage) Total() int64 {
+	var total int64
+	for _, m := range u.Methods {
+		total += m.Units
+	}
+	return total
+}
+
+// Path returns the quota ledger file, relative to pkg.Root.
+func Path() string {
+	return filepath.ToSlash(filepath.Join(auth.CacheDir(), FileName))
+}
+
+// Today returns the Pacific date that the quota is currently counted for.
+func Today() string {
+	return now().In(pacific()).Format(time.DateOnly)
+}
+
+// ResetsAt returns the next midnight Pacific time.
+func ResetsAt() time.Time {
+	t := now().In(pacific())
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+}
+
+// Cost returns the units a call of method, such as "videos.list", spends.
+func Cost(method string) int64 {
+	if cost, ok := costs[method]; ok {
+		return cost
+	}
+	if strings.HasSuffix(method, ".list") || method == "videos.getRating" {
+		return readCost
+	}
+	return writeCost
+}
+
+// Method names the API method of req, such as "videos.insert". It reports
+// false for requests that are not API calls, such as the chunks of a
+// resumable upload, which are covered by the call that started it.
+func Method(req *http.Request) (string, bool) {
+	if req.URL.Query().Has("upload_id") {
+		return "", false
+	}
+	_, rest, ok := strings.Cut(req.URL.Path, "/youtube/v3/")
+	segments := strings.FieldsFunc(rest, func(r rune) bool { return r == '/' })
+	if !ok || len(segments) == 0 {
+		return "", false
+	}
+
+	resource, action, _ := strings.Cut(segments[0], ":")
+	segments = segments[1:]
+	if resource == "liveChat" && len(segments) > 0 {
+		resource = "liveChat" + strings.ToUpper(segments[0][:1]) + segments[0][1:]
+		segments = segments[1:]
+	}
+	switch {
+	case action != "":
+	case resource == "captions" && len(segments) > 0:
+		action = "download"
+	case len(segments) > 0:
+		action = segments[0]
+	default:
+		switch req.Method {
+		case http.MethodGet:
+			action = "list"
+		case http.MethodPost:
+			action = "insert"
+		case http.MethodPut:
+			action = "update"
+		case http.MethodDelete:
+			action = "delete"
+		default:
+			return "", false
+		}
+	}
+	return resource + "." + action, true
+}
+
+// Load returns today's usage. A missing ledger, or one from an earlier day,
+// is empty.
+func Load() (*Usage, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+// Spend records units for method, refusing with ErrBudgetExceeded when
+// that would take today's usage over Budget.
+func Spend(method string, units int64) error {
+	mu.Lock()
+	defer mu.Unlock()
+	usage, err := load()
+	if err != nil {
+		slog.Warn("Starting a new quota ledger", "error", err)
+		usage = &Usage{Day: Today(), Methods: map[string]*MethodUsage{}}
+	}
+	if used := usage.Total(); Budget > 0 && used+units > Budget {
+		return failure.New(
+			failure.QuotaExceeded, fmt.Errorf(
+				"%w: %s costs %d units, %d of %d already spent today, resets at %s",
+				ErrBudgetExceeded, method, units, used, Budget,
+				ResetsAt().Format(time.RFC3339),
+			),
+		)
+	}
+
+	m, ok := usage.Methods[method]
+	if !ok {
+		m = &MethodUsage{Method: method}
+		usage.Methods[method] = m
+	}
+	m.Calls++
+	m.Units += units
+	if err := save(usage); err != nil {
+		slog.Warn("Quota usage not recorded", "method", method, "error", err)
+	}
+	return nil
+}
+
+func load() (*Usage, error) {
+	usage := &Usage{Day: Today(), Methods: map[string]*MethodUsage{}}
+	data, err := pkg.Root.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return usage, nil
+	} else if err != nil {
+		return nil, errors.Join(errReadQuota, err)
+	}
+	stored := &Usage{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, errors.Join(errReadQuota, err)
+	}
+	if stored.Day != usage.Day || stored.Methods == nil {
+		return usage, nil
+	}
+	return stored, nil
+}
+
+func save(usage *Usage) error {
+	path := Path()
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return errors.Join(errWriteQuota, err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := pkg.Root.MkdirAll(dir, 0755); err != nil {
+			return errors.Join(errWriteQuota, err)
+		}
+	}
+	if err := pkg.Root.WriteFile(path, data, 0600); err != nil {
+		return errors.Join(errWriteQuota, err)
+	}
+	return nil
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+// Wrap meters every API call sent through base. YouTube charges failed
+// calls too, so every retry of a call made below base is metered again.
+func Wrap(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if method, ok := Method(req); ok {
+		spend := func() error { return Spend(method, Cost(method)) }
+		if err := spend(); err != nil {
+			return nil, err
+		}
+		req = req.WithContext(retry.WithResend(req.Context(), spend))
+	}
+	return t.base.RoundTrip(req)
+}
+
+// Report summarizes today's usage against Budget.
+type Report struct {
+	Day       string         `yaml:"day" json:"day"`
+	Used      int64          `yaml:"used" json:"used"`
+	Budget    int64          `yaml:"budget" json:"budget"`
+	Remaining int64          `yaml:"remaining" json:"remaining"`
+	ResetsAt  string         `yaml:"resets_at" json:"resets_at"`
+	Methods   []*MethodUsage `yaml:"methods" json:"methods"`
+}
+
+// Quota reports the units spent today.
+type Quota struct {
+	common.Fields
+}
+
+type IQuota interface {
+	Status(io.Writer) error
+}
+
+type Option func(*Quota)
+
+func NewQuota(opts ...Option) IQuota {
+	q := &Quota{Fields: common.Fields{}}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// Status prints today's usage per method, the most expensive first, and
+// what is left of Budget, or of the default daily limit without one.
+func (q *Quota) Status(writer io.Writer) error {
+	usage, err := Load()
+	if err != nil {
+		return err
+	}
+
+	limit := Budget
+	if limit == 0 {
+		limit = DailyLimit
+	}
+	report := &Report{
+		Day:      usage.Day,
+		Used:     usage.Total(),
+		Budget:   Budget,
+		ResetsAt: ResetsAt().Format(time.RFC3339),
+		Methods:  make([]*MethodUsage, 0, len(usage.Methods)),
+	}
+	report.Remaining = max(limit-report.Used, 0)
+	for _, m := range usage.Methods {
+		report.Methods = append(report.Methods, m)
+	}
+	slices.SortFunc(
+		report.Methods, func(a, b *MethodUsage) int {
+			if a.Units != b.Units {
+				return int(b.Units - a.Units)
+			}
+			return strings.Compare(a.Method, b.Method)
+		},
+	)
+
+	switch q.Output {
+	case "json", "yaml":
+		common.PrintResult(q.Output, report, writer, "")
+	default:
+		if err := common.PrintList(
+			&common.Fields{Output: "table"}, report.Methods, writer, table.Row{"Method", "Calls", "Units"},
+			func(m *MethodUsage) table.Row {
+				return table.Row{m.Method, m.Calls, m.Units}
+			},
+		); err != nil {
+			return err
+		}
+		budget := "no budget"
+		if Budget > 0 {
+			budget = fmt.Sprintf("budget %d", Budget)
+		}
+		_, _ = fmt.Fprintf(
+			writer, "%d units spent on %s (%s), %d remaining, resets at %s\n",
+			report.Used, report.Day, budget, report.Remaining, report.ResetsAt,
+		)
+	}
+	return nil
+}
+
+var WithOutput = common.WithOutput[*Quota]
@@ -0,0 +1,242 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+	"github.com/eat-pray-ai/yutu/pkg/retry"
+)
+
+func setRoot(t *testing.T) {
+	t.Helper()
+	common.SetTestRoot(t)
+	oldBudget, oldNow := Budget, now
+	t.Cleanup(func() { Budget, now = oldBudget, oldNow })
+}
+
+func setNow(t *testing.T, value string) {
+	t.Helper()
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("bad time %q: %v", value, err)
+	}
+	now = func() time.Time { return at }
+}
+
+func TestMethod(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/videos?part=id", "videos.list"},
+		{http.MethodPost, "https://youtube.googleapis.com/upload/youtube/v3/videos?uploadType=resumable", "videos.insert"},
+		{http.MethodPut, "https://youtube.googleapis.com/youtube/v3/playlists", "playlists.update"},
+		{http.MethodDelete, "https://youtube.googleapis.com/youtube/v3/comments?id=x", "comments.delete"},
+		{http.MethodPost, "https://youtube.googleapis.com/youtube/v3/videos/rate?id=x", "videos.rate"},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/videos/getRating?id=x", "videos.getRating"},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/captions/abc", "captions.download"},
+		{http.MethodPost, "https://youtube.googleapis.com/youtube/v3/thumbnails/set?videoId=x", "thumbnails.set"},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/liveChat/messages", "liveChatMessages.list"},
+		{http.MethodPost, "https://youtube.googleapis.com/youtube/v3/liveChat/bans", "liveChatBans.insert"},
+		{http.MethodPost, "https://youtube.googleapis.com/youtube/v3/liveBroadcasts/transition", "liveBroadcasts.transition"},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/videos:batchGetStats", "videos.batchGetStats"},
+		{http.MethodPut, "https://youtube.googleapis.com/upload/youtube/v3/videos?upload_id=x", ""},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/liveChat//messages/", "liveChatMessages.list"},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3/liveChat/", "liveChat.list"},
+		{http.MethodGet, "https://youtube.googleapis.com/youtube/v3//", ""},
+		{http.MethodPost, "https://oauth2.googleapis.com/token", ""},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.want, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.url, nil)
+				got, ok := Method(req)
+				if got != tt.want || ok != (tt.want != "") {
+					t.Errorf("Method() = %q, %v, want %q", got, ok, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestCost(t *testing.T) {
+	tests := map[string]int64{
+		"videos.list":      1,
+		"videos.getRating": 1,
+		"search.list":      100,
+		"captions.list":    50,
+		"videos.insert":    1600,
+		"videos.rate":      50,
+		"playlists.delete": 50,
+	}
+	for method, want := range tests {
+		if got := Cost(method); got != want {
+			t.Errorf("Cost(%q) = %d, want %d", method, got, want)
+		}
+	}
+}
+
+func TestSpend(t *testing.T) {
+	setRoot(t)
+	setNow(t, "2026-03-09T23:30:00-07:00")
+
+	for _, method := range []string{"videos.list", "videos.list", "search.list"} {
+		if err := Spend(method, Cost(method)); err != nil {
+			t.Fatalf("Spend(%q) error = %v", method, err)
+		}
+	}
+	usage, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if usage.Day != "2026-03-09" || usage.Total() != 102 {
+		t.Errorf("usage = %s, %d units, want 2026-03-09, 102 units", usage.Day, usage.Total())
+	}
+	if m := usage.Methods["videos.list"]; m == nil || m.Calls != 2 || m.Units != 2 {
+		t.Errorf("videos.list = %+v", m)
+	}
+
+	// 07:30 UTC is still the same day in Los Angeles.
+	setNow(t, "2026-03-10T06:30:00Z")
+	if usage, _ = Load(); usage.Total() != 102 {
+		t.Errorf("before Pacific midnight: %d units, want 102", usage.Total())
+	}
+	setNow(t, "2026-03-10T07:00:00Z")
+	if usage, _ = Load(); usage.Day != "2026-03-10" || usage.Total() != 0 {
+		t.Errorf("after Pacific midnight: %s, %d units, want 2026-03-10, 0", usage.Day, usage.Total())
+	}
+}
+
+func TestSpend_Budget(t *testing.T) {
+	setRoot(t)
+	setNow(t, "2026-03-09T12:00:00-07:00")
+	Budget = 150
+
+	if err := Spend("search.list", 100); err != nil {
+		t.Fatalf("Spend() error = %v", err)
+	}
+	err := Spend("videos.update", 50)
+	if err != nil {
+		t.Fatalf("Spend() up to the budget error = %v", err)
+	}
+	err = Spend("videos.list", 1)
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Spend() error = %v, want %v", err, ErrBudgetExceeded)
+	}
+	if c := failure.Classify(err).Class; c != failure.QuotaExceeded {
+		t.Errorf("class = %s, want %s", c, failure.QuotaExceeded)
+	}
+	if !strings.Contains(err.Error(), "videos.list costs 1 units, 150 of 150") {
+		t.Errorf("Spend() error = %v", err)
+	}
+	if usage, _ := Load(); usage.Methods["videos.list"] != nil {
+		t.Errorf("refused call was recorded: %+v", usage.Methods["videos.list"])
+	}
+}
+
+func TestTransport(t *testing.T) {
+	setRoot(t)
+	Budget = 1
+	calls := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				_, _ = w.Write([]byte(`{}`))
+			},
+		),
+	)
+	defer server.Close()
+	client := &http.Client{Transport: Wrap(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/youtube/v3/channels?part=id")
+	if err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+	_ = resp.Body.Close()
+	_, err = client.Get(server.URL + "/youtube/v3/channels?part=id")
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("second call error = %v, want %v", err, ErrBudgetExceeded)
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+}
+
+func TestTransport_Retries(t *testing.T) {
+	setRoot(t)
+	oldDelay := retry.Default.BaseDelay
+	retry.Default.BaseDelay = 0
+	t.Cleanup(func() { retry.Default.BaseDelay = oldDelay })
+	calls := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if calls++; calls == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			},
+		),
+	)
+	defer server.Close()
+	client := &http.Client{Transport: Wrap(retry.Wrap(nil, retry.Default))}
+
+	resp, err := client.Get(server.URL + "/youtube/v3/channels?part=id")
+	if err != nil {
+		t.Fatalf("call error = %v", err)
+	}
+	_ = resp.Body.Close()
+	usage, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if calls != 2 || usage.Total() != 2 {
+		t.Errorf("server saw %d calls and %d units were spent, want 2 each", calls, usage.Total())
+	}
+}
+
+func TestQuota_Status(t *testing.T) {
+	setRoot(t)
+	setNow(t, "2026-03-09T12:00:00-07:00")
+	Budget = 500
+	_ = Spend("videos.list", 1)
+	_ = Spend("search.list", 100)
+
+	var buf bytes.Buffer
+	if err := NewQuota(WithOutput("json")).Status(&buf); err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	report := &Report{}
+	if err := json.Unmarshal(buf.Bytes(), report); err != nil {
+		t.Fatalf("bad json %q: %v", buf.String(), err)
+	}
+	if report.Used != 101 || report.Remaining != 399 ||
+		report.ResetsAt != "2026-03-10T00:00:00-07:00" {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Methods) != 2 || report.Methods[0].Method != "search.list" {
+		t.Errorf("methods = %+v", report.Methods)
+	}
+
+	buf.Reset()
+	if err := NewQuota(WithOutput("table")).Status(&buf); err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "101 units spent on 2026-03-09 (budget 500), 399 remaining") {
+		t.Errorf("Status() = %q", buf.String())
+	}
+}
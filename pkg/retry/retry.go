@@ -0,0 +1,288 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retry resends YouTube Data API calls that failed for a transient
+// reason, waiting with exponential backoff and full jitter, or as long as
+// the server asks with Retry-After.
+//
+// Reads, updates and deletes are retried on 429, 5xx, rate limit errors and
+// network errors, since sending them twice does no harm. Inserts and other
+// POSTs are only retried when the server certainly did not act on them: on
+// 429, rate limit errors and failed connections. Chunks of a resumable
+// upload are left to the upload package, which resumes from the offset the
+// server reports.
+package retry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+)
+
+const (
+	// AttemptsEnv sets Default.Attempts.
+	AttemptsEnv = "YUTU_RETRY_ATTEMPTS"
+	// MaxDelayEnv sets Default.MaxDelay.
+	MaxDelayEnv = "YUTU_RETRY_MAX_DELAY"
+
+	// maxErrorBody bounds how much of an error response is read to find its
+	// reason.
+	maxErrorBody = 64 << 10
+)
+
+// ErrQuotaExhausted stops a call that failed because the project ran out of
+// quota, which no retry can fix before the quota resets.
+var ErrQuotaExhausted = errors.New(
+	"YouTube Data API quota exhausted, it resets at midnight Pacific time",
+)
+
+// Policy decides how often and how long to wait before resending a call.
+type Policy struct {
+	// Attempts is the number of tries, including the first. 1 disables
+	// retries.
+	Attempts int
+	// BaseDelay is the longest wait before the first retry; each further
+	// retry doubles it.
+	BaseDelay time.Duration
+	// MaxDelay caps every wait. A Retry-After longer than MaxDelay is not
+	// waited for.
+	MaxDelay time.Duration
+}
+
+// Default is the policy of every client yutu builds. Its fields default to
+// YUTU_RETRY_ATTEMPTS and YUTU_RETRY_MAX_DELAY.
+var Default = &Policy{
+	Attempts:  envInt(AttemptsEnv, 4),
+	BaseDelay: time.Second,
+	MaxDelay:  envDuration(MaxDelayEnv, 30*time.Second),
+}
+
+// sleep is replaced in tests.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func envInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		slog.Warn("Ignoring invalid retry setting", "env", key, "value", value)
+		return fallback
+	}
+	return n
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		slog.Warn("Ignoring invalid retry setting", "env", key, "value", value)
+		return fallback
+	}
+	return d
+}
+
+type resendKey struct{}
+
+// WithResend returns ctx making the calls sent with it run resend before
+// every retry, so that what each attempt costs can be counted. An error of
+// resend ends the call with it.
+func WithResend(ctx context.Context, resend func() error) context.Context {
+	return context.WithValue(ctx, resendKey{}, resend)
+}
+
+// Client returns a copy of c that retries with Default.
+func Client(c *http.Client) *http.Client {
+	if c == nil {
+		return nil
+	}
+	wrapped := *c
+	wrapped.Transport = Wrap(c.Transport, Default)
+	return &wrapped
+}
+
+// Wrap retries the calls sent through base according to p. A nil base is
+// http.DefaultTransport.
+func Wrap(base http.RoundTripper, p *Policy) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, policy: p}
+}
+
+type transport struct {
+	base   http.RoundTripper
+	policy *Policy
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if !replayable || req.URL.Query().Has("upload_id") {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := t.base.RoundTrip(req)
+		reason := ""
+		if err == nil {
+			if reason, err = quotaReason(res); err != nil {
+				return nil, err
+			}
+		}
+		if attempt >= t.policy.Attempts || !retryable(req, res, err, reason) {
+			return res, err
+		}
+
+		delay := t.policy.backoff(attempt)
+		if res != nil {
+			if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+				if after > t.policy.MaxDelay {
+					return res, err
+				}
+				delay = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxErrorBody))
+			_ = res.Body.Close()
+		}
+		slog.Debug(
+			"Retrying API call", "method", req.Method, "url", req.URL.Redacted(),
+			"attempt", attempt+1, "delay", delay, "status", status(res),
+			"reason", reason, "error", err,
+		)
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if resend, ok := req.Context().Value(resendKey{}).(func() error); ok {
+			if err := resend(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// backoff returns a random wait of up to BaseDelay doubled for every retry
+// made so far, capped at MaxDelay.
+func (p *Policy) backoff(attempt int) time.Duration {
+	ceiling := p.MaxDelay
+	if shift := attempt - 1; shift < 32 {
+		ceiling = min(p.BaseDelay<<shift, p.MaxDelay)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// retryable tells whether the outcome of req is worth another try.
+func retryable(req *http.Request, res *http.Response, err error, reason string) bool {
+	idempotent := req.Method != http.MethodPost && req.Method != http.MethodPatch
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return idempotent
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case reason == "rateLimitExceeded" || reason == "userRateLimitExceeded":
+		return true
+	case res.StatusCode >= 500 && res.StatusCode != http.StatusNotImplemented:
+		return idempotent
+	}
+	return false
+}
+
+// quotaReason returns the reason of a 403 or 429 response, leaving its body
+// readable, or ErrQuotaExhausted when the reason says the quota is gone.
+func quotaReason(res *http.Response) (string, error) {
+	if res.StatusCode != http.StatusForbidden &&
+		res.StatusCode != http.StatusTooManyRequests {
+		return "", nil
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return "", nil
+	}
+
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) != nil || len(payload.Error.Errors) == 0 {
+		return "", nil
+	}
+	reason := payload.Error.Errors[0].Reason
+	if reason == "quotaExceeded" || reason == "dailyLimitExceeded" {
+		return reason, failure.New(
+			failure.QuotaExceeded,
+			fmt.Errorf("%w: %s", ErrQuotaExhausted, payload.Error.Message),
+		)
+	}
+	return reason, nil
+}
+
+// retryAfter parses a Retry-After header given in seconds or as a date.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+func status(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+	return res.StatusCode
+}
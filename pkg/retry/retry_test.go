@@ -0,0 +1,286 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg/failure"
+)
+
+const (
+	rateLimited = `{"error":{"code":403,"message":"Rate limited","errors":[{"reason":"rateLimitExceeded"}]}}`
+	quotaGone   = `{"error":{"code":403,"message":"The request cannot be completed because you have exceeded your quota.","errors":[{"reason":"quotaExceeded"}]}}`
+)
+
+// replies answers with the given statuses in turn, the last one repeating,
+// and records the bodies it received.
+type replies struct {
+	statuses []int
+	bodies   []string
+	headers  http.Header
+	payload  string
+	calls    int
+}
+
+func (r *replies) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.bodies = append(r.bodies, string(body))
+	status := r.statuses[min(r.calls, len(r.statuses)-1)]
+	r.calls++
+	for k, v := range r.headers {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+	if status >= 400 {
+		_, _ = io.WriteString(w, r.payload)
+	}
+}
+
+func setSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	old := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = old })
+	return &waits
+}
+
+func newClient(p *Policy) *http.Client {
+	return &http.Client{Transport: Wrap(nil, p)}
+}
+
+var policy = &Policy{Attempts: 4, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+func TestTransport(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		payload  string
+		want     int
+		calls    int
+	}{
+		{name: "list 503", method: http.MethodGet, statuses: []int{503, 503, 200}, want: 200, calls: 3},
+		{name: "list gives up", method: http.MethodGet, statuses: []int{500}, want: 500, calls: 4},
+		{name: "update 502", method: http.MethodPut, statuses: []int{502, 200}, want: 200, calls: 2},
+		{name: "insert 503", method: http.MethodPost, statuses: []int{503, 200}, want: 503, calls: 1},
+		{name: "insert 429", method: http.MethodPost, statuses: []int{429, 200}, want: 200, calls: 2},
+		{
+			name: "insert rate limited", method: http.MethodPost, statuses: []int{403, 200},
+			payload: rateLimited, want: 200, calls: 2,
+		},
+		{
+			name: "forbidden", method: http.MethodGet, statuses: []int{403, 200},
+			payload: `{"error":{"errors":[{"reason":"forbidden"}]}}`, want: 403, calls: 1,
+		},
+		{name: "not found", method: http.MethodDelete, statuses: []int{404, 200}, want: 404, calls: 1},
+		{name: "not implemented", method: http.MethodGet, statuses: []int{501, 200}, want: 501, calls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				waits := setSleep(t)
+				r := &replies{statuses: tt.statuses, payload: tt.payload}
+				server := httptest.NewServer(r)
+				defer server.Close()
+
+				req, _ := http.NewRequest(tt.method, server.URL+"/youtube/v3/videos", strings.NewReader(`{"id":"x"}`))
+				res, err := newClient(policy).Do(req)
+				if err != nil {
+					t.Fatalf("Do() error = %v", err)
+				}
+				body, _ := io.ReadAll(res.Body)
+				_ = res.Body.Close()
+				if res.StatusCode != tt.want || r.calls != tt.calls {
+					t.Errorf("status = %d after %d calls, want %d after %d", res.StatusCode, r.calls, tt.want, tt.calls)
+				}
+				if len(*waits) != tt.calls-1 {
+					t.Errorf("waited %d times, want %d", len(*waits), tt.calls-1)
+				}
+				for i, b := range r.bodies {
+					if b != `{"id":"x"}` {
+						t.Errorf("body of call %d = %q", i+1, b)
+					}
+				}
+				if tt.want >= 400 && string(body) != tt.payload {
+					t.Errorf("response body = %q, want %q", body, tt.payload)
+				}
+			},
+		)
+	}
+}
+
+func TestTransport_QuotaExhausted(t *testing.T) {
+	setSleep(t)
+	r := &replies{statuses: []int{403, 200}, payload: quotaGone}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	_, err := newClient(policy).Get(server.URL + "/youtube/v3/search")
+	if !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("Get() error = %v, want %v", err, ErrQuotaExhausted)
+	}
+	if c := failure.Classify(err).Class; c != failure.QuotaExceeded {
+		t.Errorf("class = %s, want %s", c, failure.QuotaExceeded)
+	}
+	if !strings.Contains(err.Error(), "exceeded your quota") {
+		t.Errorf("Get() error = %v", err)
+	}
+	if r.calls != 1 {
+		t.Errorf("server saw %d calls, want 1", r.calls)
+	}
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		after string
+		waits []time.Duration
+		calls int
+	}{
+		{name: "seconds", after: "7", waits: []time.Duration{7 * time.Second}, calls: 2},
+		{name: "too long", after: "3600", calls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				waits := setSleep(t)
+				r := &replies{
+					statuses: []int{503, 200},
+					headers:  http.Header{"Retry-After": {tt.after}},
+				}
+				server := httptest.NewServer(r)
+				defer server.Close()
+
+				res, err := newClient(policy).Get(server.URL + "/youtube/v3/videos")
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				_ = res.Body.Close()
+				if r.calls != tt.calls || len(*waits) != len(tt.waits) {
+					t.Fatalf("calls = %d, waits = %v", r.calls, *waits)
+				}
+				for i, w := range tt.waits {
+					if (*waits)[i] != w {
+						t.Errorf("wait %d = %v, want %v", i, (*waits)[i], w)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestTransport_UploadChunk(t *testing.T) {
+	waits := setSleep(t)
+	r := &replies{statuses: []int{503, 200}}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/upload/youtube/v3/videos?upload_id=abc", strings.NewReader("chunk"))
+	res, err := newClient(policy).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = res.Body.Close()
+	if r.calls != 1 || len(*waits) != 0 {
+		t.Errorf("upload chunk was retried: %d calls", r.calls)
+	}
+}
+
+func TestTransport_Canceled(t *testing.T) {
+	old := sleep
+	t.Cleanup(func() { sleep = old })
+	r := &replies{statuses: []int{503}}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sleep = func(context.Context, time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/youtube/v3/videos", nil)
+	_, err := newClient(policy).Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want %v", err, context.Canceled)
+	}
+	if r.calls != 1 {
+		t.Errorf("server saw %d calls, want 1", r.calls)
+	}
+}
+
+func TestTransport_Resend(t *testing.T) {
+	setSleep(t)
+	r := &replies{statuses: []int{503}}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	errRefused := errors.New("refused")
+	resends := 0
+	ctx := WithResend(
+		context.Background(), func() error {
+			if resends++; resends == 2 {
+				return errRefused
+			}
+			return nil
+		},
+	)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err := newClient(policy).Do(req)
+	if !errors.Is(err, errRefused) {
+		t.Errorf("Do() error = %v, want %v", err, errRefused)
+	}
+	if r.calls != 2 {
+		t.Errorf("server saw %d calls, want 2", r.calls)
+	}
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	p := &Policy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, ceiling := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 60: 5 * time.Second} {
+		for range 20 {
+			if d := p.backoff(attempt); d < 0 || d > ceiling {
+				t.Errorf("backoff(%d) = %v, want within [0, %v]", attempt, d, ceiling)
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("12"); !ok || d != 12*time.Second {
+		t.Errorf("retryAfter(12) = %v, %v", d, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := retryAfter(date); !ok || d <= 0 || d > time.Minute {
+		t.Errorf("retryAfter(%q) = %v, %v", date, d, ok)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("retryAfter(soon) should fail")
+	}
+}
+
+func TestClient(t *testing.T) {
+	if Client(nil) != nil {
+		t.Error("Client(nil) should be nil")
+	}
+	base := &http.Client{Timeout: time.Minute}
+	c := Client(base)
+	if c == base || c.Timeout != time.Minute || base.Transport != nil {
+		t.Error("Client() should copy the client")
+	}
+	if _, ok := c.Transport.(*transport); !ok {
+		t.Errorf("Transport = %T", c.Transport)
+	}
+}
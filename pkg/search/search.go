@@ -6,6 +6,7 @@ package search
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -46,6 +47,7 @@ type Search struct {
 
 type ISearch[T any] interface {
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	List(io.Writer) error
 }
 
@@ -60,8 +62,12 @@ func NewSearch(opts ...Option) ISearch[youtube.SearchResult] {
 }
 
 func (s *Search) Get() ([]*youtube.SearchResult, error) {
+	return common.Collect(s.All())
+}
+
+func (s *Search) All() iter.Seq2[*youtube.SearchResult, error] {
 	if err := s.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.SearchResult](err)
 	}
 	call := s.Service.Search.List(s.Parts)
 	if s.ChannelId != "" {
@@ -148,36 +154,38 @@ func (s *Search) Get() ([]*youtube.SearchResult, error) {
 		call = call.VideoType(s.VideoType)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&s.Fields, call,
-		func(r *youtube.SearchListResponse) ([]*youtube.SearchResult, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.SearchListResponse) (
+			[]*youtube.SearchResult, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetSearch,
 	)
 }
 
 func (s *Search) List(writer io.Writer) error {
-	results, err := s.Get()
-	if err != nil && results == nil {
-		return err
-	}
-
-	common.PrintList(
-		s.Output, results, writer, table.Row{"Kind", "Title", "Resource ID"},
+	return common.PrintSeq(
+		&s.Fields, s.All(), writer, table.Row{"Kind", "Title", "Resource ID"},
 		func(r *youtube.SearchResult) table.Row {
-			var resourceId string
-			switch r.Id.Kind {
-			case "youtube#video":
-				resourceId = r.Id.VideoId
-			case "youtube#channel":
-				resourceId = r.Id.ChannelId
-			case "youtube#playlist":
-				resourceId = r.Id.PlaylistId
+			var kind, title, resourceId string
+			if r.Id != nil {
+				kind = r.Id.Kind
+				switch kind {
+				case "youtube#video":
+					resourceId = r.Id.VideoId
+				case "youtube#channel":
+					resourceId = r.Id.ChannelId
+				case "youtube#playlist":
+					resourceId = r.Id.PlaylistId
+				}
+			}
+			if r.Snippet != nil {
+				title = r.Snippet.Title
 			}
-			return table.Row{r.Id.Kind, r.Snippet.Title, resourceId}
+			return table.Row{kind, title, resourceId}
 		},
 	)
-	return err
 }
 
 func WithChannelType(channelType string) Option {
@@ -326,6 +334,12 @@ func WithVideoType(videoType string) Option {
 
 var (
 	WithParts      = common.WithParts[*Search]
+	WithColumns    = common.WithColumns[*Search]
+	WithWhere      = common.WithWhere[*Search]
+	WithSortBy     = common.WithSortBy[*Search]
+	WithFieldMask  = common.WithFieldMask[*Search]
+	WithPageToken  = common.WithPageToken[*Search]
+	WithEnvelope   = common.WithEnvelope[*Search]
 	WithOutput     = common.WithOutput[*Search]
 	WithService    = common.WithService[*Search]
 	WithMaxResults = common.WithMaxResults[*Search]
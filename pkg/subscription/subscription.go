@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -33,6 +34,7 @@ type Subscription struct {
 
 type ISubscription[T any] interface {
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	List(io.Writer) error
 	Insert(io.Writer) error
 	Delete(io.Writer) error
@@ -49,8 +51,12 @@ func NewSubscription(opts ...Option) ISubscription[youtube.Subscription] {
 }
 
 func (s *Subscription) Get() ([]*youtube.Subscription, error) {
+	return common.Collect(s.All())
+}
+
+func (s *Subscription) All() iter.Seq2[*youtube.Subscription, error] {
 	if err := s.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Subscription](err)
 	}
 	call := s.Service.Subscriptions.List(s.Parts)
 	if len(s.Ids) > 0 {
@@ -80,37 +86,39 @@ func (s *Subscription) Get() ([]*youtube.Subscription, error) {
 		call = call.Order(s.Order)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&s.Fields, call,
-		func(r *youtube.SubscriptionListResponse) ([]*youtube.Subscription, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.SubscriptionListResponse) (
+			[]*youtube.Subscription, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetSubscription,
 	)
 }
 
 func (s *Subscription) List(writer io.Writer) error {
-	subscriptions, err := s.Get()
-	if err != nil && subscriptions == nil {
-		return err
-	}
-
-	common.PrintList(
-		s.Output, subscriptions, writer,
+	return common.PrintSeq(
+		&s.Fields, s.All(), writer,
 		table.Row{"ID", "Kind", "Resource ID", "Channel Title"},
 		func(sub *youtube.Subscription) table.Row {
-			var resourceId string
-			switch sub.Snippet.ResourceId.Kind {
-			case "youtube#video":
-				resourceId = sub.Snippet.ResourceId.VideoId
-			case "youtube#channel":
-				resourceId = sub.Snippet.ResourceId.ChannelId
-			case "youtube#playlist":
-				resourceId = sub.Snippet.ResourceId.PlaylistId
+			var kind, resourceId, title string
+			if sub.Snippet != nil {
+				title = sub.Snippet.Title
+				if sub.Snippet.ResourceId != nil {
+					kind = sub.Snippet.ResourceId.Kind
+					switch kind {
+					case "youtube#video":
+						resourceId = sub.Snippet.ResourceId.VideoId
+					case "youtube#channel":
+						resourceId = sub.Snippet.ResourceId.ChannelId
+					case "youtube#playlist":
+						resourceId = sub.Snippet.ResourceId.PlaylistId
+					}
+				}
 			}
-			return table.Row{sub.Id, sub.Snippet.ResourceId.Kind, resourceId, sub.Snippet.Title}
+			return table.Row{sub.Id, kind, resourceId, title}
 		},
 	)
-	return err
 }
 
 func (s *Subscription) Insert(writer io.Writer) error {
@@ -200,6 +208,12 @@ func WithTitle(title string) Option {
 
 var (
 	WithParts      = common.WithParts[*Subscription]
+	WithColumns    = common.WithColumns[*Subscription]
+	WithWhere      = common.WithWhere[*Subscription]
+	WithSortBy     = common.WithSortBy[*Subscription]
+	WithFieldMask  = common.WithFieldMask[*Subscription]
+	WithPageToken  = common.WithPageToken[*Subscription]
+	WithEnvelope   = common.WithEnvelope[*Subscription]
 	WithOutput     = common.WithOutput[*Subscription]
 	WithService    = common.WithService[*Subscription]
 	WithIds        = common.WithIds[*Subscription]
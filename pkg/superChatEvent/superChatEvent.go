@@ -6,6 +6,7 @@ package superChatEvent
 import (
 	"errors"
 	"io"
+	"iter"
 
 	"github.com/eat-pray-ai/yutu/pkg/common"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -22,6 +23,7 @@ type SuperChatEvent struct {
 
 type ISuperChatEvent[T any] interface {
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 	List(io.Writer) error
 }
 
@@ -36,41 +38,57 @@ func NewSuperChatEvent(opts ...Option) ISuperChatEvent[youtube.SuperChatEvent] {
 }
 
 func (s *SuperChatEvent) Get() ([]*youtube.SuperChatEvent, error) {
+	return common.Collect(s.All())
+}
+
+func (s *SuperChatEvent) All() iter.Seq2[*youtube.SuperChatEvent, error] {
 	if err := s.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.SuperChatEvent](err)
 	}
 	call := s.Service.SuperChatEvents.List(s.Parts)
 	if s.Hl != "" {
 		call = call.Hl(s.Hl)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&s.Fields, call,
-		func(r *youtube.SuperChatEventListResponse) ([]*youtube.SuperChatEvent, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.SuperChatEventListResponse) (
+			[]*youtube.SuperChatEvent, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetSuperChatEvent,
 	)
 }
 
 func (s *SuperChatEvent) List(writer io.Writer) error {
-	events, err := s.Get()
-	if err != nil && events == nil {
-		return err
-	}
-
-	common.PrintList(
-		s.Output, events, writer, table.Row{"ID", "Amount", "Comment", "Supporter"},
+	return common.PrintSeq(
+		&s.Fields, s.All(), writer, table.Row{"ID", "Amount", "Comment", "Supporter"},
 		func(e *youtube.SuperChatEvent) table.Row {
-			return table.Row{e.Id, e.Snippet.DisplayString, e.Snippet.CommentText, e.Snippet.SupporterDetails.DisplayName}
+			amount := ""
+			comment := ""
+			supporter := ""
+			if e.Snippet != nil {
+				amount = e.Snippet.DisplayString
+				comment = e.Snippet.CommentText
+				if e.Snippet.SupporterDetails != nil {
+					supporter = e.Snippet.SupporterDetails.DisplayName
+				}
+			}
+			return table.Row{e.Id, amount, comment, supporter}
 		},
 	)
-	return err
 }
 
 var (
 	WithHl         = common.WithHl[*SuperChatEvent]
 	WithMaxResults = common.WithMaxResults[*SuperChatEvent]
 	WithParts      = common.WithParts[*SuperChatEvent]
+	WithColumns    = common.WithColumns[*SuperChatEvent]
+	WithWhere      = common.WithWhere[*SuperChatEvent]
+	WithSortBy     = common.WithSortBy[*SuperChatEvent]
+	WithFieldMask  = common.WithFieldMask[*SuperChatEvent]
+	WithPageToken  = common.WithPageToken[*SuperChatEvent]
+	WithEnvelope   = common.WithEnvelope[*SuperChatEvent]
 	WithOutput     = common.WithOutput[*SuperChatEvent]
 	WithService    = common.WithService[*SuperChatEvent]
 )
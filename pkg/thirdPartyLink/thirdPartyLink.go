@@ -61,6 +61,11 @@ func (tpl *ThirdPartyLink) Get() ([]*youtube.ThirdPartyLink, error) {
 		call = call.ExternalChannelId(tpl.ExternalChannelId)
 	}
 
+	call, err := common.ApplyMask(&tpl.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetThirdPartyLink, err)
@@ -74,8 +79,8 @@ func (tpl *ThirdPartyLink) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		tpl.Output, links, writer,
+	return common.PrintList(
+		&tpl.Fields, links, writer,
 		table.Row{"Linking Token", "Type", "Link Status"},
 		func(link *youtube.ThirdPartyLink) table.Row {
 			var linkStatus, linkType string
@@ -88,7 +93,6 @@ func (tpl *ThirdPartyLink) List(writer io.Writer) error {
 			return table.Row{link.LinkingToken, linkType, linkStatus}
 		},
 	)
-	return nil
 }
 
 func (tpl *ThirdPartyLink) Insert(writer io.Writer) error {
@@ -213,7 +217,11 @@ func WithExternalChannelId(externalChannelId string) Option {
 }
 
 var (
-	WithParts   = common.WithParts[*ThirdPartyLink]
-	WithOutput  = common.WithOutput[*ThirdPartyLink]
-	WithService = common.WithService[*ThirdPartyLink]
+	WithParts     = common.WithParts[*ThirdPartyLink]
+	WithColumns   = common.WithColumns[*ThirdPartyLink]
+	WithWhere     = common.WithWhere[*ThirdPartyLink]
+	WithSortBy    = common.WithSortBy[*ThirdPartyLink]
+	WithFieldMask = common.WithFieldMask[*ThirdPartyLink]
+	WithOutput    = common.WithOutput[*ThirdPartyLink]
+	WithService   = common.WithService[*ThirdPartyLink]
 )
@@ -9,6 +9,7 @@ import (
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
 )
 
 var (
@@ -44,7 +45,7 @@ func (t *Thumbnail) Set(writer io.Writer) error {
 		return errors.Join(errSetThumbnail, err)
 	}
 
-	call := t.Service.Thumbnails.Set(t.VideoId).Media(file)
+	call := t.Service.Thumbnails.Set(t.VideoId).Media(progress.File(t.Ctx, file))
 	res, err := call.Do()
 	if err != nil {
 		return errors.Join(errSetThumbnail, err)
@@ -71,4 +72,5 @@ func WithFile(file string) Option {
 var (
 	WithOutput  = common.WithOutput[*Thumbnail]
 	WithService = common.WithService[*Thumbnail]
+	WithContext = common.WithContext[*Thumbnail]
 )
@@ -0,0 +1,422 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	// StateDir is the directory under pkg.Root that holds resume state files.
+	StateDir = ".yutu/uploads"
+	// ChunkSize is the default chunk size, a multiple of the 256 KiB the
+	// resumable protocol requires.
+	ChunkSize = googleapi.DefaultUploadChunkSize
+	chunkUnit = 256 * 1024
+
+	statusResumeIncomplete = 308
+)
+
+var (
+	errInitiate    = errors.New("failed to initiate resumable upload")
+	errUploadChunk = errors.New("failed to upload chunk")
+	errQueryOffset = errors.New("failed to query upload offset")
+	errNoSession   = errors.New("resumable upload session URI missing")
+	errSaveState   = errors.New("failed to save upload state")
+	errBadRange    = errors.New("malformed Range header")
+)
+
+// State is the on-disk record that lets an interrupted upload continue.
+type State struct {
+	SessionURI string    `json:"session_uri"`
+	File       string    `json:"file"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"mod_time"`
+	Offset     int64     `json:"offset"`
+}
+
+// Resumable uploads media through the YouTube resumable upload protocol,
+// persisting the session URI and confirmed offset after every chunk.
+type Resumable struct {
+	Client      *http.Client
+	URL         string
+	Metadata    any
+	ContentType string
+	ChunkSize   int64
+	// StatePath is relative to pkg.Root; an empty path disables persistence.
+	StatePath string
+	// Progress is told the confirmed offset after every chunk; may be nil.
+	Progress *progress.Tracker
+}
+
+// StatePath returns the state file used for the given media file. A relative
+// file is resolved against pkg.Root, which it is opened from, so the same
+// upload is found from any working directory.
+func StatePath(file string) string {
+	abs := file
+	if !filepath.IsAbs(file) {
+		abs = filepath.Join(*pkg.RootDir, file)
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.ToSlash(
+		filepath.Join(StateDir, hex.EncodeToString(sum[:8])+".json"),
+	)
+}
+
+// Upload sends media of the given size and returns the body of the final
+// response. If a matching state file exists the upload continues from the
+// offset the server confirms instead of starting over.
+func (r *Resumable) Upload(
+	ctx context.Context, media io.ReadSeeker, file string, size int64,
+	modTime time.Time,
+) ([]byte, error) {
+	chunkSize := r.chunkSize()
+	state := r.loadState(file, size, modTime)
+	if state != nil {
+		offset, body, err := r.queryOffset(ctx, state)
+		switch {
+		case body != nil:
+			r.removeState()
+			r.Progress.Set(size)
+			r.Progress.Finish()
+			return body, nil
+		case err != nil:
+			slog.Warn(
+				"discarding stale upload session", "file", file, "error", err,
+			)
+			state = nil
+		default:
+			state.Offset = offset
+			r.Progress.Resume(offset)
+			slog.Debug("Resuming upload", "file", file, "offset", offset)
+		}
+	}
+
+	if state == nil {
+		uri, err := r.initiate(ctx, size)
+		if err != nil {
+			return nil, err
+		}
+		state = &State{
+			SessionURI: uri, File: file, Size: size, ModTime: modTime,
+		}
+		if err := r.saveState(state); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		end := min(state.Offset+chunkSize, size)
+		if _, err := media.Seek(state.Offset, io.SeekStart); err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+		req, err := http.NewRequestWithContext(
+			ctx, http.MethodPut, state.SessionURI,
+			io.LimitReader(media, end-state.Offset),
+		)
+		if err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+		req.ContentLength = end - state.Offset
+		req.Header.Set("Content-Type", r.ContentType)
+		req.Header.Set(
+			"Content-Range", contentRange(state.Offset, end, size),
+		)
+
+		res, err := r.Client.Do(req)
+		if err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+		body, err := readBody(res)
+		if err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+
+		switch res.StatusCode {
+		case http.StatusOK, http.StatusCreated:
+			r.removeState()
+			r.Progress.Set(size)
+			r.Progress.Finish()
+			return body, nil
+		case statusResumeIncomplete:
+			next, err := nextOffset(res.Header.Get("Range"))
+			if err != nil {
+				return nil, errors.Join(errUploadChunk, err)
+			}
+			state.Offset = next
+			r.Progress.Set(next)
+			if err := r.saveState(state); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Join(errUploadChunk, responseError(res, body))
+		}
+	}
+}
+
+// UploadStream sends media of unknown length and returns the body of the
+// final response. Each chunk is buffered in memory until the server confirms
+// it, and the total size is announced with the last chunk. Streams cannot be
+// reread, so no resume state is persisted.
+func (r *Resumable) UploadStream(ctx context.Context, media io.Reader) ([]byte, error) {
+	chunkSize := r.chunkSize()
+	uri, err := r.initiate(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset int64
+	pending := make([]byte, 0, chunkSize)
+	eof := false
+	for {
+		if !eof && int64(len(pending)) < chunkSize {
+			n, err := io.ReadFull(media, pending[len(pending):chunkSize])
+			pending = pending[:len(pending)+n]
+			switch {
+			case err == io.EOF || err == io.ErrUnexpectedEOF:
+				eof = true
+			case err != nil:
+				return nil, errors.Join(errUploadChunk, err)
+			}
+		}
+
+		end := offset + int64(len(pending))
+		total := "*"
+		if eof {
+			total = strconv.FormatInt(end, 10)
+		}
+		req, err := http.NewRequestWithContext(
+			ctx, http.MethodPut, uri, bytes.NewReader(pending),
+		)
+		if err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+		req.ContentLength = int64(len(pending))
+		req.Header.Set("Content-Type", r.ContentType)
+		if len(pending) == 0 {
+			req.Header.Set("Content-Range", "bytes */"+total)
+		} else {
+			req.Header.Set(
+				"Content-Range", fmt.Sprintf("bytes %d-%d/%s", offset, end-1, total),
+			)
+		}
+
+		res, err := r.Client.Do(req)
+		if err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+		body, err := readBody(res)
+		if err != nil {
+			return nil, errors.Join(errUploadChunk, err)
+		}
+
+		switch res.StatusCode {
+		case http.StatusOK, http.StatusCreated:
+			r.Progress.Set(end)
+			r.Progress.Finish()
+			return body, nil
+		case statusResumeIncomplete:
+			next, err := nextOffset(res.Header.Get("Range"))
+			if err != nil || next < offset || next > end {
+				return nil, errors.Join(
+					errUploadChunk, fmt.Errorf("%w: %q", errBadRange, res.Header.Get("Range")),
+				)
+			}
+			// Keep whatever the server did not persist for the next request.
+			pending = append(pending[:0], pending[next-offset:]...)
+			offset = next
+			r.Progress.Set(offset)
+		default:
+			return nil, errors.Join(errUploadChunk, responseError(res, body))
+		}
+	}
+}
+
+func (r *Resumable) chunkSize() int64 {
+	chunkSize := r.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = ChunkSize
+	}
+	if rem := chunkSize % chunkUnit; rem != 0 {
+		chunkSize += chunkUnit - rem
+	}
+	return chunkSize
+}
+
+// initiate opens an upload session; a negative size leaves the length open.
+func (r *Resumable) initiate(ctx context.Context, size int64) (string, error) {
+	metadata, err := json.Marshal(r.Metadata)
+	if err != nil {
+		return "", errors.Join(errInitiate, err)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, r.URL, bytes.NewReader(metadata),
+	)
+	if err != nil {
+		return "", errors.Join(errInitiate, err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Type", r.ContentType)
+	if size >= 0 {
+		req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(size, 10))
+	}
+
+	res, err := r.Client.Do(req)
+	if err != nil {
+		return "", errors.Join(errInitiate, err)
+	}
+	body, err := readBody(res)
+	if err != nil {
+		return "", errors.Join(errInitiate, err)
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return "", errors.Join(errInitiate, responseError(res, body))
+	}
+	uri := res.Header.Get("Location")
+	if uri == "" {
+		return "", errors.Join(errInitiate, errNoSession)
+	}
+	return uri, nil
+}
+
+// queryOffset asks the server how many bytes of the session it already holds.
+// A non-nil body means the upload had completed before the interruption.
+func (r *Resumable) queryOffset(ctx context.Context, state *State) (
+	int64, []byte, error,
+) {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, state.SessionURI, http.NoBody,
+	)
+	if err != nil {
+		return 0, nil, errors.Join(errQueryOffset, err)
+	}
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", state.Size))
+
+	res, err := r.Client.Do(req)
+	if err != nil {
+		return 0, nil, errors.Join(errQueryOffset, err)
+	}
+	body, err := readBody(res)
+	if err != nil {
+		return 0, nil, errors.Join(errQueryOffset, err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return state.Size, body, nil
+	case statusResumeIncomplete:
+		offset, err := nextOffset(res.Header.Get("Range"))
+		if err != nil {
+			return 0, nil, errors.Join(errQueryOffset, err)
+		}
+		return offset, nil, nil
+	default:
+		return 0, nil, errors.Join(errQueryOffset, responseError(res, body))
+	}
+}
+
+func (r *Resumable) loadState(file string, size int64, modTime time.Time) *State {
+	if r.StatePath == "" {
+		return nil
+	}
+	data, err := pkg.Root.ReadFile(r.StatePath)
+	if err != nil {
+		return nil
+	}
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		slog.Warn("ignoring corrupt upload state", "path", r.StatePath, "error", err)
+		return nil
+	}
+	if state.File != file || state.Size != size || !state.ModTime.Equal(modTime) {
+		slog.Warn(
+			"ignoring upload state for a different file", "path", r.StatePath,
+		)
+		return nil
+	}
+	return state
+}
+
+func (r *Resumable) saveState(state *State) error {
+	if r.StatePath == "" {
+		return nil
+	}
+	if err := pkg.Root.MkdirAll(filepath.Dir(r.StatePath), 0755); err != nil {
+		return errors.Join(errSaveState, err)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errors.Join(errSaveState, err)
+	}
+	if err := pkg.Root.WriteFile(r.StatePath, data, 0600); err != nil {
+		return errors.Join(errSaveState, err)
+	}
+	return nil
+}
+
+func (r *Resumable) removeState() {
+	if r.StatePath == "" {
+		return
+	}
+	if err := pkg.Root.Remove(r.StatePath); err != nil && !os.IsNotExist(err) {
+		slog.Warn("failed to remove upload state", "path", r.StatePath, "error", err)
+	}
+}
+
+func contentRange(start, end, size int64) string {
+	if start == end {
+		return fmt.Sprintf("bytes */%d", size)
+	}
+	return fmt.Sprintf("bytes %d-%d/%d", start, end-1, size)
+}
+
+// nextOffset parses a "bytes=0-N" Range header into the next byte to send.
+// A missing header means the server has not persisted anything yet.
+func nextOffset(header string) (int64, error) {
+	if header == "" {
+		return 0, nil
+	}
+	_, last, ok := strings.Cut(strings.TrimPrefix(header, "bytes="), "-")
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errBadRange, header)
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errBadRange, header)
+	}
+	return n + 1, nil
+}
+
+func readBody(res *http.Response) ([]byte, error) {
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	return io.ReadAll(res.Body)
+}
+
+func responseError(res *http.Response, body []byte) error {
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected status %s", res.Status)
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+)
+
+// fakeSession implements the server side of the resumable upload protocol.
+type fakeSession struct {
+	mu        sync.Mutex
+	received  []byte
+	size      int64
+	initiated int
+	failAfter int
+	chunks    int
+}
+
+func (f *fakeSession) handler(t *testing.T, baseURL *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			f.initiated++
+			if got := r.URL.Query().Get("uploadType"); got != "resumable" {
+				t.Errorf("uploadType = %q, want resumable", got)
+			}
+			var meta map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&meta); err != nil {
+				t.Errorf("failed to decode metadata: %v", err)
+			}
+			_, _ = fmt.Sscan(r.Header.Get("X-Upload-Content-Length"), &f.size)
+			w.Header().Set("Location", *baseURL+"/session")
+		case http.MethodPut:
+			cr := r.Header.Get("Content-Range")
+			body, _ := io.ReadAll(r.Body)
+			if strings.HasPrefix(cr, "bytes */") {
+				var total int64
+				if _, err := fmt.Sscanf(cr, "bytes */%d", &total); err == nil &&
+					total > 0 && total == int64(len(f.received)) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"id": "uploaded"}`))
+					return
+				}
+				if len(f.received) > 0 {
+					w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(f.received)-1))
+				}
+				w.WriteHeader(statusResumeIncomplete)
+				return
+			}
+			var start, end int64
+			var totalStr string
+			_, _ = fmt.Sscanf(cr, "bytes %d-%d/%s", &start, &end, &totalStr)
+			total, err := strconv.ParseInt(totalStr, 10, 64)
+			if err != nil {
+				total = -1 // "*": more chunks follow
+			}
+			if start != int64(len(f.received)) {
+				t.Errorf("chunk starts at %d, want %d", start, len(f.received))
+			}
+			if f.failAfter > 0 && f.chunks >= f.failAfter {
+				http.Error(w, "backend error", http.StatusServiceUnavailable)
+				return
+			}
+			f.chunks++
+			f.received = append(f.received, body...)
+			if total < 0 || int64(len(f.received)) < total {
+				w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(f.received)-1))
+				w.WriteHeader(statusResumeIncomplete)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": "uploaded"}`))
+		}
+	}
+}
+
+func newServer(t *testing.T, f *fakeSession) *httptest.Server {
+	t.Helper()
+	var baseURL string
+	ts := httptest.NewServer(f.handler(t, &baseURL))
+	baseURL = ts.URL
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestStatePath(t *testing.T) {
+	dir := common.SetTestRoot(t)
+	want := StatePath("video.mp4")
+	if got := StatePath(filepath.Join(dir, "video.mp4")); got != want {
+		t.Errorf("StatePath(absolute) = %q, want %q", got, want)
+	}
+	t.Chdir(t.TempDir())
+	if got := StatePath("video.mp4"); got != want {
+		t.Errorf("StatePath() in another directory = %q, want %q", got, want)
+	}
+}
+
+func TestResumable_Upload(t *testing.T) {
+	common.SetTestRoot(t)
+	f := &fakeSession{}
+	ts := newServer(t, f)
+	data := bytes.Repeat([]byte("y"), 2*chunkUnit+10)
+	modTime := time.Unix(1700000000, 0)
+
+	r := &Resumable{
+		Client:      ts.Client(),
+		URL:         ts.URL + "/upload?uploadType=resumable",
+		Metadata:    map[string]string{"title": "t"},
+		ContentType: "video/mp4",
+		ChunkSize:   1,
+		StatePath:   StatePath("video.mp4"),
+	}
+	body, err := r.Upload(
+		context.Background(), bytes.NewReader(data), "video.mp4",
+		int64(len(data)), modTime,
+	)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if !strings.Contains(string(body), "uploaded") {
+		t.Errorf("Upload() body = %s", body)
+	}
+	if f.chunks != 3 {
+		t.Errorf("uploaded in %d chunks, want 3", f.chunks)
+	}
+	if !bytes.Equal(f.received, data) {
+		t.Errorf("server received %d bytes, want %d", len(f.received), len(data))
+	}
+	if _, err := pkg.Root.Stat(r.StatePath); !os.IsNotExist(err) {
+		t.Errorf("state file should be removed after success, stat err = %v", err)
+	}
+}
+
+func TestResumable_Upload_Resume(t *testing.T) {
+	common.SetTestRoot(t)
+	f := &fakeSession{failAfter: 1}
+	ts := newServer(t, f)
+	data := bytes.Repeat([]byte("z"), 3*chunkUnit)
+	modTime := time.Unix(1700000000, 0)
+
+	newResumable := func() *Resumable {
+		return &Resumable{
+			Client:      ts.Client(),
+			URL:         ts.URL + "/upload?uploadType=resumable",
+			Metadata:    map[string]string{"title": "t"},
+			ContentType: "video/mp4",
+			ChunkSize:   chunkUnit,
+			StatePath:   StatePath("video.mp4"),
+		}
+	}
+
+	_, err := newResumable().Upload(
+		context.Background(), bytes.NewReader(data), "video.mp4",
+		int64(len(data)), modTime,
+	)
+	if err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+
+	raw, err := pkg.Root.ReadFile(StatePath("video.mp4"))
+	if err != nil {
+		t.Fatalf("state file missing after failure: %v", err)
+	}
+	state := &State{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		t.Fatalf("invalid state file: %v", err)
+	}
+	if state.Offset != chunkUnit || state.SessionURI != ts.URL+"/session" {
+		t.Errorf("state = %+v, want offset %d", state, chunkUnit)
+	}
+
+	f.failAfter = 0
+	_, err = newResumable().Upload(
+		context.Background(), bytes.NewReader(data), "video.mp4",
+		int64(len(data)), modTime,
+	)
+	if err != nil {
+		t.Fatalf("resumed Upload() error = %v", err)
+	}
+	if f.initiated != 1 {
+		t.Errorf("session initiated %d times, want 1", f.initiated)
+	}
+	if !bytes.Equal(f.received, data) {
+		t.Errorf("server received %d bytes, want %d", len(f.received), len(data))
+	}
+}
+
+func TestResumable_Upload_StaleState(t *testing.T) {
+	common.SetTestRoot(t)
+	f := &fakeSession{}
+	ts := newServer(t, f)
+	data := []byte("small video")
+
+	r := &Resumable{
+		Client:      ts.Client(),
+		URL:         ts.URL + "/upload?uploadType=resumable",
+		ContentType: "video/mp4",
+		StatePath:   StatePath("video.mp4"),
+	}
+	if err := r.saveState(
+		&State{
+			SessionURI: ts.URL + "/old", File: "video.mp4", Size: 999,
+			ModTime: time.Unix(1, 0),
+		},
+	); err != nil {
+		t.Fatalf("saveState() error = %v", err)
+	}
+
+	_, err := r.Upload(
+		context.Background(), bytes.NewReader(data), "video.mp4",
+		int64(len(data)), time.Unix(2, 0),
+	)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if f.initiated != 1 {
+		t.Errorf("session initiated %d times, want 1", f.initiated)
+	}
+}
+
+func TestResumable_UploadStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       int
+		wantChunks int
+	}{
+		{name: "shorter than a chunk", size: 10, wantChunks: 1},
+		{name: "several chunks", size: 2*chunkUnit + 7, wantChunks: 3},
+		{name: "chunk boundary", size: 2 * chunkUnit, wantChunks: 2},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				common.SetTestRoot(t)
+				f := &fakeSession{}
+				ts := newServer(t, f)
+				data := bytes.Repeat([]byte("s"), tt.size)
+
+				r := &Resumable{
+					Client:      ts.Client(),
+					URL:         ts.URL + "/upload?uploadType=resumable",
+					Metadata:    map[string]string{"title": "t"},
+					ContentType: "video/mp4",
+					ChunkSize:   chunkUnit,
+				}
+				// Hide Seek so the stream cannot be rewound.
+				body, err := r.UploadStream(
+					context.Background(), struct{ io.Reader }{bytes.NewReader(data)},
+				)
+				if err != nil {
+					t.Fatalf("UploadStream() error = %v", err)
+				}
+				if !strings.Contains(string(body), "uploaded") {
+					t.Errorf("UploadStream() body = %s", body)
+				}
+				if f.size != 0 {
+					t.Errorf("stream announced a length of %d", f.size)
+				}
+				if f.chunks != tt.wantChunks {
+					t.Errorf("uploaded in %d chunks, want %d", f.chunks, tt.wantChunks)
+				}
+				if !bytes.Equal(f.received, data) {
+					t.Errorf("server received %d bytes, want %d", len(f.received), len(data))
+				}
+			},
+		)
+	}
+}
+
+func TestNextOffset(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{header: "", want: 0},
+		{header: "bytes=0-0", want: 1},
+		{header: "bytes=0-262143", want: 262144},
+		{header: "garbage", wantErr: true},
+		{header: "bytes=0-x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.header, func(t *testing.T) {
+				got, err := nextOffset(tt.header)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("nextOffset(%q) error = %v", tt.header, err)
+				}
+				if got != tt.want {
+					t.Errorf("nextOffset(%q) = %d, want %d", tt.header, got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/eat-pray-ai/yutu/pkg/failure"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/term"
@@ -118,7 +119,12 @@ func ExtractHl(uri string) string {
 	return ""
 }
 
-var ErrNotConfirmed = errors.New("operation not confirmed (pass confirm: true or rerun with --yes)")
+// ErrNotConfirmed is an invalid argument, as passing --yes or confirm: true
+// makes the same call go through.
+var ErrNotConfirmed = failure.New(
+	failure.InvalidArgument,
+	errors.New("operation not confirmed (pass confirm: true or rerun with --yes)"),
+)
 
 func ConfirmPreRun(cmd *cobra.Command, msg string) error {
 	if yes, _ := cmd.Flags().GetBool("yes"); yes {
@@ -143,10 +149,22 @@ func ConfirmPreRun(cmd *cobra.Command, msg string) error {
 	return nil
 }
 
+// Exit ends the process, tests replace it.
+var Exit = os.Exit
+
+// HandleCmdError reports err and exits with the code of its failure class.
+// With --output json the error goes to stderr as a JSON object instead of
+// the usage and message.
 func HandleCmdError(err error, cmd *cobra.Command) {
 	if err == nil {
 		return
 	}
-	_ = cmd.Help()
-	cmd.PrintErrf("Error: %v\n", err)
+	f := failure.Classify(err)
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		PrintJSON(map[string]any{"error": f}, cmd.ErrOrStderr())
+	} else {
+		_ = cmd.Help()
+		cmd.PrintErrf("Error: %v\n", err)
+	}
+	Exit(f.ExitCode)
 }
@@ -12,8 +12,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/eat-pray-ai/yutu/pkg/failure"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"google.golang.org/api/googleapi"
 )
 
 func TestStrToBoolPtr(t *testing.T) {
@@ -471,6 +473,9 @@ func TestConfirmPreRun(t *testing.T) {
 				if err != tt.wantErr {
 					t.Errorf("ConfirmPreRun() error = %v, want %v", err, tt.wantErr)
 				}
+				if err != nil && failure.Classify(err).Class != failure.InvalidArgument {
+					t.Errorf("ConfirmPreRun() error = %v, want invalid argument", err)
+				}
 			},
 		)
 	}
@@ -478,16 +483,19 @@ func TestConfirmPreRun(t *testing.T) {
 
 func TestHandleCmdError(t *testing.T) {
 	tests := []struct {
-		name    string
-		input   error
-		wantOut string
-		wantErr string
+		name     string
+		input    error
+		output   string
+		wantOut  string
+		wantErr  string
+		wantCode int
 	}{
 		{
-			name:    "with error",
-			input:   fmt.Errorf("some error"),
-			wantOut: "help called",
-			wantErr: "Error: some error\n",
+			name:     "with error",
+			input:    fmt.Errorf("some error"),
+			wantOut:  "help called",
+			wantErr:  "Error: some error\n",
+			wantCode: 1,
 		},
 		{
 			name:    "without error",
@@ -495,12 +503,40 @@ func TestHandleCmdError(t *testing.T) {
 			wantOut: "",
 			wantErr: "",
 		},
+		{
+			name: "not found",
+			input: &googleapi.Error{
+				Code: 404, Message: "Video not found",
+				Errors: []googleapi.ErrorItem{{Reason: "videoNotFound", Message: "Video not found"}},
+			},
+			wantOut:  "help called",
+			wantErr:  "Error: googleapi: Error 404: Video not found, videoNotFound\n",
+			wantCode: 5,
+		},
+		{
+			name: "json",
+			input: fmt.Errorf(
+				"failed to list: %w", &googleapi.Error{
+					Code: 403, Message: "Quota exceeded",
+					Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded", Message: "Quota exceeded"}},
+				},
+			),
+			output:   "json",
+			wantOut:  "",
+			wantErr:  `{"error":{"class":"quotaExceeded","exit_code":6,"status":403,"reason":"quotaExceeded","message":"failed to list: googleapi: Error 403: Quota exceeded, quotaExceeded"}}` + "\n",
+			wantCode: 6,
+		},
 	}
 
+	oldExit := Exit
+	t.Cleanup(func() { Exit = oldExit })
 	for _, tt := range tests {
 		t.Run(
 			tt.name, func(t *testing.T) {
+				code := 0
+				Exit = func(c int) { code = c }
 				cmd := &cobra.Command{Use: "test"}
+				cmd.Flags().String("output", tt.output, "")
 				var outBuf, errBuf bytes.Buffer
 				cmd.SetOut(&outBuf)
 				cmd.SetErr(&errBuf)
@@ -523,6 +559,10 @@ func TestHandleCmdError(t *testing.T) {
 						"unexpected stderr output, got %q, want %q", gotErr, tt.wantErr,
 					)
 				}
+
+				if code != tt.wantCode {
+					t.Fatalf("unexpected exit code, got %d, want %d", code, tt.wantCode)
+				}
 			},
 		)
 	}
@@ -0,0 +1,227 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+)
+
+// YouTube only turns a description's timestamps into chapters when there
+// are at least minChapters of them, ascending from 00:00, each at least
+// minChapterLength long.
+const (
+	minChapters      = 3
+	minChapterLength = 10 * time.Second
+)
+
+var (
+	errReadChapters = errors.New("failed to read chapters")
+	errChapters     = errors.New("invalid chapters")
+)
+
+var (
+	// chapterLine matches "1:23 Title", "01:02:03 - Title" and the like.
+	chapterLine = regexp.MustCompile(`^(\d{1,2}(?::\d{1,2}){1,2})\s*(?:[-–—:|]\s*)?(.+)$`)
+	// isoDuration matches the ISO 8601 durations of contentDetails.
+	isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+)
+
+// Chapter is one entry of a chapter list.
+type Chapter struct {
+	Start time.Duration
+	Title string
+}
+
+// Chapters is a chapter list in the order of the file it was read from.
+type Chapters []*Chapter
+
+// readChapters reads a chapter file: a CSV of time,title when the file
+// ends in .csv, with an optional header row, otherwise lines of
+// "time title". Blank lines are skipped.
+func readChapters(path string) (Chapters, error) {
+	data, err := pkg.Root.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(errReadChapters, err)
+	}
+
+	var chapters Chapters
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			return nil, errors.Join(errReadChapters, err)
+		}
+		for i, record := range records {
+			if len(record) < 2 {
+				return nil, fmt.Errorf("%w: row %d: want time,title", errReadChapters, i+1)
+			}
+			start, err := parseTimestamp(strings.TrimSpace(record[0]))
+			if err != nil {
+				if i == 0 {
+					continue
+				}
+				return nil, fmt.Errorf("%w: row %d: %w", errReadChapters, i+1, err)
+			}
+			chapters = append(
+				chapters, &Chapter{Start: start, Title: strings.TrimSpace(record[1])},
+			)
+		}
+		return chapters, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		m := chapterLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("%w: line %d: want time title", errReadChapters, n)
+		}
+		start, err := parseTimestamp(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", errReadChapters, n, err)
+		}
+		chapters = append(chapters, &Chapter{Start: start, Title: strings.TrimSpace(m[2])})
+	}
+	return chapters, scanner.Err()
+}
+
+// parseTimestamp parses M:SS, MM:SS and H:MM:SS.
+func parseTimestamp(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("bad timestamp %q", s)
+	}
+	var d time.Duration
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, fmt.Errorf("bad timestamp %q", s)
+		}
+		d = d*60 + time.Duration(n)
+	}
+	return d * time.Second, nil
+}
+
+// Validate checks the chapter rules and returns every broken one. The last
+// chapter is checked against duration unless it is 0, meaning unknown.
+func (cs Chapters) Validate(duration time.Duration) error {
+	var errs []error
+	if len(cs) < minChapters {
+		errs = append(
+			errs, fmt.Errorf("%w: %d chapters, at least %d are needed", errChapters, len(cs), minChapters),
+		)
+	}
+	if len(cs) > 0 && cs[0].Start != 0 {
+		errs = append(
+			errs, fmt.Errorf("%w: first chapter starts at %s, not 00:00", errChapters, formatTimestamp(cs[0].Start, false)),
+		)
+	}
+	for i, c := range cs {
+		if c.Title == "" {
+			errs = append(errs, fmt.Errorf("%w: chapter %d has no title", errChapters, i+1))
+		}
+		if duration > 0 && c.Start >= duration {
+			errs = append(
+				errs, fmt.Errorf(
+					"%w: chapter %d starts at %s, after the video ends at %s",
+					errChapters, i+1, formatTimestamp(c.Start, false),
+					formatTimestamp(duration, false),
+				),
+			)
+			continue
+		}
+		end := duration
+		if i+1 < len(cs) {
+			end = cs[i+1].Start
+			if end <= c.Start {
+				errs = append(
+					errs, fmt.Errorf(
+						"%w: chapter %d at %s does not come after chapter %d at %s",
+						errChapters, i+2, formatTimestamp(end, false), i+1,
+						formatTimestamp(c.Start, false),
+					),
+				)
+				continue
+			}
+		}
+		if end > 0 && end-c.Start < minChapterLength {
+			errs = append(
+				errs, fmt.Errorf(
+					"%w: chapter %d is %s long, at least %s is needed",
+					errChapters, i+1, end-c.Start, minChapterLength,
+				),
+			)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String renders the chapters one per line, with hours only when a chapter
+// starts an hour or more in.
+func (cs Chapters) String() string {
+	hours := len(cs) > 0 && cs[len(cs)-1].Start >= time.Hour
+	var b strings.Builder
+	for _, c := range cs {
+		_, _ = fmt.Fprintf(&b, "%s %s\n", formatTimestamp(c.Start, hours), c.Title)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Apply replaces the chapter lines of description, if any, with cs. Any
+// earlier line that starts with a timestamp is taken to be a chapter.
+func (cs Chapters) Apply(description string) string {
+	var kept []string
+	for line := range strings.SplitSeq(description, "\n") {
+		if chapterLine.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	rest := strings.TrimSpace(strings.Join(kept, "\n"))
+	if rest == "" {
+		return cs.String()
+	}
+	return rest + "\n\n" + cs.String()
+}
+
+func formatTimestamp(d time.Duration, hours bool) string {
+	s := int(d / time.Second)
+	if hours || s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%02d:%02d", s/60, s%60)
+}
+
+// parseISODuration parses the duration of contentDetails, e.g. PT1H2M3S.
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("bad duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("bad duration %q", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestReadChapters(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "text",
+			file:    "chapters.txt",
+			content: "0:00 Intro\n\n1:05 - Setup\n1:02:03 Outro\n",
+			want:    "0:00:00 Intro\n0:01:05 Setup\n1:02:03 Outro",
+		},
+		{
+			name:    "csv with header",
+			file:    "chapters.csv",
+			content: "time,title\n00:00,Intro\n00:30,\"Part one, basics\"\n",
+			want:    "00:00 Intro\n00:30 Part one, basics",
+		},
+		{name: "bad line", file: "chapters.txt", content: "Intro\n", wantErr: true},
+		{name: "bad seconds", file: "chapters.txt", content: "0:75 Intro\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				root, err := os.OpenRoot(dir)
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				if err := os.WriteFile(dir+"/"+tt.file, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := readChapters(tt.file)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("readChapters() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err == nil && got.String() != tt.want {
+					t.Errorf("readChapters() = %q, want %q", got.String(), tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestChapters_Validate(t *testing.T) {
+	chapters := func(starts ...int) Chapters {
+		var cs Chapters
+		for _, s := range starts {
+			cs = append(cs, &Chapter{Start: time.Duration(s) * time.Second, Title: "t"})
+		}
+		return cs
+	}
+	tests := []struct {
+		name     string
+		chapters Chapters
+		duration time.Duration
+		want     []string
+	}{
+		{name: "valid", chapters: chapters(0, 10, 20), duration: 30 * time.Second},
+		{name: "unknown duration", chapters: chapters(0, 10, 20)},
+		{name: "too few", chapters: chapters(0, 10), want: []string{"2 chapters"}},
+		{name: "first not zero", chapters: chapters(5, 15, 25), want: []string{"first chapter"}},
+		{name: "not ascending", chapters: chapters(0, 20, 15), want: []string{"chapter 3 at 00:15"}},
+		{name: "too short", chapters: chapters(0, 5, 20), want: []string{"chapter 1 is 5s"}},
+		{
+			name: "last too short", chapters: chapters(0, 10, 20),
+			duration: 25 * time.Second, want: []string{"chapter 3 is 5s"},
+		},
+		{
+			name: "past the end", chapters: chapters(0, 10, 40),
+			duration: 30 * time.Second, want: []string{"chapter 3 starts at 00:40"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := tt.chapters.Validate(tt.duration)
+				if len(tt.want) == 0 {
+					if err != nil {
+						t.Errorf("Validate() error = %v", err)
+					}
+					return
+				}
+				if !errors.Is(err, errChapters) {
+					t.Fatalf("Validate() error = %v, want %v", err, errChapters)
+				}
+				for _, w := range tt.want {
+					if !strings.Contains(err.Error(), w) {
+						t.Errorf("Validate() error = %q, want it to mention %q", err, w)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestChapters_Apply(t *testing.T) {
+	cs := Chapters{{0, "Intro"}, {10 * time.Second, "Middle"}, {20 * time.Second, "End"}}
+	block := "00:00 Intro\n00:10 Middle\n00:20 End"
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"", block},
+		{"About this video.", "About this video.\n\n" + block},
+		{"About.\n\n00:00 Old\n00:30 Older\n", "About.\n\n" + block},
+	}
+	for _, tt := range tests {
+		if got := cs.Apply(tt.description); got != tt.want {
+			t.Errorf("Apply(%q) = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT15S":    15 * time.Second,
+		"PT1H2M3S": time.Hour + 2*time.Minute + 3*time.Second,
+		"P1DT1M":   24*time.Hour + time.Minute,
+		"P0D":      0,
+	}
+	for in, want := range tests {
+		if got, err := parseISODuration(in); err != nil || got != want {
+			t.Errorf("parseISODuration(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseISODuration("15 seconds"); err == nil {
+		t.Error("parseISODuration() should reject non ISO 8601 input")
+	}
+}
+
+func TestVideo_Update_Chapters(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration string
+		wantErr  bool
+	}{
+		{name: "fits the video", duration: "PT1M"},
+		{name: "longer than the video", duration: "PT25S", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				root, err := os.OpenRoot(dir)
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				content := "00:00 Intro\n00:10 Middle\n00:20 End\n"
+				if err := os.WriteFile(dir+"/chapters.txt", []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				var updated *youtube.Video
+				svc := common.NewTestService(
+					t, http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Content-Type", "application/json")
+							if r.Method == http.MethodGet {
+								if !strings.Contains(strings.Join(r.URL.Query()["part"], ","), "contentDetails") {
+									t.Errorf("part = %v, want contentDetails", r.URL.Query()["part"])
+								}
+								_, _ = w.Write(
+									[]byte(`{"items": [{"id": "vid", "snippet": {"title": "T", "description": "About."}, "contentDetails": {"duration": "` + tt.duration + `"}}]}`),
+								)
+								return
+							}
+							updated = &youtube.Video{}
+							_ = json.NewDecoder(r.Body).Decode(updated)
+							_, _ = w.Write([]byte(`{"id": "vid"}`))
+						},
+					),
+				)
+
+				v := NewVideo(
+					WithService(svc), WithIds([]string{"vid"}),
+					WithChapters("chapters.txt"), WithMaxResults(1), WithOutput("silent"),
+				)
+				err = v.Update(io.Discard)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr {
+					if updated != nil {
+						t.Error("invalid chapters should not be applied")
+					}
+					return
+				}
+				want := "About.\n\n" + strings.TrimSpace(content)
+				if updated == nil || updated.Snippet.Description != want {
+					t.Errorf("description = %+v, want %q", updated, want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	statusSuccess = "success"
+	statusFailed  = "failed"
+	statusSkipped = "skipped"
+
+	defaultConcurrency = 2
+)
+
+var (
+	errReadManifest  = errors.New("failed to read manifest")
+	errWriteResults  = errors.New("failed to write results")
+	errManifestEntry = errors.New("manifest entry has no file")
+)
+
+// Result records the outcome of one manifest entry.
+type Result struct {
+	File     string `yaml:"file" json:"file"`
+	Title    string `yaml:"title,omitempty" json:"title,omitempty"`
+	Status   string `yaml:"status" json:"status"`
+	VideoId  string `yaml:"video_id,omitempty" json:"video_id,omitempty"`
+	Error    string `yaml:"error,omitempty" json:"error,omitempty"`
+	Finished string `yaml:"finished,omitempty" json:"finished,omitempty"`
+}
+
+// insertManifest uploads every entry of v.Manifest with bounded concurrency.
+// Flags set on v act as defaults that entries may override. Outcomes are
+// written to the results file after each entry, and entries that already
+// succeeded in an earlier run are skipped.
+func (v *Video) insertManifest(writer io.Writer) error {
+	if err := v.EnsureService(); err != nil {
+		return err
+	}
+	entries, err := v.readManifest()
+	if err != nil {
+		return errors.Join(errReadManifest, err)
+	}
+
+	resultsPath := v.Results
+	if resultsPath == "" {
+		ext := filepath.Ext(v.Manifest)
+		resultsPath = strings.TrimSuffix(v.Manifest, ext) + ".results.yaml"
+	}
+	previous := readResults(resultsPath)
+
+	concurrency := v.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	ctx := v.Ctx
+	if ctx != nil && concurrency > 1 {
+		// Parallel uploads would fight over a single progress line.
+		ctx = progress.NewContext(ctx, nil)
+	}
+
+	results := make([]*Result, len(entries))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	var writeErr error
+
+	for i, entry := range entries {
+		if prev, ok := previous[entry.File]; ok && prev.Status == statusSuccess {
+			results[i] = &Result{
+				File: entry.File, Title: prev.Title, Status: statusSkipped,
+				VideoId: prev.VideoId, Finished: prev.Finished,
+			}
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, entry *Video) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			entry.Service = v.Service
+			entry.Client = v.Client
+			entry.Ctx = ctx
+			r := &Result{File: entry.File, Title: entry.Title}
+			if entry.File == "" {
+				r.Status, r.Error = statusFailed, errManifestEntry.Error()
+			} else if res, _, err := entry.insert(); err != nil {
+				r.Status, r.Error = statusFailed, err.Error()
+				if res != nil {
+					r.VideoId = res.Id
+				}
+			} else {
+				r.Status, r.VideoId = statusSuccess, res.Id
+				if res.Snippet != nil {
+					r.Title = res.Snippet.Title
+				}
+			}
+			r.Finished = time.Now().UTC().Format(time.RFC3339)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = r
+			if err := writeResults(resultsPath, results, previous); err != nil {
+				writeErr = err
+			}
+		}(i, entry)
+	}
+	wg.Wait()
+	if writeErr != nil {
+		return errors.Join(errWriteResults, writeErr)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == statusFailed {
+			failed++
+		}
+	}
+
+	switch v.Output {
+	case "json", "yaml", "silent":
+		common.PrintResult(v.Output, results, writer, "")
+	default:
+		if err := common.PrintList(
+			&common.Fields{Output: "table"}, results, writer,
+			table.Row{"File", "Status", "Video ID", "Error"},
+			func(r *Result) table.Row {
+				return table.Row{r.File, r.Status, r.VideoId, r.Error}
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return errors.Join(
+			errInsertVideo,
+			fmt.Errorf(
+				"%d of %d manifest entries failed, see %s",
+				failed, len(results), resultsPath,
+			),
+		)
+	}
+	return nil
+}
+
+// readManifest parses v.Manifest into one Video per entry, each starting
+// from a copy of v so that flags act as defaults.
+func (v *Video) readManifest() ([]*Video, error) {
+	data, err := pkg.Root.ReadFile(v.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []map[string]any
+	if strings.EqualFold(filepath.Ext(v.Manifest), ".csv") {
+		raw, err = parseCSV(data)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Video, 0, len(raw))
+	for i, m := range raw {
+		encoded, err := json.Marshal(m)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		row := &Video{}
+		if err := json.Unmarshal(encoded, row); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		entry := *v
+		entry.Fields.Ctx = nil
+		entry.Manifest, entry.Results, entry.Concurrency = "", "", 0
+		detach(reflect.ValueOf(&entry).Elem())
+		merge(reflect.ValueOf(&entry).Elem(), reflect.ValueOf(row).Elem(), m)
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+// detach gives val its own copy of the flag pointers and slices it shares
+// with the Video it was copied from, so that entries never write through to
+// their siblings or to the defaults.
+func detach(val reflect.Value) {
+	for i := range val.NumField() {
+		f := val.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		switch f.Kind() {
+		case reflect.Struct:
+			if val.Type().Field(i).Anonymous {
+				detach(f)
+			}
+		case reflect.Pointer:
+			if !f.IsNil() && f.Elem().Kind() == reflect.Bool {
+				p := reflect.New(f.Type().Elem())
+				p.Elem().Set(f.Elem())
+				f.Set(p)
+			}
+		case reflect.Slice:
+			if !f.IsNil() {
+				f.Set(reflect.AppendSlice(reflect.MakeSlice(f.Type(), 0, f.Len()), f))
+			}
+		}
+	}
+}
+
+// merge sets the fields of dst named by the json keys of row to their
+// values in src, including those of embedded structs.
+func merge(dst, src reflect.Value, row map[string]any) {
+	for i := range dst.NumField() {
+		sf := dst.Type().Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			merge(dst.Field(i), src.Field(i), row)
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if _, ok := row[name]; ok && name != "" && name != "-" {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
+
+// parseCSV turns a CSV manifest with a header row of field names into maps
+// typed after the json tags of Video. List fields are comma separated.
+func parseCSV(data []byte) ([]map[string]any, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	kinds := fieldKinds(reflect.TypeFor[Video]())
+	header := records[0]
+	entries := make([]map[string]any, 0, len(records)-1)
+	for _, record := range records[1:] {
+		m := make(map[string]any, len(header))
+		for i, name := range header {
+			name = strings.TrimSpace(name)
+			if i >= len(record) || record[i] == "" {
+				continue
+			}
+			value := record[i]
+			switch kinds[name] {
+			case reflect.Bool:
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				m[name] = b
+			case reflect.Int64:
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				m[name] = n
+			case reflect.Slice:
+				parts := strings.Split(value, ",")
+				for j := range parts {
+					parts[j] = strings.TrimSpace(parts[j])
+				}
+				m[name] = parts
+			default:
+				m[name] = value
+			}
+		}
+		entries = append(entries, m)
+	}
+	return entries, nil
+}
+
+// fieldKinds maps json field names of t, including embedded structs, to the
+// kind of value they hold, looking through pointers.
+func fieldKinds(t reflect.Type) map[string]reflect.Kind {
+	kinds := map[string]reflect.Kind{}
+	for f := range t.Fields() {
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			for name, kind := range fieldKinds(f.Type) {
+				kinds[name] = kind
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		kinds[name] = ft.Kind()
+	}
+	return kinds
+}
+
+func readResults(path string) map[string]*Result {
+	previous := map[string]*Result{}
+	data, err := pkg.Root.ReadFile(path)
+	if err != nil {
+		return previous
+	}
+	var results []*Result
+	if err := yaml.Unmarshal(data, &results); err != nil {
+		return previous
+	}
+	for _, r := range results {
+		if r != nil {
+			previous[r.File] = r
+		}
+	}
+	return previous
+}
+
+// writeResults persists finished results, carrying over earlier outcomes of
+// entries that have not run yet in this invocation.
+func writeResults(path string, results []*Result, previous map[string]*Result) error {
+	seen := map[string]bool{}
+	merged := make([]*Result, 0, len(results))
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		seen[r.File] = true
+		if r.Status == statusSkipped {
+			r = &Result{
+				File: r.File, Title: r.Title, Status: statusSuccess,
+				VideoId: r.VideoId, Finished: r.Finished,
+			}
+		}
+		merged = append(merged, r)
+	}
+	for _, file := range slices.Sorted(maps.Keys(previous)) {
+		if !seen[file] {
+			merged = append(merged, previous[file])
+		}
+	}
+
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = json.MarshalIndent(merged, "", "  ")
+	} else {
+		data, err = yaml.Marshal(merged)
+	}
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := pkg.Root.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return pkg.Root.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"gopkg.in/yaml.v3"
+)
+
+func TestVideo_Insert_Manifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	root, err := os.OpenRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to open root: %v", err)
+	}
+	oldRoot := pkg.Root
+	pkg.Root = root
+	defer func() { pkg.Root = oldRoot }()
+	defer func() { _ = root.Close() }()
+
+	for _, name := range []string{"a.mp4", "b.mp4"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(name), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+	manifest := `
+- file: a.mp4
+  title: First
+  tags: [one]
+- file: b.mp4
+  privacy: unlisted
+- file: missing.mp4
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "uploads.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	var uploads atomic.Int32
+	svc := common.NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				n := uploads.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(
+					w, `{"id": "vid-%d", "snippet": {"title": "t"}, "status": {}}`, n,
+				)
+			},
+		),
+	)
+
+	run := func() (string, error) {
+		v := NewVideo(
+			WithService(svc),
+			WithManifest("uploads.yaml"),
+			WithPrivacy("private"),
+			WithConcurrency(2),
+			WithOutput("yaml"),
+		)
+		var buf bytes.Buffer
+		err := v.Insert(&buf)
+		return buf.String(), err
+	}
+
+	out, err := run()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 manifest entries failed") {
+		t.Fatalf("Insert() error = %v, want one failed entry", err)
+	}
+	if uploads.Load() != 2 {
+		t.Errorf("uploaded %d videos, want 2", uploads.Load())
+	}
+	if !strings.Contains(out, "missing.mp4") {
+		t.Errorf("output does not mention failed entry: %s", out)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "uploads.results.yaml"))
+	if err != nil {
+		t.Fatalf("results file not written: %v", err)
+	}
+	var results []*Result
+	if err := yaml.Unmarshal(data, &results); err != nil {
+		t.Fatalf("invalid results file: %v", err)
+	}
+	statuses := map[string]string{}
+	for _, r := range results {
+		statuses[r.File] = r.Status
+		if r.Status == statusSuccess && r.VideoId == "" {
+			t.Errorf("result for %s has no video ID", r.File)
+		}
+	}
+	want := map[string]string{
+		"a.mp4": statusSuccess, "b.mp4": statusSuccess, "missing.mp4": statusFailed,
+	}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+
+	out, _ = run()
+	if uploads.Load() != 2 {
+		t.Errorf("re-run uploaded again: %d uploads", uploads.Load())
+	}
+	if strings.Count(out, statusSkipped) != 2 {
+		t.Errorf("re-run should skip 2 entries: %s", out)
+	}
+}
+
+func TestVideo_ReadManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	root, err := os.OpenRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to open root: %v", err)
+	}
+	oldRoot := pkg.Root
+	pkg.Root = root
+	defer func() { pkg.Root = oldRoot }()
+	defer func() { _ = root.Close() }()
+
+	csvManifest := "file,title,tags,for_kids,channel_id\n" +
+		"a.mp4,First,\"go, yutu\",true,UC1\n" +
+		"b.mp4,,,,\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "uploads.csv"), []byte(csvManifest), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	v := NewVideo(
+		WithManifest("uploads.csv"),
+		WithPrivacy("private"),
+		WithTitle("Default"),
+	).(*Video)
+	entries, err := v.readManifest()
+	if err != nil {
+		t.Fatalf("readManifest() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	first := entries[0]
+	if first.File != "a.mp4" || first.Title != "First" || first.Privacy != "private" {
+		t.Errorf("first entry = %+v", first)
+	}
+	if !reflect.DeepEqual(first.Tags, []string{"go", "yutu"}) {
+		t.Errorf("first entry tags = %v", first.Tags)
+	}
+	if first.ForKids == nil || !*first.ForKids || first.ChannelId != "UC1" {
+		t.Errorf("first entry for_kids/channel_id not parsed: %+v", first)
+	}
+	if first.Manifest != "" {
+		t.Error("entries must not inherit the manifest path")
+	}
+	if second := entries[1]; second.Title != "Default" {
+		t.Errorf("second entry title = %q, want flag default", second.Title)
+	}
+}
+
+func TestVideo_ReadManifest_Isolated(t *testing.T) {
+	tmpDir := common.SetTestRoot(t)
+
+	manifest := `
+- file: a.mp4
+  for_kids: true
+  tags: [one]
+- file: b.mp4
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "uploads.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	forKids := false
+	v := NewVideo(
+		WithManifest("uploads.yaml"),
+		WithForKids(&forKids),
+		WithTags([]string{"default", "tag"}),
+	).(*Video)
+	entries, err := v.readManifest()
+	if err != nil {
+		t.Fatalf("readManifest() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	first, second := entries[0], entries[1]
+	if first.ForKids == nil || !*first.ForKids {
+		t.Errorf("first entry for_kids = %v, want true", first.ForKids)
+	}
+	if *second.ForKids || *v.ForKids {
+		t.Error("for_kids of one entry leaked into its sibling or the defaults")
+	}
+	if first.ForKids == second.ForKids || second.ForKids == v.ForKids {
+		t.Error("entries share the for_kids pointer")
+	}
+
+	second.Tags[0] = "changed"
+	if v.Tags[0] != "default" {
+		t.Errorf("tags of an entry write through to the defaults: %v", v.Tags)
+	}
+	if !reflect.DeepEqual(first.Tags, []string{"one"}) {
+		t.Errorf("first entry tags = %v, want [one]", first.Tags)
+	}
+}
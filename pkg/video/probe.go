@@ -0,0 +1,45 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg/probe"
+)
+
+// probe reads the container of file and seeks back to its start. With
+// v.Probe it also fills RecordingDate from the creation time unless already
+// set, and warns about anything YouTube is likely to reject. Duration and
+// resolution are only logged, as YouTube works them out itself and the API
+// takes neither. Probing never fails an upload; nil is returned when the
+// container cannot be read.
+func (v *Video) probe(file io.ReadSeeker, size int64) *probe.Info {
+	defer func() { _, _ = file.Seek(0, io.SeekStart) }()
+	info, err := probe.Probe(file, size)
+	if err != nil {
+		if v.Probe {
+			slog.Warn("Failed to probe video file", "file", v.File, "error", err)
+		}
+		return nil
+	}
+	if !v.Probe {
+		return info
+	}
+
+	if v.RecordingDate == "" && !info.Created.IsZero() {
+		v.RecordingDate = info.Created.UTC().Format(time.RFC3339)
+	}
+	slog.Info(
+		"Probed video file", "file", v.File, "container", info.Container,
+		"duration", info.Duration, "resolution", info.Resolution(),
+		"recordingDate", v.RecordingDate,
+	)
+	for _, w := range info.Warnings() {
+		slog.Warn("YouTube may reject video file", "file", v.File, "problem", w)
+	}
+	return info
+}
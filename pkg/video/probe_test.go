@@ -0,0 +1,93 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+)
+
+// mp4WithCreation builds a minimal MP4 whose mvhd records created.
+func mp4WithCreation(created time.Time) []byte {
+	box := func(typ string, body []byte) []byte {
+		b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+		return append(append(b, typ...), body...)
+	}
+	secs := uint32(created.Sub(time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)).Seconds())
+	mvhd := binary.BigEndian.AppendUint32(make([]byte, 4), secs)
+	mvhd = append(mvhd, make([]byte, 12)...)
+	return append(box("ftyp", []byte("isom")), box("moov", box("mvhd", mvhd))...)
+}
+
+func TestVideo_Insert_Probe(t *testing.T) {
+	tests := []struct {
+		name          string
+		recordingDate string
+		want          string
+	}{
+		{name: "fill from container", want: "2026-05-04T12:30:00Z"},
+		{
+			name:          "explicit date wins",
+			recordingDate: "2020-01-01T00:00:00Z",
+			want:          "2020-01-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				tmpDir := t.TempDir()
+				root, err := os.OpenRoot(tmpDir)
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				t.Setenv("YUTU_CACHE_TOKEN", "")
+
+				content := mp4WithCreation(time.Date(2026, 5, 4, 12, 30, 0, 0, time.UTC))
+				if err := os.WriteFile(tmpDir+"/clip.mp4", content, 0644); err != nil {
+					t.Fatalf("failed to create file: %v", err)
+				}
+
+				var body string
+				svc := common.NewTestService(
+					t, http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							b, _ := io.ReadAll(r.Body)
+							body = string(b)
+							w.Header().Set("Content-Type", "application/json")
+							_, _ = w.Write([]byte(`{"id": "vid", "snippet": {"title": "t"}, "status": {}}`))
+						},
+					),
+				)
+
+				v := NewVideo(
+					WithService(svc), WithFile("clip.mp4"), WithPrivacy("private"),
+					WithProbe(true), WithRecordingDate(tt.recordingDate),
+					WithOutput("silent"),
+				)
+				if err := v.Insert(io.Discard); err != nil {
+					t.Fatalf("Insert() error = %v", err)
+				}
+				if !strings.Contains(body, `"recordingDate":"`+tt.want+`"`) {
+					t.Errorf("request missing recordingDate %s:\n%s", tt.want, body)
+				}
+				if !strings.Contains(body, string(content)) {
+					t.Error("probing should not consume the file before upload")
+				}
+			},
+		)
+	}
+}
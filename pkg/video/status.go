@@ -0,0 +1,230 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"google.golang.org/api/youtube/v3"
+)
+
+const defaultWaitTimeout = 30 * time.Minute
+
+var (
+	errGetStatus      = errors.New("failed to get video status")
+	errWaitTimeout    = errors.New("timed out waiting for video processing")
+	errProcessing     = errors.New("video processing failed")
+	errBadWaitTimeout = errors.New("invalid wait timeout")
+)
+
+// pollInterval is how often processing status is checked while waiting.
+var pollInterval = 15 * time.Second
+
+// statusParts are the parts needed to build a StatusReport. The
+// processingDetails and suggestions parts are only returned to the owner.
+var statusParts = []string{"id", "status", "processingDetails", "suggestions"}
+
+// StatusReport summarizes the upload and processing state of a video.
+type StatusReport struct {
+	VideoId          string   `yaml:"video_id" json:"video_id"`
+	UploadStatus     string   `yaml:"upload_status" json:"upload_status"`
+	FailureReason    string   `yaml:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+	RejectionReason  string   `yaml:"rejection_reason,omitempty" json:"rejection_reason,omitempty"`
+	PrivacyStatus    string   `yaml:"privacy_status,omitempty" json:"privacy_status,omitempty"`
+	ProcessingStatus string   `yaml:"processing_status,omitempty" json:"processing_status,omitempty"`
+	ProcessingError  string   `yaml:"processing_failure_reason,omitempty" json:"processing_failure_reason,omitempty"`
+	PartsTotal       int64    `yaml:"parts_total,omitempty" json:"parts_total,omitempty"`
+	PartsProcessed   int64    `yaml:"parts_processed,omitempty" json:"parts_processed,omitempty"`
+	TimeLeftMs       int64    `yaml:"time_left_ms,omitempty" json:"time_left_ms,omitempty"`
+	Errors           []string `yaml:"processing_errors,omitempty" json:"processing_errors,omitempty"`
+	Warnings         []string `yaml:"processing_warnings,omitempty" json:"processing_warnings,omitempty"`
+	Hints            []string `yaml:"processing_hints,omitempty" json:"processing_hints,omitempty"`
+}
+
+// NewStatusReport extracts a StatusReport from a video fetched with
+// statusParts.
+func NewStatusReport(video *youtube.Video) *StatusReport {
+	r := &StatusReport{VideoId: video.Id}
+	if s := video.Status; s != nil {
+		r.UploadStatus = s.UploadStatus
+		r.FailureReason = s.FailureReason
+		r.RejectionReason = s.RejectionReason
+		r.PrivacyStatus = s.PrivacyStatus
+	}
+	if p := video.ProcessingDetails; p != nil {
+		r.ProcessingStatus = p.ProcessingStatus
+		r.ProcessingError = p.ProcessingFailureReason
+		if pp := p.ProcessingProgress; pp != nil {
+			r.PartsTotal = int64(pp.PartsTotal)
+			r.PartsProcessed = int64(pp.PartsProcessed)
+			r.TimeLeftMs = int64(pp.TimeLeftMs)
+		}
+	}
+	if s := video.Suggestions; s != nil {
+		r.Errors = s.ProcessingErrors
+		r.Warnings = s.ProcessingWarnings
+		r.Hints = s.ProcessingHints
+	}
+	return r
+}
+
+// Done reports whether YouTube has finished with the video, successfully
+// or not.
+func (r *StatusReport) Done() bool {
+	switch r.UploadStatus {
+	case "processed", "failed", "rejected", "deleted":
+		return true
+	}
+	switch r.ProcessingStatus {
+	case "succeeded", "failed", "terminated":
+		return true
+	}
+	return false
+}
+
+// Failed reports whether the upload was rejected or processing failed.
+func (r *StatusReport) Failed() bool {
+	return slices.Contains([]string{"failed", "rejected", "deleted"}, r.UploadStatus) ||
+		slices.Contains([]string{"failed", "terminated"}, r.ProcessingStatus)
+}
+
+// Err describes a failed report, or returns nil.
+func (r *StatusReport) Err() error {
+	if !r.Failed() {
+		return nil
+	}
+	reason := r.UploadStatus
+	switch {
+	case r.RejectionReason != "":
+		reason += ": " + r.RejectionReason
+	case r.FailureReason != "":
+		reason += ": " + r.FailureReason
+	case r.ProcessingError != "":
+		reason = r.ProcessingStatus + ": " + r.ProcessingError
+	}
+	return fmt.Errorf("%w: %s %s", errProcessing, r.VideoId, reason)
+}
+
+// Progress formats processing progress as a percentage, or "" when unknown.
+func (r *StatusReport) Progress() string {
+	if r.PartsTotal <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%%", 100*float64(r.PartsProcessed)/float64(r.PartsTotal))
+}
+
+// Status reports uploadStatus, failure and rejection reasons, processing
+// progress and processing suggestions of the videos in v.Ids.
+func (v *Video) Status(writer io.Writer) error {
+	videos, err := v.getStatus(v.Ids)
+	if err != nil {
+		return err
+	}
+	reports := make([]*StatusReport, 0, len(videos))
+	for _, video := range videos {
+		reports = append(reports, NewStatusReport(video))
+	}
+
+	return common.PrintList(
+		&v.Fields, reports, writer,
+		table.Row{"ID", "Upload", "Processing", "Progress", "Reason"},
+		func(r *StatusReport) table.Row {
+			reason := r.RejectionReason
+			if reason == "" {
+				reason = r.FailureReason
+			}
+			if reason == "" {
+				reason = r.ProcessingError
+			}
+			return table.Row{
+				r.VideoId, r.UploadStatus, r.ProcessingStatus, r.Progress(), reason,
+			}
+		},
+	)
+}
+
+func (v *Video) getStatus(ids []string) ([]*youtube.Video, error) {
+	if err := v.EnsureService(); err != nil {
+		return nil, err
+	}
+	call := v.Service.Videos.List(statusParts).Id(ids...)
+	if v.OnBehalfOfContentOwner != "" {
+		call = call.OnBehalfOfContentOwner(v.OnBehalfOfContentOwner)
+	}
+	res, err := call.Do()
+	if err != nil {
+		return nil, errors.Join(errGetStatus, err)
+	}
+	return res.Items, nil
+}
+
+// waitProcessed polls the status of id until processing finishes or
+// v.WaitTimeout elapses. It returns the last video seen, which carries the
+// status, processingDetails and suggestions parts.
+func (v *Video) waitProcessed(id string) (*youtube.Video, error) {
+	timeout, err := v.waitTimeout()
+	if err != nil {
+		return nil, err
+	}
+	ctx := v.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	var last *youtube.Video
+	for {
+		videos, err := v.getStatus([]string{id})
+		if err != nil {
+			return last, err
+		}
+		if len(videos) == 0 {
+			return last, fmt.Errorf("%w: %s not found", errGetStatus, id)
+		}
+		last = videos[0]
+		report := NewStatusReport(last)
+		if report.Done() {
+			return last, report.Err()
+		}
+		slog.Debug(
+			"Waiting for video processing", "videoId", id,
+			"uploadStatus", report.UploadStatus, "progress", report.Progress(),
+			"timeLeftMs", report.TimeLeftMs,
+		)
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return last, fmt.Errorf(
+					"%w: %s is still %s after %s", errWaitTimeout, id,
+					report.UploadStatus, timeout,
+				)
+			}
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *Video) waitTimeout() (time.Duration, error) {
+	if v.WaitTimeout == "" {
+		return defaultWaitTimeout, nil
+	}
+	timeout, err := time.ParseDuration(v.WaitTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("%w: %q", errBadWaitTimeout, v.WaitTimeout)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestStatusReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		video    *youtube.Video
+		done     bool
+		wantErr  string
+		progress string
+	}{
+		{
+			name: "processing",
+			video: &youtube.Video{
+				Id:     "v1",
+				Status: &youtube.VideoStatus{UploadStatus: "uploaded"},
+				ProcessingDetails: &youtube.VideoProcessingDetails{
+					ProcessingStatus: "processing",
+					ProcessingProgress: &youtube.VideoProcessingDetailsProcessingProgress{
+						PartsTotal: 4, PartsProcessed: 1,
+					},
+				},
+			},
+			progress: "25%",
+		},
+		{
+			name: "processed",
+			video: &youtube.Video{
+				Id:     "v2",
+				Status: &youtube.VideoStatus{UploadStatus: "processed"},
+			},
+			done: true,
+		},
+		{
+			name: "rejected",
+			video: &youtube.Video{
+				Id: "v3",
+				Status: &youtube.VideoStatus{
+					UploadStatus: "rejected", RejectionReason: "duplicate",
+				},
+			},
+			done:    true,
+			wantErr: "v3 rejected: duplicate",
+		},
+		{
+			name: "processing failed",
+			video: &youtube.Video{
+				Id:     "v4",
+				Status: &youtube.VideoStatus{UploadStatus: "uploaded"},
+				ProcessingDetails: &youtube.VideoProcessingDetails{
+					ProcessingStatus:        "failed",
+					ProcessingFailureReason: "transcodeFailed",
+				},
+			},
+			done:    true,
+			wantErr: "v4 failed: transcodeFailed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				r := NewStatusReport(tt.video)
+				if r.Done() != tt.done {
+					t.Errorf("Done() = %v, want %v", r.Done(), tt.done)
+				}
+				if got := r.Progress(); got != tt.progress {
+					t.Errorf("Progress() = %q, want %q", got, tt.progress)
+				}
+				err := r.Err()
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Errorf("Err() = %v, want nil", err)
+					}
+					return
+				}
+				if !errors.Is(err, errProcessing) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Err() = %v, want %q", err, tt.wantErr)
+				}
+			},
+		)
+	}
+}
+
+func TestVideo_Status(t *testing.T) {
+	svc := common.NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if got := strings.Join(r.URL.Query()["part"], ","); got != "id,status,processingDetails,suggestions" {
+					t.Errorf("part = %q", got)
+				}
+				if got := strings.Join(r.URL.Query()["id"], ","); got != "v1,v2" {
+					t.Errorf("id = %q", got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(
+					[]byte(`{"items": [
+						{"id": "v1", "status": {"uploadStatus": "processed"}},
+						{"id": "v2", "status": {"uploadStatus": "failed", "failureReason": "codec"},
+						 "suggestions": {"processingErrors": ["audioFileError"]}}
+					]}`),
+				)
+			},
+		),
+	)
+
+	var buf bytes.Buffer
+	v := NewVideo(WithService(svc), WithIds([]string{"v1", "v2"}), WithOutput("json"))
+	if err := v.Status(&buf); err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	var reports []*StatusReport
+	if err := json.Unmarshal(buf.Bytes(), &reports); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, buf.String())
+	}
+	if len(reports) != 2 || reports[1].FailureReason != "codec" ||
+		len(reports[1].Errors) != 1 {
+		t.Errorf("Status() = %s", buf.String())
+	}
+
+	buf.Reset()
+	v = NewVideo(WithService(svc), WithIds([]string{"v1", "v2"}), WithOutput("table"))
+	if err := v.Status(&buf); err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "codec") {
+		t.Errorf("table output missing failure reason:\n%s", buf.String())
+	}
+}
+
+func TestVideo_Insert_Wait(t *testing.T) {
+	oldInterval := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = oldInterval }()
+
+	tests := []struct {
+		name     string
+		statuses []string
+		timeout  string
+		wantErr  error
+	}{
+		{
+			name:     "processed after polling",
+			statuses: []string{"uploaded", "uploaded", "processed"},
+		},
+		{
+			name:     "rejected",
+			statuses: []string{"uploaded", "rejected"},
+			wantErr:  errProcessing,
+		},
+		{
+			name:     "timeout",
+			statuses: []string{"uploaded"},
+			timeout:  "20ms",
+			wantErr:  errWaitTimeout,
+		},
+		{
+			name:    "invalid timeout",
+			timeout: "soon",
+			wantErr: errBadWaitTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				tmpDir := t.TempDir()
+				root, err := os.OpenRoot(tmpDir)
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				t.Setenv("YUTU_CACHE_TOKEN", "")
+				if err := os.WriteFile(tmpDir+"/video.mp4", []byte("video"), 0644); err != nil {
+					t.Fatalf("failed to create dummy file: %v", err)
+				}
+
+				uploads, polls := 0, 0
+				svc := common.NewTestService(
+					t, http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Content-Type", "application/json")
+							if r.Method == http.MethodPost {
+								uploads++
+								_, _ = w.Write([]byte(`{"id": "vid", "status": {"uploadStatus": "uploaded"}}`))
+								return
+							}
+							status := tt.statuses[min(polls, len(tt.statuses)-1)]
+							polls++
+							_, _ = fmt.Fprintf(
+								w, `{"items": [{"id": "vid", "status": {"uploadStatus": %q}}]}`,
+								status,
+							)
+						},
+					),
+				)
+
+				var buf bytes.Buffer
+				v := NewVideo(
+					WithService(svc), WithFile("video.mp4"), WithPrivacy("private"),
+					WithWait(true), WithWaitTimeout(tt.timeout),
+				)
+				err = v.Insert(&buf)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Insert() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr == errBadWaitTimeout {
+					if uploads != 0 {
+						t.Error("an invalid timeout should fail before uploading")
+					}
+					return
+				}
+				if tt.wantErr == nil && polls != len(tt.statuses) {
+					t.Errorf("polled %d times, want %d", polls, len(tt.statuses))
+				}
+				if !strings.Contains(buf.String(), "Video inserted: vid") {
+					t.Errorf("output = %q", buf.String())
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eat-pray-ai/yutu/pkg/caption"
+	"github.com/eat-pray-ai/yutu/pkg/playlistItem"
+	"github.com/eat-pray-ai/yutu/pkg/thumbnail"
+	"google.golang.org/api/youtube/v3"
+)
+
+const (
+	stepUpload    = "upload"
+	stepThumbnail = "thumbnail"
+	stepPlaylist  = "playlist"
+	stepCaption   = "caption"
+	stepRollback  = "rollback"
+)
+
+var errFollowUp = errors.New("follow-up steps failed")
+
+// Step is the outcome of one step of an insert.
+type Step struct {
+	Name   string `yaml:"name" json:"name"`
+	Status string `yaml:"status" json:"status"`
+	Error  string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Outcome reports every step of an insert whose follow-up steps did not all
+// succeed, and whether the uploaded video was deleted again.
+type Outcome struct {
+	VideoId    string  `yaml:"video_id" json:"video_id"`
+	Steps      []*Step `yaml:"steps" json:"steps"`
+	RolledBack bool    `yaml:"rolled_back" json:"rolled_back"`
+}
+
+func (o *Outcome) add(name string, err error) {
+	step := &Step{Name: name, Status: statusSuccess}
+	if err != nil {
+		step.Status, step.Error = statusFailed, err.Error()
+	}
+	o.Steps = append(o.Steps, step)
+}
+
+// Failed returns the names of the steps that failed, in order.
+func (o *Outcome) Failed() []string {
+	var failed []string
+	for _, s := range o.Steps {
+		if s.Status == statusFailed {
+			failed = append(failed, s.Name)
+		}
+	}
+	return failed
+}
+
+// Err summarizes the failed steps, or returns nil when all succeeded.
+func (o *Outcome) Err() error {
+	failed := o.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("%s: %s", o.VideoId, strings.Join(failed, ", "))
+	if o.RolledBack {
+		msg += ", video deleted"
+	}
+	return fmt.Errorf("%w: %s", errFollowUp, msg)
+}
+
+func (o *Outcome) String() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Video inserted: %s\n", o.VideoId)
+	for _, s := range o.Steps[1:] {
+		if s.Error != "" {
+			_, _ = fmt.Fprintf(&b, "  %s: %s: %s\n", s.Name, s.Status, s.Error)
+		} else {
+			_, _ = fmt.Fprintf(&b, "  %s: %s\n", s.Name, s.Status)
+		}
+	}
+	if o.RolledBack {
+		_, _ = fmt.Fprintf(&b, "Video deleted: %s\n", o.VideoId)
+	}
+	return b.String()
+}
+
+// followUp sets the thumbnail, adds the video to the playlist and uploads
+// the caption of an uploaded video. Every step runs even if an earlier one
+// failed so that the outcome names all failures. With v.Atomic the video is
+// deleted again when any step failed.
+func (v *Video) followUp(res *youtube.Video) *Outcome {
+	o := &Outcome{VideoId: res.Id}
+	o.add(stepUpload, nil)
+
+	if v.Thumbnail != "" {
+		t := thumbnail.NewThumbnail(
+			thumbnail.WithVideoId(res.Id),
+			thumbnail.WithFile(v.Thumbnail),
+			thumbnail.WithService(v.Service),
+			thumbnail.WithOutput("silent"),
+		)
+		o.add(stepThumbnail, t.Set(io.Discard))
+	}
+
+	if v.PlaylistId != "" {
+		pi := playlistItem.NewPlaylistItem(
+			playlistItem.WithTitle(res.Snippet.Title),
+			playlistItem.WithDescription(res.Snippet.Description),
+			playlistItem.WithKind("video"),
+			playlistItem.WithKVideoId(res.Id),
+			playlistItem.WithPlaylistId(v.PlaylistId),
+			playlistItem.WithChannelId(res.Snippet.ChannelId),
+			playlistItem.WithPrivacy(res.Status.PrivacyStatus),
+			playlistItem.WithService(v.Service),
+			playlistItem.WithOutput("silent"),
+		)
+		o.add(stepPlaylist, pi.Insert(io.Discard))
+	}
+
+	if v.Caption != "" {
+		language := v.CaptionLanguage
+		if language == "" {
+			language = v.Language
+		}
+		c := caption.NewCaption(
+			caption.WithVideoId(res.Id),
+			caption.WithFile(v.Caption),
+			caption.WithLanguage(language),
+			caption.WithName(v.CaptionName),
+			caption.WithService(v.Service),
+			caption.WithOutput("silent"),
+		)
+		o.add(stepCaption, c.Insert(io.Discard))
+	}
+
+	if len(o.Failed()) > 0 && v.Atomic {
+		call := v.Service.Videos.Delete(res.Id)
+		if v.OnBehalfOfContentOwner != "" {
+			call = call.OnBehalfOfContentOwner(v.OnBehalfOfContentOwner)
+		}
+		err := call.Do()
+		if err != nil {
+			err = errors.Join(errDeleteVideo, err)
+		}
+		o.add(stepRollback, err)
+		o.RolledBack = err == nil
+	}
+	return o
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 eat-pray-ai & OpenWaygate
+// SPDX-License-Identifier: Apache-2.0
+
+package video
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eat-pray-ai/yutu/pkg"
+	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/ledger"
+)
+
+func TestVideo_Insert_FollowUp(t *testing.T) {
+	tests := []struct {
+		name       string
+		atomic     bool
+		fail       []string
+		output     string
+		wantErr    bool
+		wantSteps  map[string]string
+		wantDelete bool
+		wantLedger int
+	}{
+		{
+			name: "all steps succeed",
+			wantSteps: map[string]string{
+				stepUpload: statusSuccess, stepThumbnail: statusSuccess,
+				stepPlaylist: statusSuccess, stepCaption: statusSuccess,
+			},
+			wantLedger: 1,
+		},
+		{
+			name:    "failures are reported",
+			fail:    []string{"thumbnails", "captions"},
+			output:  "json",
+			wantErr: true,
+			wantSteps: map[string]string{
+				stepUpload: statusSuccess, stepThumbnail: statusFailed,
+				stepPlaylist: statusSuccess, stepCaption: statusFailed,
+			},
+			wantLedger: 1,
+		},
+		{
+			name:    "atomic rolls back",
+			atomic:  true,
+			fail:    []string{"playlistItems"},
+			output:  "json",
+			wantErr: true,
+			wantSteps: map[string]string{
+				stepUpload: statusSuccess, stepThumbnail: statusSuccess,
+				stepPlaylist: statusFailed, stepCaption: statusSuccess,
+				stepRollback: statusSuccess,
+			},
+			wantDelete: true,
+		},
+		{
+			name:    "atomic rollback failure",
+			atomic:  true,
+			fail:    []string{"thumbnails", "videos:DELETE"},
+			output:  "json",
+			wantErr: true,
+			wantSteps: map[string]string{
+				stepUpload: statusSuccess, stepThumbnail: statusFailed,
+				stepPlaylist: statusSuccess, stepCaption: statusSuccess,
+				stepRollback: statusFailed,
+			},
+			wantDelete: true,
+			wantLedger: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				tmpDir := t.TempDir()
+				root, err := os.OpenRoot(tmpDir)
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				t.Setenv("YUTU_CACHE_TOKEN", "")
+				for name, data := range map[string]string{
+					"video.mp4": "video", "cover.jpg": "jpg", "subs.srt": "srt",
+				} {
+					if err := os.WriteFile(tmpDir+"/"+name, []byte(data), 0644); err != nil {
+						t.Fatalf("failed to create %s: %v", name, err)
+					}
+				}
+
+				var mu sync.Mutex
+				deleted := false
+				svc := common.NewTestService(
+					t, http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							mu.Lock()
+							defer mu.Unlock()
+							resource := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+							if resource == "set" {
+								resource = "thumbnails"
+							}
+							if r.Method == http.MethodDelete {
+								deleted = true
+								resource += ":DELETE"
+							}
+							for _, f := range tt.fail {
+								if f == resource {
+									http.Error(w, `{"error": {"code": 500, "message": "boom"}}`, http.StatusInternalServerError)
+									return
+								}
+							}
+							if r.Method == http.MethodDelete {
+								w.WriteHeader(http.StatusNoContent)
+								return
+							}
+							w.Header().Set("Content-Type", "application/json")
+							_, _ = w.Write([]byte(`{"id": "vid", "snippet": {"title": "t"}, "status": {"privacyStatus": "private"}}`))
+						},
+					),
+				)
+
+				var buf bytes.Buffer
+				v := NewVideo(
+					WithService(svc), WithFile("video.mp4"), WithPrivacy("private"),
+					WithThumbnail("cover.jpg"), WithPlaylistId("PL1"),
+					WithCaption("subs.srt"), WithCaptionLanguage("en"),
+					WithAtomic(tt.atomic), WithOutput(tt.output),
+				)
+				err = v.Insert(&buf)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Insert() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil && !errors.Is(err, errFollowUp) {
+					t.Errorf("Insert() error = %v, want %v", err, errFollowUp)
+				}
+				if deleted != tt.wantDelete {
+					t.Errorf("video deleted = %v, want %v", deleted, tt.wantDelete)
+				}
+
+				if tt.output == "json" {
+					outcome := &Outcome{}
+					if err := json.Unmarshal(buf.Bytes(), outcome); err != nil {
+						t.Fatalf("invalid json outcome: %v\n%s", err, buf.String())
+					}
+					got := map[string]string{}
+					for _, s := range outcome.Steps {
+						got[s.Name] = s.Status
+						if s.Status == statusFailed && s.Error == "" {
+							t.Errorf("failed step %s has no error", s.Name)
+						}
+					}
+					if len(got) != len(tt.wantSteps) {
+						t.Errorf("steps = %v, want %v", got, tt.wantSteps)
+					}
+					for name, status := range tt.wantSteps {
+						if got[name] != status {
+							t.Errorf("step %s = %q, want %q", name, got[name], status)
+						}
+					}
+					if outcome.RolledBack != (tt.wantDelete && tt.wantLedger == 0) {
+						t.Errorf("rolled_back = %v", outcome.RolledBack)
+					}
+				}
+
+				entries, err := ledger.Load()
+				if err != nil {
+					t.Fatalf("ledger.Load() error = %v", err)
+				}
+				if len(entries) != tt.wantLedger {
+					t.Errorf("ledger has %d entries, want %d", len(entries), tt.wantLedger)
+				}
+			},
+		)
+	}
+}
@@ -4,22 +4,39 @@
 package video
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
 	"os"
-	"slices"
+	"path/filepath"
+	"strconv"
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/ledger"
+	"github.com/eat-pray-ai/yutu/pkg/lint"
 	"github.com/eat-pray-ai/yutu/pkg/playlistItem"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
 	"github.com/eat-pray-ai/yutu/pkg/thumbnail"
+	"github.com/eat-pray-ai/yutu/pkg/upload"
 	"github.com/jedib0t/go-pretty/v6/table"
 
 	"github.com/eat-pray-ai/yutu/pkg/utils"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/youtube/v3"
 )
 
+// sniffLen is how much of a stream http.DetectContentType considers.
+const sniffLen = 512
+
 var (
 	errGetVideo           = errors.New("failed to get video")
 	errInsertVideo        = errors.New("failed to insert video")
@@ -28,6 +45,9 @@ var (
 	errGetRating          = errors.New("failed to get rating")
 	errDeleteVideo        = errors.New("failed to delete video")
 	errReportAbuse        = errors.New("failed to report abuse")
+	errDuplicate          = errors.New("file already uploaded")
+	errStreamTitle        = errors.New("title is required when uploading from a stream")
+	errEmptyStream        = errors.New("video stream is empty")
 	errScheduleNonPrivate = errors.New(
 		"publishAt requires privacy private; a public or unlisted video would go offline until the scheduled time",
 	)
@@ -58,13 +78,29 @@ type Video struct {
 	Stabilize   *bool    `yaml:"stabilize" json:"stabilize,omitempty"`
 	MaxHeight   int64    `yaml:"max_height" json:"max_height,omitempty"`
 	MaxWidth    int64    `yaml:"max_width" json:"max_width,omitempty"`
+	Resume      bool     `yaml:"resume" json:"resume,omitempty"`
+	Manifest    string   `yaml:"manifest" json:"manifest,omitempty"`
+	Results     string   `yaml:"results" json:"results,omitempty"`
+	Concurrency int64    `yaml:"concurrency" json:"concurrency,omitempty"`
+	Force       bool     `yaml:"force" json:"force,omitempty"`
+	Wait        bool     `yaml:"wait" json:"wait,omitempty"`
+	WaitTimeout string   `yaml:"wait_timeout" json:"wait_timeout,omitempty"`
+	Atomic      bool     `yaml:"atomic" json:"atomic,omitempty"`
+	Probe       bool     `yaml:"probe" json:"probe,omitempty"`
+	Chapters    string   `yaml:"chapters" json:"chapters,omitempty"`
 
 	RecordingDate                 string `yaml:"recording_date" json:"recording_date,omitempty"`
+	Caption                       string `yaml:"caption" json:"caption,omitempty"`
+	CaptionName                   string `yaml:"caption_name" json:"caption_name,omitempty"`
+	CaptionLanguage               string `yaml:"caption_language" json:"caption_language,omitempty"`
 	ContainsSyntheticMedia        *bool  `yaml:"contains_synthetic_media" json:"contains_synthetic_media,omitempty"`
 	SecondaryReasonId             string `yaml:"secondary_reason_id" json:"secondary_reason_id,omitempty"`
 	NotifySubscribers             *bool  `yaml:"notify_subscribers" json:"notify_subscribers,omitempty"`
 	PublicStatsViewable           *bool  `yaml:"public_stats_viewable" json:"public_stats_viewable,omitempty"`
 	OnBehalfOfContentOwnerChannel string `yaml:"on_behalf_of_content_owner_channel" json:"on_behalf_of_content_owner_channel,omitempty"`
+
+	// Reader, when set, is uploaded instead of opening File.
+	Reader io.Reader `yaml:"-" json:"-"`
 }
 
 type IVideo[T any] interface {
@@ -75,7 +111,9 @@ type IVideo[T any] interface {
 	GetRating(io.Writer) error
 	Delete(io.Writer) error
 	ReportAbuse(io.Writer) error
+	Status(io.Writer) error
 	Get() ([]*T, error)
+	All() iter.Seq2[*T, error]
 }
 
 type Option func(*Video)
@@ -89,8 +127,12 @@ func NewVideo(opts ...Option) IVideo[youtube.Video] {
 }
 
 func (v *Video) Get() ([]*youtube.Video, error) {
+	return common.Collect(v.All())
+}
+
+func (v *Video) All() iter.Seq2[*youtube.Video, error] {
 	if err := v.EnsureService(); err != nil {
-		return nil, err
+		return common.FailSeq[youtube.Video](err)
 	}
 	call := v.Service.Videos.List(v.Parts)
 	if len(v.Ids) > 0 {
@@ -124,22 +166,19 @@ func (v *Video) Get() ([]*youtube.Video, error) {
 		call = call.OnBehalfOfContentOwner(v.OnBehalfOfContentOwner)
 	}
 
-	return common.Paginate(
+	return common.PaginateSeq(
 		&v.Fields, call,
-		func(r *youtube.VideoListResponse) ([]*youtube.Video, string) {
-			return r.Items, r.NextPageToken
+		func(r *youtube.VideoListResponse) (
+			[]*youtube.Video, string, *youtube.PageInfo,
+		) {
+			return r.Items, r.NextPageToken, r.PageInfo
 		}, errGetVideo,
 	)
 }
 
 func (v *Video) List(writer io.Writer) error {
-	videos, err := v.Get()
-	if err != nil && videos == nil {
-		return err
-	}
-
-	common.PrintList(
-		v.Output, videos, writer, table.Row{"ID", "Title", "Channel ID", "Views"},
+	return common.PrintSeq(
+		&v.Fields, v.All(), writer, table.Row{"ID", "Title", "Channel ID", "Views"},
 		func(video *youtube.Video) table.Row {
 			title := ""
 			channelId := ""
@@ -154,28 +193,100 @@ func (v *Video) List(writer io.Writer) error {
 			return table.Row{video.Id, title, channelId, views}
 		},
 	)
-	return err
 }
 
 func (v *Video) Insert(writer io.Writer) error {
-	if err := v.EnsureService(); err != nil {
+	if v.Manifest != "" {
+		return v.insertManifest(writer)
+	}
+	res, outcome, err := v.insert()
+	if outcome != nil {
+		common.PrintResult(v.Output, outcome, writer, "%s", outcome)
 		return err
 	}
-	file, err := pkg.Root.Open(v.File)
-	if err != nil {
-		return errors.Join(errInsertVideo, err)
+	if res == nil {
+		return err
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
 
-	if !slices.Contains(v.Tags, "yutu🐰") {
-		v.Tags = append(v.Tags, "yutu🐰")
+	if v.Wait {
+		common.PrintResult(
+			v.Output, res, writer, "Video inserted: %s, upload status: %s\n",
+			res.Id, NewStatusReport(res).UploadStatus,
+		)
+	} else {
+		common.PrintResult(v.Output, res, writer, "Video inserted: %s\n", res.Id)
 	}
+	return err
+}
 
+// insert uploads v.File and runs the thumbnail, playlist and caption
+// follow-ups. When a follow-up fails, the outcome of every step is returned
+// with the error, and the video only if it was not rolled back. With v.Wait
+// it then waits for processing; a video that uploaded but failed processing
+// is returned together with the error.
+func (v *Video) insert() (*youtube.Video, *Outcome, error) {
 	if v.Title == "" {
+		if v.Reader != nil && (v.File == "" || v.File == "-") {
+			return nil, nil, errors.Join(errInsertVideo, errStreamTitle)
+		}
 		v.Title = utils.GetFileName(v.File)
 	}
+	v.Tags = lint.Tagged(v.Tags)
+	chapters, err := v.readChapters()
+	if err != nil {
+		return nil, nil, errors.Join(errInsertVideo, err)
+	}
+	if chapters != nil {
+		v.Description = chapters.Apply(v.Description)
+	}
+	if err := v.lint(); err != nil {
+		return nil, nil, errors.Join(errInsertVideo, err)
+	}
+
+	if err := v.EnsureService(); err != nil {
+		return nil, nil, err
+	}
+	if v.Wait {
+		if _, err := v.waitTimeout(); err != nil {
+			return nil, nil, errors.Join(errInsertVideo, err)
+		}
+	}
+
+	var (
+		file   *os.File
+		stream *ledger.HashReader
+		hash   string
+		size   int64
+	)
+	if v.Reader != nil {
+		// A stream can only be hashed while it is uploaded, so duplicates
+		// are reported afterwards instead of refused.
+		stream = ledger.NewHashReader(v.Reader)
+		if v.Probe {
+			slog.Warn("Probing needs a seekable file, skipped for stream")
+		}
+	} else {
+		file, err = pkg.Root.Open(v.File)
+		if err != nil {
+			return nil, nil, errors.Join(errInsertVideo, err)
+		}
+		defer func(file *os.File) {
+			_ = file.Close()
+		}(file)
+
+		hash, size, err = v.checkDuplicate(file)
+		if err != nil {
+			return nil, nil, errors.Join(errInsertVideo, err)
+		}
+		if v.Probe || chapters != nil {
+			info := v.probe(file, size)
+			if info != nil && chapters != nil {
+				if err := chapters.Validate(info.Duration); err != nil {
+					return nil, nil, errors.Join(errInsertVideo, err)
+				}
+			}
+		}
+	}
 
 	video := &youtube.Video{
 		Snippet: &youtube.VideoSnippet{
@@ -234,46 +345,232 @@ func (v *Video) Insert(writer io.Writer) error {
 		call = call.Stabilize(*v.Stabilize)
 	}
 
-	res, err := call.Media(file).Do()
+	var res *youtube.Video
+	switch {
+	case stream != nil:
+		res, err = v.insertStream(stream, video, insertParts)
+	case v.Resume:
+		res, err = v.insertResumable(file, video, insertParts)
+	default:
+		res, err = call.Media(progress.File(v.Ctx, file)).Do()
+	}
 	if err != nil {
-		return errors.Join(errInsertVideo, err)
+		return nil, nil, errors.Join(errInsertVideo, err)
+	}
+	if stream != nil {
+		hash, size = stream.Sum()
+		if prev, _ := ledger.Lookup(hash); prev != nil {
+			slog.Warn(
+				"uploaded stream matches an earlier upload", "videoId", res.Id,
+				"previousVideoId", prev.VideoId, "uploaded", prev.Uploaded,
+			)
+		}
 	}
 
-	if v.Thumbnail != "" {
-		t := thumbnail.NewThumbnail(
-			thumbnail.WithVideoId(res.Id),
-			thumbnail.WithFile(v.Thumbnail),
-			thumbnail.WithService(v.Service),
-			thumbnail.WithOutput("silent"),
+	outcome := v.followUp(res)
+	if !outcome.RolledBack {
+		path := v.File
+		if stream != nil {
+			path = ""
+		}
+		if err := ledger.Record(
+			&ledger.Entry{
+				Hash: hash, Size: size, Path: path, VideoId: res.Id,
+				Title: v.Title,
+			},
+		); err != nil {
+			slog.Warn("failed to record upload", "file", v.File, "error", err)
+		}
+	}
+	if err := outcome.Err(); err != nil {
+		if outcome.RolledBack {
+			res = nil
+		}
+		return res, outcome, errors.Join(errInsertVideo, err)
+	}
+
+	if v.Wait {
+		latest, err := v.waitProcessed(res.Id)
+		if latest != nil {
+			res.Status = latest.Status
+			res.ProcessingDetails = latest.ProcessingDetails
+			res.Suggestions = latest.Suggestions
+		}
+		if err != nil {
+			return res, nil, errors.Join(errInsertVideo, err)
+		}
+	}
+
+	return res, nil, nil
+}
+
+// readChapters reads v.Chapters, if set, and checks the rules that do not
+// depend on the video duration.
+func (v *Video) readChapters() (Chapters, error) {
+	if v.Chapters == "" {
+		return nil, nil
+	}
+	chapters, err := readChapters(v.Chapters)
+	if err != nil {
+		return nil, err
+	}
+	return chapters, chapters.Validate(0)
+}
+
+// lint checks the metadata of v against the API limits before any call.
+func (v *Video) lint() error {
+	return lint.Check(
+		lint.Metadata{Title: v.Title, Description: v.Description, Tags: v.Tags},
+	)
+}
+
+// checkDuplicate hashes file and looks the hash up in the upload ledger.
+// A file that was uploaded before is refused unless v.Force is set, in which
+// case only a warning is logged. The file offset is reset before returning.
+func (v *Video) checkDuplicate(file *os.File) (string, int64, error) {
+	hash, size, err := ledger.HashFile(file)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	prev, err := ledger.Lookup(hash)
+	if err != nil {
+		slog.Warn("failed to check upload ledger", "error", err)
+		return hash, size, nil
+	}
+	if prev == nil {
+		return hash, size, nil
+	}
+	if !v.Force {
+		return "", 0, fmt.Errorf(
+			"%w: %s matches video %s uploaded from %s at %s, use --force to upload anyway",
+			errDuplicate, v.File, prev.VideoId, prev.Path, prev.Uploaded,
 		)
-		_ = t.Set(nil)
 	}
+	slog.Warn(
+		"uploading duplicate file", "file", v.File, "videoId", prev.VideoId,
+		"uploaded", prev.Uploaded,
+	)
+	return hash, size, nil
+}
 
-	if v.PlaylistId != "" {
-		pi := playlistItem.NewPlaylistItem(
-			playlistItem.WithTitle(res.Snippet.Title),
-			playlistItem.WithDescription(res.Snippet.Description),
-			playlistItem.WithKind("video"),
-			playlistItem.WithKVideoId(res.Id),
-			playlistItem.WithPlaylistId(v.PlaylistId),
-			playlistItem.WithChannelId(res.Snippet.ChannelId),
-			playlistItem.WithPrivacy(res.Status.PrivacyStatus),
-			playlistItem.WithService(v.Service),
-			playlistItem.WithOutput("silent"),
+// insertResumable uploads the file in chunks, recording the session under
+// pkg.Root so that a later run with the same file resumes instead of
+// starting over.
+func (v *Video) insertResumable(
+	file *os.File, video *youtube.Video, parts string,
+) (*youtube.Video, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(v.File))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx, r := v.resumable(video, parts, contentType, info.Size())
+	r.StatePath = upload.StatePath(v.File)
+	body, err := r.Upload(ctx, file, v.File, info.Size(), info.ModTime())
+	if err != nil {
+		return nil, err
+	}
+	return decodeVideo(body)
+}
+
+// insertStream uploads a stream of unknown length in chunks. The MIME type
+// is sniffed from the first bytes of the stream.
+func (v *Video) insertStream(
+	stream io.Reader, video *youtube.Video, parts string,
+) (*youtube.Video, error) {
+	br := bufio.NewReaderSize(stream, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if len(head) == 0 {
+		if err == nil || err == io.EOF {
+			err = errEmptyStream
+		}
+		return nil, err
+	}
+
+	ctx, r := v.resumable(video, parts, http.DetectContentType(head), -1)
+	body, err := r.UploadStream(ctx, br)
+	if err != nil {
+		return nil, err
+	}
+	return decodeVideo(body)
+}
+
+// resumable prepares a resumable upload of size bytes, -1 when unknown,
+// carrying the same query parameters as the generated insert call.
+func (v *Video) resumable(
+	video *youtube.Video, parts, contentType string, size int64,
+) (context.Context, *upload.Resumable) {
+	params := url.Values{}
+	params.Set("alt", "json")
+	params.Set("uploadType", "resumable")
+	params.Set("part", parts)
+	if v.AutoLevels != nil {
+		params.Set("autoLevels", strconv.FormatBool(*v.AutoLevels))
+	}
+	if v.NotifySubscribers != nil {
+		params.Set("notifySubscribers", strconv.FormatBool(*v.NotifySubscribers))
+	}
+	if v.OnBehalfOfContentOwner != "" {
+		params.Set("onBehalfOfContentOwner", v.OnBehalfOfContentOwner)
+	}
+	if v.OnBehalfOfContentOwnerChannel != "" {
+		params.Set(
+			"onBehalfOfContentOwnerChannel", v.OnBehalfOfContentOwnerChannel,
 		)
+	}
+	if v.Stabilize != nil {
+		params.Set("stabilize", strconv.FormatBool(*v.Stabilize))
+	}
 
-		_ = pi.Insert(writer)
+	ctx := v.Ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	return ctx, &upload.Resumable{
+		Client: v.HTTPClient(),
+		URL: googleapi.ResolveRelative(
+			v.Service.BasePath, "upload/youtube/v3/videos",
+		) + "?" + params.Encode(),
+		Metadata:    video,
+		ContentType: contentType,
+		Progress:    progress.NewTracker(progress.FromContext(ctx), size),
+	}
+}
 
-	common.PrintResult(v.Output, res, writer, "Video inserted: %s\n", res.Id)
-	return nil
+func decodeVideo(body []byte) (*youtube.Video, error) {
+	res := &youtube.Video{}
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 func (v *Video) Update(writer io.Writer) error {
+	if v.Tags != nil {
+		v.Tags = lint.Tagged(v.Tags)
+	}
+	if err := v.lint(); err != nil {
+		return errors.Join(errUpdateVideo, err)
+	}
+	chapters, err := v.readChapters()
+	if err != nil {
+		return errors.Join(errUpdateVideo, err)
+	}
 	if err := v.EnsureService(); err != nil {
 		return err
 	}
 	v.Parts = []string{"id", "snippet", "status"}
+	if chapters != nil {
+		v.Parts = append(v.Parts, "contentDetails")
+	}
 	videos, err := v.Get()
 
 	if err != nil {
@@ -316,10 +613,23 @@ func (v *Video) Update(writer io.Writer) error {
 	if v.Description != "" {
 		video.Snippet.Description = v.Description
 	}
-	if v.Tags != nil {
-		if !slices.Contains(v.Tags, "yutu🐰") {
-			v.Tags = append(v.Tags, "yutu🐰")
+	if chapters != nil {
+		if original.ContentDetails != nil {
+			duration, err := parseISODuration(original.ContentDetails.Duration)
+			if err == nil {
+				err = chapters.Validate(duration)
+			}
+			if err != nil {
+				return errors.Join(errUpdateVideo, err)
+			}
 		}
+		video.Snippet.Description = chapters.Apply(video.Snippet.Description)
+		err := lint.Check(lint.Metadata{Description: video.Snippet.Description})
+		if err != nil {
+			return errors.Join(errUpdateVideo, err)
+		}
+	}
+	if v.Tags != nil {
 		video.Snippet.Tags = v.Tags
 	}
 	if v.Language != "" {
@@ -425,22 +735,15 @@ func (v *Video) GetRating(writer io.Writer) error {
 		return errors.Join(errGetRating, err)
 	}
 
-	switch v.Output {
-	case "json":
-		utils.PrintJSON(res.Items, writer)
-	case "yaml":
-		utils.PrintYAML(res.Items, writer)
-	default:
-		tb := table.NewWriter()
-		defer tb.Render()
-		tb.SetOutputMirror(writer)
-		tb.SetStyle(pkg.TableStyle)
-		tb.AppendHeader(table.Row{"ID", "Rating"})
-		for _, item := range res.Items {
-			tb.AppendRow(table.Row{item.VideoId, item.Rating})
-		}
+	if v.Output == "" {
+		v.Output = "table"
 	}
-	return nil
+	return common.PrintList(
+		&v.Fields, res.Items, writer, table.Row{"ID", "Rating"},
+		func(r *youtube.VideoRating) table.Row {
+			return table.Row{r.VideoId, r.Rating}
+		},
+	)
 }
 
 func (v *Video) Delete(writer io.Writer) error {
@@ -646,6 +949,90 @@ func WithMaxWidth(maxWidth int64) Option {
 	}
 }
 
+func WithResume(resume bool) Option {
+	return func(v *Video) {
+		v.Resume = resume
+	}
+}
+
+func WithManifest(manifest string) Option {
+	return func(v *Video) {
+		v.Manifest = manifest
+	}
+}
+
+func WithResults(results string) Option {
+	return func(v *Video) {
+		v.Results = results
+	}
+}
+
+func WithConcurrency(concurrency int64) Option {
+	return func(v *Video) {
+		v.Concurrency = concurrency
+	}
+}
+
+func WithForce(force bool) Option {
+	return func(v *Video) {
+		v.Force = force
+	}
+}
+
+func WithWait(wait bool) Option {
+	return func(v *Video) {
+		v.Wait = wait
+	}
+}
+
+func WithWaitTimeout(timeout string) Option {
+	return func(v *Video) {
+		v.WaitTimeout = timeout
+	}
+}
+
+func WithReader(reader io.Reader) Option {
+	return func(v *Video) {
+		v.Reader = reader
+	}
+}
+
+func WithCaption(caption string) Option {
+	return func(v *Video) {
+		v.Caption = caption
+	}
+}
+
+func WithCaptionLanguage(language string) Option {
+	return func(v *Video) {
+		v.CaptionLanguage = language
+	}
+}
+
+func WithCaptionName(name string) Option {
+	return func(v *Video) {
+		v.CaptionName = name
+	}
+}
+
+func WithAtomic(atomic bool) Option {
+	return func(v *Video) {
+		v.Atomic = atomic
+	}
+}
+
+func WithChapters(chapters string) Option {
+	return func(v *Video) {
+		v.Chapters = chapters
+	}
+}
+
+func WithProbe(probe bool) Option {
+	return func(v *Video) {
+		v.Probe = probe
+	}
+}
+
 func WithNotifySubscribers(notifySubscribers *bool) Option {
 	return func(v *Video) {
 		if notifySubscribers != nil {
@@ -680,8 +1067,15 @@ func WithSecondaryReasonId(secondaryReasonId string) Option {
 
 var (
 	WithParts      = common.WithParts[*Video]
+	WithColumns    = common.WithColumns[*Video]
+	WithWhere      = common.WithWhere[*Video]
+	WithSortBy     = common.WithSortBy[*Video]
+	WithFieldMask  = common.WithFieldMask[*Video]
+	WithPageToken  = common.WithPageToken[*Video]
+	WithEnvelope   = common.WithEnvelope[*Video]
 	WithOutput     = common.WithOutput[*Video]
 	WithService    = common.WithService[*Video]
+	WithContext    = common.WithContext[*Video]
 	WithIds        = common.WithIds[*Video]
 	WithMaxResults = common.WithMaxResults[*Video]
 	WithHl         = common.WithHl[*Video]
@@ -6,6 +6,8 @@ package video
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"math"
 	"mime"
@@ -18,6 +20,8 @@ import (
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/ledger"
+	"github.com/eat-pray-ai/yutu/pkg/upload"
 	"google.golang.org/api/youtube/v3"
 )
 
@@ -83,6 +87,7 @@ func TestNewVideo(t *testing.T) {
 					WithMaxResults(50),
 					WithNotifySubscribers(&notifySubscribersTrue),
 					WithPublicStatsViewable(&publicStatsViewableTrue),
+					WithResume(true),
 					WithOnBehalfOfContentOwner("owner123"),
 					WithOnBehalfOfContentOwnerChannel("ownerChannel123"),
 					WithParts([]string{"snippet", "contentDetails"}),
@@ -127,6 +132,7 @@ func TestNewVideo(t *testing.T) {
 				Stabilize:                     &stabilizeTrue,
 				MaxHeight:                     1080,
 				MaxWidth:                      1920,
+				Resume:                        true,
 				NotifySubscribers:             &notifySubscribersTrue,
 				PublicStatsViewable:           &publicStatsViewableTrue,
 				OnBehalfOfContentOwnerChannel: "ownerChannel123",
@@ -735,6 +741,8 @@ func TestVideo_Insert(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(
 			tt.name, func(t *testing.T) {
+				// Every case uploads the same file; start from an empty ledger.
+				_ = pkg.Root.Remove(ledger.Path())
 				svc := common.NewTestService(
 					t, http.HandlerFunc(
 						func(w http.ResponseWriter, r *http.Request) {
@@ -758,6 +766,72 @@ func TestVideo_Insert(t *testing.T) {
 	}
 }
 
+func TestVideo_Insert_Duplicate(t *testing.T) {
+	tmpDir := t.TempDir()
+	root, err := os.OpenRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to open root: %v", err)
+	}
+	oldRoot := pkg.Root
+	pkg.Root = root
+	defer func() { pkg.Root = oldRoot }()
+	defer func() { _ = root.Close() }()
+	t.Setenv("YUTU_CACHE_TOKEN", "")
+
+	for _, name := range []string{"a.mp4", "b.mp4"} {
+		if err := os.WriteFile(tmpDir+"/"+name, []byte("same bytes"), 0644); err != nil {
+			t.Fatalf("failed to create dummy file: %v", err)
+		}
+	}
+
+	uploads := 0
+	svc := common.NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				uploads++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(
+					w, `{"id": "vid-%d", "snippet": {"title": "t"}, "status": {}}`,
+					uploads,
+				)
+			},
+		),
+	)
+
+	insert := func(file string, force bool) error {
+		v := NewVideo(
+			WithService(svc), WithFile(file), WithPrivacy("private"),
+			WithForce(force), WithOutput("silent"),
+		)
+		return v.Insert(io.Discard)
+	}
+
+	if err := insert("a.mp4", false); err != nil {
+		t.Fatalf("first Insert() error = %v", err)
+	}
+	err = insert("b.mp4", false)
+	if !errors.Is(err, errDuplicate) {
+		t.Fatalf("duplicate Insert() error = %v, want %v", err, errDuplicate)
+	}
+	if !strings.Contains(err.Error(), "vid-1") {
+		t.Errorf("error should name the existing video: %v", err)
+	}
+	if uploads != 1 {
+		t.Errorf("duplicate was uploaded, %d uploads", uploads)
+	}
+
+	if err := insert("b.mp4", true); err != nil {
+		t.Fatalf("forced Insert() error = %v", err)
+	}
+	entries, err := ledger.Load()
+	if err != nil {
+		t.Fatalf("ledger.Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[1].Path != "b.mp4" || entries[1].VideoId != "vid-2" {
+		t.Errorf("ledger entries = %+v", entries)
+	}
+}
+
 func TestVideo_Insert_FileError(t *testing.T) {
 	tmpDir := t.TempDir()
 	root, err := os.OpenRoot(tmpDir)
@@ -787,6 +861,187 @@ func TestVideo_Insert_FileError(t *testing.T) {
 	}
 }
 
+func TestVideo_Insert_Resume(t *testing.T) {
+	tmpDir := t.TempDir()
+	root, err := os.OpenRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to open root: %v", err)
+	}
+	oldRoot := pkg.Root
+	pkg.Root = root
+	defer func() { pkg.Root = oldRoot }()
+	defer func() { _ = root.Close() }()
+
+	content := []byte("dummy video content")
+	if err := os.WriteFile(tmpDir+"/test_video.mp4", content, 0644); err != nil {
+		t.Fatalf("failed to create dummy file: %v", err)
+	}
+	info, _ := os.Stat(tmpDir + "/test_video.mp4")
+
+	var sessionURL string
+	var received []byte
+	svc := common.NewTestService(
+		t, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPost:
+					t.Error("resumed upload must not initiate a new session")
+				case http.MethodPut:
+					cr := r.Header.Get("Content-Range")
+					if strings.HasPrefix(cr, "bytes */") {
+						w.Header().Set("Range", "bytes=0-4")
+						w.WriteHeader(308)
+						return
+					}
+					if want := "bytes 5-18/19"; cr != want {
+						t.Errorf("Content-Range = %q, want %q", cr, want)
+					}
+					received, _ = io.ReadAll(r.Body)
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"id": "resumed-id", "snippet": {"title": "t"}, "status": {}}`))
+				}
+			},
+		),
+	)
+	sessionURL = strings.TrimSuffix(svc.BasePath, "/") + "/session"
+
+	state, _ := json.Marshal(
+		upload.State{
+			SessionURI: sessionURL, File: "test_video.mp4",
+			Size: info.Size(), ModTime: info.ModTime(), Offset: 5,
+		},
+	)
+	statePath := upload.StatePath("test_video.mp4")
+	_ = root.MkdirAll(upload.StateDir, 0755)
+	if err := root.WriteFile(statePath, state, 0600); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	v := NewVideo(
+		WithService(svc),
+		WithFile("test_video.mp4"),
+		WithResume(true),
+		WithOutput("json"),
+	)
+	var buf bytes.Buffer
+	if err := v.Insert(&buf); err != nil {
+		t.Fatalf("Video.Insert() error = %v", err)
+	}
+	if string(received) != string(content[5:]) {
+		t.Errorf("server received %q, want %q", received, content[5:])
+	}
+	if !strings.Contains(buf.String(), "resumed-id") {
+		t.Errorf("Video.Insert() output = %s", buf.String())
+	}
+	if _, err := root.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("state file should be removed, stat err = %v", err)
+	}
+}
+
+func TestVideo_Insert_Reader(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		stream   []byte
+		wantType string
+		wantErr  error
+	}{
+		{
+			name:     "mp4 stream",
+			opts:     []Option{WithTitle("Piped")},
+			stream:   append([]byte("\x00\x00\x00\x18ftypmp42"), make([]byte, 40)...),
+			wantType: "video/mp4",
+		},
+		{
+			name:    "title required",
+			opts:    []Option{WithFile("-")},
+			stream:  []byte("video"),
+			wantErr: errStreamTitle,
+		},
+		{
+			name:    "empty stream",
+			opts:    []Option{WithTitle("Empty")},
+			wantErr: errEmptyStream,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				root, err := os.OpenRoot(t.TempDir())
+				if err != nil {
+					t.Fatalf("failed to open root: %v", err)
+				}
+				oldRoot := pkg.Root
+				pkg.Root = root
+				defer func() { pkg.Root = oldRoot }()
+				defer func() { _ = root.Close() }()
+				t.Setenv("YUTU_CACHE_TOKEN", "")
+
+				var received []byte
+				var contentType string
+				svc := common.NewTestService(
+					t, http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							switch r.Method {
+							case http.MethodPost:
+								if got := r.URL.Query().Get("uploadType"); got != "resumable" {
+									t.Errorf("uploadType = %q", got)
+								}
+								if r.Header.Get("X-Upload-Content-Length") != "" {
+									t.Error("a stream has no known length")
+								}
+								contentType = r.Header.Get("X-Upload-Content-Type")
+								w.Header().Set("Location", "http://"+r.Host+"/session")
+							case http.MethodPut:
+								body, _ := io.ReadAll(r.Body)
+								received = append(received, body...)
+								cr := r.Header.Get("Content-Range")
+								if strings.HasSuffix(cr, "/*") {
+									w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+									w.WriteHeader(308)
+									return
+								}
+								w.Header().Set("Content-Type", "application/json")
+								_, _ = w.Write([]byte(`{"id": "stream-id", "snippet": {"title": "Piped"}, "status": {}}`))
+							}
+						},
+					),
+				)
+
+				opts := append(
+					[]Option{
+						WithService(svc), WithPrivacy("private"), WithOutput("silent"),
+						WithReader(bytes.NewReader(tt.stream)),
+					}, tt.opts...,
+				)
+				err = NewVideo(opts...).Insert(io.Discard)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Insert() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil {
+					return
+				}
+				if contentType != tt.wantType {
+					t.Errorf("X-Upload-Content-Type = %q, want %q", contentType, tt.wantType)
+				}
+				if !bytes.Equal(received, tt.stream) {
+					t.Errorf("server received %d bytes, want %d", len(received), len(tt.stream))
+				}
+
+				entries, err := ledger.Load()
+				if err != nil {
+					t.Fatalf("ledger.Load() error = %v", err)
+				}
+				if len(entries) != 1 || entries[0].VideoId != "stream-id" ||
+					entries[0].Size != int64(len(tt.stream)) || entries[0].Path != "" {
+					t.Errorf("ledger entries = %+v", entries)
+				}
+			},
+		)
+	}
+}
+
 func TestVideo_Update(t *testing.T) {
 	embeddableTrue := true
 	containsSyntheticMediaTrue := true
@@ -1012,6 +1267,29 @@ func TestVideo_Update(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "update video with invalid metadata",
+			opts: []Option{
+				WithIds([]string{"video-id"}),
+				WithTitle(strings.Repeat("t", 101)),
+				WithTags([]string{"<tag>"}),
+			},
+			verify: func(r *http.Request) {
+				t.Errorf("invalid metadata should not reach the API: %s", r.Method)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update video with tags too long once tagged",
+			opts: []Option{
+				WithIds([]string{"video-id"}),
+				WithTags([]string{strings.Repeat("t", 496)}),
+			},
+			verify: func(r *http.Request) {
+				t.Errorf("invalid metadata should not reach the API: %s", r.Method)
+			},
+			wantErr: true,
+		},
 		{
 			name: "update video not found",
 			opts: []Option{
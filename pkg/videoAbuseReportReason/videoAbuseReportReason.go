@@ -46,6 +46,11 @@ func (va *VideoAbuseReportReason) Get() (
 		call = call.Hl(va.Hl)
 	}
 
+	call, err := common.ApplyMask(&va.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetVideoAbuseReportReason, err)
@@ -60,18 +65,25 @@ func (va *VideoAbuseReportReason) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		va.Output, reasons, writer, table.Row{"ID", "Label"},
+	return common.PrintList(
+		&va.Fields, reasons, writer, table.Row{"ID", "Label"},
 		func(r *youtube.VideoAbuseReportReason) table.Row {
-			return table.Row{r.Id, r.Snippet.Label}
+			label := ""
+			if r.Snippet != nil {
+				label = r.Snippet.Label
+			}
+			return table.Row{r.Id, label}
 		},
 	)
-	return nil
 }
 
 var (
-	WithHL      = common.WithHl[*VideoAbuseReportReason]
-	WithParts   = common.WithParts[*VideoAbuseReportReason]
-	WithOutput  = common.WithOutput[*VideoAbuseReportReason]
-	WithService = common.WithService[*VideoAbuseReportReason]
+	WithHL        = common.WithHl[*VideoAbuseReportReason]
+	WithParts     = common.WithParts[*VideoAbuseReportReason]
+	WithColumns   = common.WithColumns[*VideoAbuseReportReason]
+	WithWhere     = common.WithWhere[*VideoAbuseReportReason]
+	WithSortBy    = common.WithSortBy[*VideoAbuseReportReason]
+	WithFieldMask = common.WithFieldMask[*VideoAbuseReportReason]
+	WithOutput    = common.WithOutput[*VideoAbuseReportReason]
+	WithService   = common.WithService[*VideoAbuseReportReason]
 )
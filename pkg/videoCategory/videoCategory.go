@@ -51,6 +51,11 @@ func (vc *VideoCategory) Get() ([]*youtube.VideoCategory, error) {
 		call = call.RegionCode(vc.RegionCode)
 	}
 
+	call, err := common.ApplyMask(&vc.Fields, call)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := call.Do()
 	if err != nil {
 		return nil, errors.Join(errGetVideoCategory, err)
@@ -65,13 +70,18 @@ func (vc *VideoCategory) List(writer io.Writer) error {
 		return err
 	}
 
-	common.PrintList(
-		vc.Output, categories, writer, table.Row{"ID", "Title", "Assignable"},
+	return common.PrintList(
+		&vc.Fields, categories, writer, table.Row{"ID", "Title", "Assignable"},
 		func(c *youtube.VideoCategory) table.Row {
-			return table.Row{c.Id, c.Snippet.Title, c.Snippet.Assignable}
+			title := ""
+			assignable := false
+			if c.Snippet != nil {
+				title = c.Snippet.Title
+				assignable = c.Snippet.Assignable
+			}
+			return table.Row{c.Id, title, assignable}
 		},
 	)
-	return nil
 }
 
 func WithRegionCode(regionCode string) Option {
@@ -81,9 +91,13 @@ func WithRegionCode(regionCode string) Option {
 }
 
 var (
-	WithIds     = common.WithIds[*VideoCategory]
-	WithHl      = common.WithHl[*VideoCategory]
-	WithParts   = common.WithParts[*VideoCategory]
-	WithOutput  = common.WithOutput[*VideoCategory]
-	WithService = common.WithService[*VideoCategory]
+	WithIds       = common.WithIds[*VideoCategory]
+	WithHl        = common.WithHl[*VideoCategory]
+	WithParts     = common.WithParts[*VideoCategory]
+	WithColumns   = common.WithColumns[*VideoCategory]
+	WithWhere     = common.WithWhere[*VideoCategory]
+	WithSortBy    = common.WithSortBy[*VideoCategory]
+	WithFieldMask = common.WithFieldMask[*VideoCategory]
+	WithOutput    = common.WithOutput[*VideoCategory]
+	WithService   = common.WithService[*VideoCategory]
 )
@@ -11,6 +11,7 @@ import (
 
 	"github.com/eat-pray-ai/yutu/pkg"
 	"github.com/eat-pray-ai/yutu/pkg/common"
+	"github.com/eat-pray-ai/yutu/pkg/progress"
 	"google.golang.org/api/youtube/v3"
 )
 
@@ -73,7 +74,9 @@ func (w *Watermark) Set(writer io.Writer) error {
 		inVideoBranding.Timing.Type = w.OffsetType
 	}
 
-	call := w.Service.Watermarks.Set(w.ChannelId, inVideoBranding).Media(file)
+	call := w.Service.Watermarks.Set(w.ChannelId, inVideoBranding).Media(
+		progress.File(w.Ctx, file),
+	)
 	if w.OnBehalfOfContentOwner != "" {
 		call = call.OnBehalfOfContentOwner(w.OnBehalfOfContentOwner)
 	}
@@ -138,6 +141,7 @@ func WithOffsetType(offsetType string) Option {
 var (
 	WithChannelId = common.WithChannelId[*Watermark]
 	WithService   = common.WithService[*Watermark]
+	WithContext   = common.WithContext[*Watermark]
 
 	WithOnBehalfOfContentOwner = common.WithOnBehalfOfContentOwner[*Watermark]
 )